@@ -3,64 +3,431 @@ package main
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
+	"os/signal"
+	"slices"
+	"strconv"
+	"syscall"
 	"time"
 
 	_ "github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
 	_ "github.com/jackc/pgx/v5/stdlib"
 
 	"uuid-vs-autoincreament/internal/bench"
 )
 
 func main() {
+	err := run(os.Args[1:], os.Stdout)
+	var fe *failure
+	switch {
+	case err == nil:
+	case errors.Is(err, flag.ErrHelp):
+		// -h / -help は使い方を出して正常終了する。
+	case errors.As(err, &fe):
+		slog.Error(fe.msg, "err", fe.err)
+		os.Exit(1)
+	case errors.Is(err, errStrategiesFailed):
+		// 失敗した方式は run の中で 1 件ずつ記録済み。
+		os.Exit(1)
+	default:
+		// フラグの解析エラーは FlagSet が stderr へ出している。
+		os.Exit(2)
+	}
+}
+
+// run は args を解析してベンチマークを実行し、計測結果と一覧を stdout へ書く。
+// 診断ログは stderr へ出し、stdout には書かない。
+func run(args []string, stdout io.Writer) error {
 	// ベンチマークの既定設定を読み込み、CLI 引数で上書き可能にする。
 	cfg := bench.DefaultConfig()
-	bench.RegisterFlags(flag.CommandLine, &cfg)
-	flag.Parse()
+	fs := flag.NewFlagSet("benchmark_ids", flag.ContinueOnError)
+	bench.RegisterFlags(fs, &cfg)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	// プリセットはコマンドラインで明示されたフラグを上書きしない。
+	explicit := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+
+	// 診断ログは stderr へ出し、stdout は計測結果専用にする。
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: cfg.LogLevel})))
 
 	// 実行前に最低限の入力値を検証する。
+	if err := bench.ApplyPreset(&cfg, explicit); err != nil {
+		return failed("invalid config", err)
+	}
 	if err := bench.ValidateConfig(cfg); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		return failed("invalid config", err)
 	}
 
-	// MySQL 接続を初期化する（ドライバは blank import で登録済み）。
-	mysqlDB, err := sql.Open("mysql", bench.MySQLDSN(cfg))
-	if err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+	// -list-strategies は方式の一覧を出して終わる。
+	if cfg.ListStrategies {
+		fmt.Fprint(stdout, bench.FormatStrategies(bench.Strategies()))
+		return nil
 	}
-	defer mysqlDB.Close()
 
-	// PostgreSQL 接続を初期化する（pgx stdlib ドライバを利用）。
-	pgDB, err := sql.Open("pgx", bench.PGDSN(cfg))
-	if err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+	// -export-ddl は計測で作るテーブルの CREATE 文を出して終わる。
+	if cfg.ExportDDL {
+		fmt.Fprint(stdout, bench.ExportDDL(cfg))
+		return nil
+	}
+
+	// -micro は DB へ接続せず、クライアント側の UUID 変換コストだけを計測して終わる。
+	if cfg.Micro {
+		fmt.Fprint(stdout, bench.FormatMicro(bench.RunMicro(cfg.Rows)))
+		return nil
+	}
+
+	// -uuid-contention も DB へ接続せず、並列時の UUID 生成のスループットだけを計測して終わる。
+	if cfg.UUIDContention > 0 {
+		fmt.Fprint(stdout, bench.FormatUUIDContention(bench.RunUUIDContention(cfg.Rows, cfg.UUIDContention)))
+		return nil
+	}
+
+	// 共有 DB のディスクを埋めないよう、しきい値を超える -rows は接続前に確認する。
+	// -setup-only は行を入れないため確認しない。
+	if !cfg.SetupOnly {
+		if err := bench.ConfirmRows(cfg, os.Stdin, os.Stderr, isTerminal(os.Stdin)); err != nil {
+			return failed("rows confirmation failed", err)
+		}
+	}
+
+	// 接続先が明示されていなければ、ホスト/ポート等の個別フラグから DSN を組み立てる。
+	// URL が指定されていれば個別フラグの組み立ては使わない（形式は ValidateConfig で検証済み）。
+	mysqlDSNs := []string{bench.MySQLDSN(cfg)}
+	if cfg.MySQLURL != "" {
+		d, err := bench.MySQLURLDSN(cfg.MySQLURL, cfg)
+		if err != nil {
+			return failed("invalid mysql-url", err)
+		}
+		mysqlDSNs = []string{d}
+	}
+	if len(cfg.MySQLDSNs) > 0 {
+		mysqlDSNs = mysqlDSNs[:0]
+		for _, dsn := range cfg.MySQLDSNs {
+			d, err := bench.MySQLTargetDSN(dsn, cfg)
+			if err != nil {
+				return failed("invalid mysql-dsn", err)
+			}
+			mysqlDSNs = append(mysqlDSNs, d)
+		}
+	}
+	pgDSNs := []string{bench.PGDSN(cfg)}
+	if cfg.PGURL != "" {
+		d, err := bench.PGURLDSN(cfg.PGURL, cfg)
+		if err != nil {
+			return failed("invalid pg-url", err)
+		}
+		pgDSNs = []string{d}
+	}
+	if len(cfg.PGDSNs) > 0 {
+		pgDSNs = pgDSNs[:0]
+		for _, dsn := range cfg.PGDSNs {
+			d, err := bench.PGTargetDSN(dsn, cfg)
+			if err != nil {
+				return failed("invalid pg-dsn", err)
+			}
+			pgDSNs = append(pgDSNs, d)
+		}
 	}
-	defer pgDB.Close()
 
+	// Ctrl-C / SIGTERM で計測ループを中断できるようにする。
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 	// 長時間実行を想定しつつ、無限待ちを避けるため全体タイムアウトを設定する。
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Minute)
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Minute)
 	defer cancel()
 
+	// MySQL は go-sql-driver、PostgreSQL は pgx stdlib ドライバで接続する（blank import で登録済み）。
 	// 実ベンチ前に DB 到達性を確認し、失敗時は即時終了する。
-	if err := mysqlDB.PingContext(ctx); err != nil {
-		fmt.Fprintln(os.Stderr, "mysql ping failed:", err)
-		os.Exit(1)
+	mysqlTargets, err := openTargets(ctx, "mysql", "mysql", mysqlDSNs)
+	if err != nil {
+		return failed("mysql connect failed", err)
 	}
-	if err := pgDB.PingContext(ctx); err != nil {
-		fmt.Fprintln(os.Stderr, "postgres ping failed:", err)
-		os.Exit(1)
+	// MySQL だけのプリセットでは PostgreSQL へ接続しない。
+	var pgTargets []bench.Target
+	if !cfg.SkipPostgres {
+		pgTargets, err = openTargets(ctx, "pgx", "postgres", pgDSNs)
+		if err != nil {
+			return failed("postgres connect failed", err)
+		}
+	}
+
+	// 接続プールの大きさを決め、-connection-warmup なら計測前に接続を開いておく。
+	for _, t := range slices.Concat(mysqlTargets, pgTargets) {
+		bench.ConfigurePool(t.DB, cfg)
+		if err := bench.WarmConnections(ctx, t.DB, cfg); err != nil {
+			return failed("connection warmup failed", err)
+		}
+	}
+
+	// -setup-only はテーブルを作り直したところで終わる。
+	if cfg.SetupOnly {
+		if err := bench.SetupTargets(ctx, mysqlTargets, pgTargets, cfg); err != nil {
+			return failed("setup failed", err)
+		}
+		return nil
+	}
+
+	// 耐久性などの DB 側設定を計測前に反映する。グローバル変数は共有サーバの他の接続にも効くため、
+	// 成功・失敗・シグナルによる中断のいずれでも終了前に元の値へ戻す。
+	for _, t := range mysqlTargets {
+		restore, err := bench.ApplySessionSettings(ctx, t.DB, cfg)
+		if err != nil {
+			return failed("session settings failed", err)
+		}
+		defer func() {
+			if err := restore(context.WithoutCancel(ctx)); err != nil {
+				slog.Error("restore mysql global variables failed", "server", t.Label, "err", err)
+			}
+		}()
+	}
+
+	// 接続先のエンジン種別を記録し、マネージド系なら警告する。
+	var pgDB *sql.DB
+	if len(pgTargets) > 0 {
+		pgDB = pgTargets[0].DB
+	}
+	md, err := bench.DetectServers(ctx, mysqlTargets[0].DB, pgDB)
+	if err != nil {
+		return failed("server detection failed", err)
+	}
+	// 全件がキャッシュに収まる設定では UUID の不利が現れにくいため、計測前に知らせる。
+	for _, w := range bench.CacheWarnings(md, cfg) {
+		slog.Warn("dataset likely fully cached", "detail", w)
+	}
+	md.RunID = uuid.NewString()
+	md.StartedAt = time.Now().UTC()
+	md.Aggregate = bench.AggregateLabel(cfg.Aggregate, cfg.AggregateTrim)
+	md.StatementMode = "prepared"
+	if cfg.NoPrepare {
+		md.StatementMode = "adhoc"
+	}
+	if cfg.SimulatedRTT > 0 {
+		md.SimulatedRTT = cfg.SimulatedRTT.String()
+	}
+	md.PGUnlogged = cfg.PGUnlogged
+	md.PGTableAutovacuum = cfg.PGAutovacuum
+	md.MySQLDriverOptions = bench.MySQLDriverOptions(cfg)
+	md.Analyze = strconv.FormatBool(cfg.Analyze)
+	if cfg.PayloadNullable {
+		md.PayloadNulls = strconv.FormatFloat(cfg.PayloadNullFraction, 'f', -1, 64)
+	}
+	md.UUIDKeys = "v4"
+	if cfg.UUIDNamespace != uuid.Nil {
+		md.UUIDKeys = "v5:" + cfg.UUIDNamespace.String()
 	}
 
 	// 各方式のベンチマークを順に実行し、CSV 形式で結果を出力する。
-	results, err := bench.RunAll(ctx, mysqlDB, pgDB, cfg)
+	// 長時間の実行でも進み具合が分かるよう、方式ごとの結果は終わり次第 stderr へ記録する。
+	// -format jsonl では結果を方式ごとに stdout へ 1 行ずつ流す。
+	onResult := logResult
+	var stream *bench.JSONLWriter
+	if cfg.Format == "jsonl" {
+		stream = bench.NewJSONLWriter(stdout)
+		onResult = func(r bench.Result) {
+			logResult(r)
+			r.Label = cfg.Label
+			stream.Write(r)
+		}
+	}
+	// -otel-endpoint 指定時だけフェーズごとのスパンを記録し、計測後にまとめて送る。
+	var tracer *bench.Tracer
+	if cfg.OTelEndpoint != "" {
+		tracer = bench.NewTracer(cfg.OTelEndpoint)
+		ctx = bench.WithTracer(ctx, tracer)
+	}
+	runner := bench.Runner{Config: cfg, OnResult: onResult}
+	// -tui は stdout が端末のときだけ進捗を描く。描画が崩れないよう、その間の診断ログは警告以上に絞る。
+	var tui *bench.TUI
+	if f, ok := stdout.(*os.File); ok && cfg.TUI && isTerminal(f) {
+		tui = bench.NewTUI(f)
+		runner.OnProgress = tui.Progress
+		runner.OnResult = tui.Result
+		slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: max(cfg.LogLevel, slog.LevelWarn)})))
+	}
+	// 時刻を埋め込む方式のキーが逆行しうるため、計測の間にシステム時刻が戻ったかを単調時計と比べて残す。
+	clockStart := time.Now()
+	results, err := runner.Run(ctx, mysqlTargets, pgTargets)
+	// -repeat は同じ設定で全体を繰り返し、insert / point / range を平均と 95% 信頼区間にまとめる。
+	if err == nil && cfg.Repeat > 1 {
+		runs := [][]bench.Result{results}
+		for i := 2; i <= cfg.Repeat && err == nil; i++ {
+			slog.Info("repeat run start", "run", i, "of", cfg.Repeat)
+			var again []bench.Result
+			again, err = runner.Run(ctx, mysqlTargets, pgTargets)
+			runs = append(runs, again)
+		}
+		results = bench.MergeRepeats(runs)
+	}
+	// -determinism-check は同じ設定でもう一度全体を実行し、方式の順位が変わらないかを比べる。
+	// 出力する結果は 1 回目のもの。
+	var rerun []bench.Result
+	if err == nil && cfg.DeterminismCheck {
+		slog.Info("determinism check: second run start")
+		rerun, err = runner.Run(ctx, mysqlTargets, pgTargets)
+	}
+	if d := bench.WallClockBackward(clockStart, time.Now()); d > 0 {
+		md.ClockBackward = d.String()
+		slog.Warn("system clock moved backward during the run", "behind", d)
+	}
+	if tui != nil {
+		tui.Close()
+		slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: cfg.LogLevel})))
+	}
+	// 失敗した実行のスパンも調査に使えるよう、結果の確認より先に送る。
+	if tracer != nil {
+		if err := tracer.Flush(context.WithoutCancel(ctx)); err != nil {
+			slog.Warn("otel export failed", "endpoint", cfg.OTelEndpoint, "err", err)
+		}
+	}
 	if err != nil {
-		fmt.Fprintln(os.Stderr, "benchmark failed:", err)
-		os.Exit(1)
+		return failed("benchmark failed", err)
+	}
+
+	// 指定時は pgx ネイティブのプール + パイプライン送信でも計測して並べる。
+	if cfg.PGXPool && !cfg.SkipPostgres {
+		for i, dsn := range pgDSNs {
+			pool, err := pgxpool.New(ctx, dsn)
+			if err != nil {
+				return failed("pgxpool open failed", err)
+			}
+			pgxResults, err := bench.RunPGXPool(ctx, pool, cfg)
+			pool.Close()
+			if err != nil {
+				return failed("pgxpool benchmark failed", err)
+			}
+			for j := range pgxResults {
+				pgxResults[j].Server = pgTargets[i].Label
+				onResult(pgxResults[j])
+			}
+			results = append(results, pgxResults...)
+		}
+	}
+	// 選択の誤りで 1 方式も計測しなかった場合は、空の表を出さずに失敗として終了する。
+	if err := bench.CheckResults(cfg, results); err != nil {
+		return failed("no results", err)
+	}
+	// 計測した表がキャッシュに収まっていたかを残し、インメモリの結果を I/O 込みの結果と取り違えないようにする。
+	md.MySQLMemoryFit = bench.MemoryFit("mysql", md.MySQLBufferPool, results, cfg)
+	md.PGMemoryFit = bench.MemoryFit("postgres", md.PGSharedBuffers, results, cfg)
+	// -simulated-rtt だけでも insert_rows_per_sec は出るが、行数を選んだときにだけ記録する。
+	if cfg.AutoScaleRows > 0 {
+		md.AutoScaledRows = bench.AutoScaledRows(results)
+	}
+	// 実行ラベルは結果の各行にも付け、別々の実行を 1 ファイルに集めても区別できるようにする。
+	md.Label = cfg.Label
+	for i := range results {
+		results[i].Label = cfg.Label
+	}
+
+	// jsonl の結果は計測中に書き出し済みのため、書き込みエラーだけを確かめる。
+	if stream != nil {
+		if err := stream.Err(); err != nil {
+			return failed("jsonl output failed", err)
+		}
+	}
+	// all はファイルへ全形式を書いたうえで、stdout には通常の CSV を出す。
+	if cfg.Format == "all" {
+		paths, err := bench.WriteAllFormats(cfg.OutPrefix, md, results, cfg.Precision, cfg.Gzip)
+		if err != nil {
+			return failed("write outputs failed", err)
+		}
+		slog.Info("results written", "files", paths)
+	}
+	if err := bench.WriteResults(stdout, cfg, bench.Report{Metadata: md, Results: results, Rerun: rerun}); err != nil {
+		return failed("write results failed", err)
 	}
-	fmt.Println(bench.FormatResults(results))
+
+	// 夜間実行などで履歴を貯める場合は追記ログへも書き出す。
+	if cfg.AppendPath != "" {
+		if err := bench.AppendResults(cfg.AppendPath, md, results); err != nil {
+			return failed("append failed", err)
+		}
+		slog.Info("results appended", "path", cfg.AppendPath, "rows", len(results))
+	}
+
+	// -fail-fast=false で失敗した方式があれば、結果を出し切ったうえで失敗として終了する。
+	if failed := bench.FailedResults(results); len(failed) > 0 {
+		for _, r := range failed {
+			slog.Error("strategy failed", "db", r.DB, "table", r.Table, "server", r.Server, "err", r.Err)
+		}
+		return errStrategiesFailed
+	}
+	return nil
+}
+
+// openTargets は dsns の各接続を開いて疎通を確認する。
+// 接続先が複数ある場合は、結果を区別できるようサーバのバージョンからラベルを付ける。
+// 接続はプロセス終了まで使い続けるため閉じない。
+func openTargets(ctx context.Context, driver, kind string, dsns []string) ([]bench.Target, error) {
+	targets := make([]bench.Target, 0, len(dsns))
+	seen := make(map[string]int)
+	for _, dsn := range dsns {
+		db, err := sql.Open(driver, dsn)
+		if err != nil {
+			return nil, err
+		}
+		if err := db.PingContext(ctx); err != nil {
+			return nil, fmt.Errorf("ping failed: %w", err)
+		}
+		t := bench.Target{DB: db}
+		if len(dsns) > 1 {
+			version, err := bench.ServerVersion(ctx, db, kind)
+			if err != nil {
+				return nil, err
+			}
+			t.Label = bench.ServerLabel(kind, version)
+			// 同じバージョンのサーバが並ぶ場合は連番で区別する。
+			seen[t.Label]++
+			if n := seen[t.Label]; n > 1 {
+				t.Label = fmt.Sprintf("%s#%d", t.Label, n)
+			}
+		}
+		slog.Info("connected", "db", kind, "server", t.Label)
+		targets = append(targets, t)
+	}
+	return targets, nil
+}
+
+// logResult は計測が終わった方式の結果を 1 行で stderr へ記録する。
+func logResult(r bench.Result) {
+	if r.Err != "" {
+		slog.Warn("result", "db", r.DB, "table", r.Table, "server", r.Server, "err", r.Err)
+		return
+	}
+	slog.Info("result", "db", r.DB, "table", r.Table, "server", r.Server, "insert_sec", r.InsertSeconds, "point_sec", r.PointSeconds, "range_sec", r.RangeSeconds)
+}
+
+// isTerminal は f が端末（キャラクタデバイス）なら真を返す。パイプや CI では偽になる。
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// errStrategiesFailed は -fail-fast=false で失敗した方式があったことを表す。結果は出し切ってある。
+var errStrategiesFailed = errors.New("some strategies failed")
+
+// failure は run を打ち切ったエラーに、どの段階で失敗したかの説明を添える。
+// main は msg を見出しにして記録し、終了コード 1 で終了する。
+type failure struct {
+	msg string
+	err error
+}
+
+func (f *failure) Error() string { return f.msg + ": " + f.err.Error() }
+
+func (f *failure) Unwrap() error { return f.err }
+
+// failed は msg の段階で err により失敗したことを表すエラーを返す。
+// 接続は閉じずにプロセスの終了に任せる。
+func failed(msg string, err error) error {
+	return &failure{msg: msg, err: err}
 }
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	t.Run("一覧_list-strategiesはDBへ接続せず方式を出す", func(t *testing.T) {
+		var out bytes.Buffer
+		if err := run([]string{"-list-strategies"}, &out); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(out.String(), "bench_auto") || !strings.Contains(out.String(), "bench_uuid_bin") {
+			t.Fatalf("output = %q, want bench_auto and bench_uuid_bin", out.String())
+		}
+	})
+
+	t.Run("DDL_export-ddlはCREATE文を出す", func(t *testing.T) {
+		var out bytes.Buffer
+		if err := run([]string{"-export-ddl"}, &out); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(out.String(), "CREATE TABLE bench_auto") {
+			t.Fatalf("output = %q, want CREATE TABLE bench_auto", out.String())
+		}
+	})
+
+	t.Run("設定エラー_検証に失敗したらfailureを返す", func(t *testing.T) {
+		var fe *failure
+		if err := run([]string{"-rows", "0", "-list-strategies"}, io.Discard); !errors.As(err, &fe) || fe.msg != "invalid config" {
+			t.Fatalf("err = %v, want invalid config failure", err)
+		}
+	})
+
+	t.Run("フラグ_未知のフラグは解析エラー", func(t *testing.T) {
+		err := run([]string{"-no-such-flag"}, io.Discard)
+		var fe *failure
+		if err == nil || errors.As(err, &fe) || errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("err = %v, want flag parse error", err)
+		}
+	})
+}
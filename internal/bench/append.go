@@ -0,0 +1,66 @@
+package bench
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// appendRecord は追記ログ 1 行ぶんの JSON 表現。
+// Result のフィールドは埋め込みでフラットに展開される。
+type appendRecord struct {
+	RunID     string    `json:"run_id"`
+	StartedAt time.Time `json:"started_at"`
+	Result
+}
+
+// AppendResults は今回の結果を run_id / started_at 付きで path へ追記する。
+// 拡張子が .jsonl / .json なら JSON Lines、それ以外は CSV として扱う。
+// CSV のヘッダはファイルが空のときだけ書き、既存ヘッダと列が食い違う場合はエラーにする。
+func AppendResults(path string, md Metadata, results []Result) error {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("open append file: %w", err)
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jsonl", ".json":
+		enc := json.NewEncoder(f)
+		for _, r := range results {
+			if err := enc.Encode(appendRecord{RunID: md.RunID, StartedAt: md.StartedAt, Result: r}); err != nil {
+				return fmt.Errorf("append json line: %w", err)
+			}
+		}
+		return nil
+	}
+
+	cols := columnsFor(results)
+	header := append([]string{"run_id", "started_at"}, columnNames(cols)...)
+	first, err := bufio.NewReader(f).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return fmt.Errorf("read append file header: %w", err)
+	}
+	w := csv.NewWriter(f)
+	if first == "" {
+		if err := w.Write(header); err != nil {
+			return err
+		}
+	} else if strings.TrimRight(first, "\r\n") != strings.Join(header, ",") {
+		return fmt.Errorf("append file %s has a different header; start a new file", path)
+	}
+	for _, r := range results {
+		row := append([]string{md.RunID, md.StartedAt.Format(time.RFC3339)}, columnValues(cols, r, DefaultPrecision)...)
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,72 @@
+package bench
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAppendResults(t *testing.T) {
+	md := Metadata{RunID: "run-1", StartedAt: time.Date(2026, 2, 14, 0, 0, 0, 0, time.UTC)}
+	results := []Result{{DB: "mysql", Table: "bench_auto", InsertRows: 10, InsertSeconds: 0.5}}
+
+	t.Run("追記ログ_CSVはヘッダを1回だけ書く", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "history.csv")
+		for i := 0; i < 2; i++ {
+			if err := AppendResults(path, md, results); err != nil {
+				t.Fatalf("AppendResults error: %v", err)
+			}
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+		if len(lines) != 3 {
+			t.Fatalf("lines = %d, want 3:\n%s", len(lines), b)
+		}
+		if !strings.HasPrefix(lines[0], "run_id,started_at,db,table,") {
+			t.Fatalf("unexpected header: %s", lines[0])
+		}
+		if !strings.HasPrefix(lines[2], "run-1,2026-02-14T00:00:00Z,mysql,bench_auto,10,") {
+			t.Fatalf("unexpected row: %s", lines[2])
+		}
+	})
+
+	t.Run("追記ログ_ヘッダ不一致はエラー", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "history.csv")
+		if err := os.WriteFile(path, []byte("a,b,c\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := AppendResults(path, md, results); err == nil {
+			t.Fatal("AppendResults error = nil, want header mismatch")
+		}
+	})
+
+	t.Run("追記ログ_JSONLinesは1行1オブジェクト", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "history.jsonl")
+		for i := 0; i < 2; i++ {
+			if err := AppendResults(path, md, results); err != nil {
+				t.Fatalf("AppendResults error: %v", err)
+			}
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("lines = %d, want 2", len(lines))
+		}
+		var got map[string]any
+		if err := json.Unmarshal([]byte(lines[1]), &got); err != nil {
+			t.Fatalf("line is not JSON: %v", err)
+		}
+		if got["run_id"] != "run-1" || got["table"] != "bench_auto" {
+			t.Fatalf("unexpected record: %v", got)
+		}
+	})
+}
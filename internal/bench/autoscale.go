@@ -0,0 +1,88 @@
+package bench
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// autoScaleCalibrationRows は -auto-scale-rows の較正で方式ごとに挿入する行数。
+// 目標時間から決める行数もこれを下限にする。
+const autoScaleCalibrationRows = 1000
+
+// scaledRows は較正で 1 行あたり perRow 秒かかった方式について、挿入フェーズがおよそ target で
+// 終わる行数を返す。autoScaleCalibrationRows 未満にはせず、max（-rows）を上限にする。
+func scaledRows(target time.Duration, perRow float64, max int) int {
+	if perRow <= 0 {
+		return max
+	}
+	rows := int(target.Seconds() / perRow)
+	if rows > max {
+		rows = max
+	}
+	if rows < autoScaleCalibrationRows {
+		rows = min(autoScaleCalibrationRows, max)
+	}
+	return rows
+}
+
+// autoScaleRows は cfg.AutoScaleRows が正なら、bench を autoScaleCalibrationRows 行で 1 度走らせて
+// 1 行あたりの挿入時間を測り、挿入フェーズが cfg.AutoScaleRows 程度で終わる行数を Rows にした cfg を返す。
+// 較正で入れた行は TRUNCATE で消してから返す。0 なら cfg をそのまま返す。
+func autoScaleRows(ctx context.Context, db *sql.DB, cfg Config, kind, table string, bench func(context.Context, *sql.DB, Config) (Result, error)) (Config, error) {
+	if cfg.AutoScaleRows <= 0 {
+		return cfg, nil
+	}
+	cal := cfg
+	cal.Rows = min(autoScaleCalibrationRows, cfg.Rows)
+	cal.Lookups = min(cfg.Lookups, cal.Rows)
+	cal.TargetErrorMargin = 0
+	cal.LatencyDumpDir = ""
+	cal.ReverseLookup = false
+	r, err := bench(ctx, db, cal)
+	if err != nil {
+		return cfg, fmt.Errorf("auto-scale-rows calibration: %w", err)
+	}
+	truncate := "TRUNCATE TABLE " + table
+	if kind == "postgres" {
+		truncate += " RESTART IDENTITY"
+	}
+	if _, err := db.ExecContext(ctx, truncate); err != nil {
+		return cfg, fmt.Errorf("auto-scale-rows calibration: %w", err)
+	}
+	perRow := perOp(r.InsertSeconds, r.InsertRows)
+	cfg.Rows = scaledRows(cfg.AutoScaleRows, perRow, cfg.Rows)
+	slog.Info("auto-scaled rows", "db", kind, "table", table, "calibration_us_per_row", perRow*1e6, "rows", cfg.Rows)
+	return cfg, nil
+}
+
+// AutoScaledRows は -auto-scale-rows で方式ごとに選んだ行数を、メタデータ用に
+// "mysql.bench_auto:50000,mysql.bench_uuid_char:12000" の形でまとめる。接続先ラベルがあれば
+// "mysql(label).bench_auto:50000" とする。行数を選んだ方式（InsertRowsPerSec のある結果）がなければ空。
+func AutoScaledRows(results []Result) string {
+	var parts []string
+	for _, r := range results {
+		// 並列挿入は -simulated-rtt でも InsertRowsPerSec を持つが、行数は選ばない。
+		if r.InsertRowsPerSec <= 0 || r.Workers > 0 {
+			continue
+		}
+		name := r.DB
+		if r.Server != "" {
+			name += "(" + r.Server + ")"
+		}
+		parts = append(parts, name+"."+r.Table+":"+strconv.Itoa(r.InsertRows))
+	}
+	return strings.Join(parts, ",")
+}
+
+// insertThroughput は cfg.AutoScaleRows が正なら方式ごとに行数が違っても比べられるよう、
+// cfg.SimulatedRTT が正なら遅延を入れたときの実効的な速さとして、1 秒あたりの挿入行数を r へ書き込む。
+func insertThroughput(cfg Config, r *Result) {
+	if (cfg.AutoScaleRows > 0 || cfg.SimulatedRTT > 0) && r.InsertSeconds > 0 {
+		r.InsertRowsPerSec = float64(r.InsertRows) / r.InsertSeconds
+	}
+}
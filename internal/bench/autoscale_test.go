@@ -0,0 +1,54 @@
+package bench
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScaledRows(t *testing.T) {
+	cases := []struct {
+		name   string
+		perRow float64
+		max    int
+		want   int
+	}{
+		{"目標時間に収まる行数", 100e-6, 1_000_000, 100_000},
+		{"rowsを上限にする", 10e-6, 50_000, 50_000},
+		{"較正の行数を下限にする", 0.1, 50_000, autoScaleCalibrationRows},
+		{"rowsが較正の行数より少なければrows", 0.1, 500, 500},
+		{"計れなければrows", 0, 50_000, 50_000},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := scaledRows(10*time.Second, c.perRow, c.max); got != c.want {
+				t.Fatalf("scaledRows = %d, want %d", got, c.want)
+			}
+		})
+	}
+}
+
+func TestAutoScaledRows(t *testing.T) {
+	t.Run("行数を選んだ方式だけをまとめる", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.AutoScaleRows = 10 * time.Second
+		a := Result{DB: "mysql", Table: "bench_auto", InsertRows: 50000, InsertSeconds: 2}
+		b := Result{DB: "mysql", Server: "m84", Table: "bench_uuid_char", InsertRows: 12000, InsertSeconds: 3}
+		insertThroughput(cfg, &a)
+		insertThroughput(cfg, &b)
+		if a.InsertRowsPerSec != 25000 || b.InsertRowsPerSec != 4000 {
+			t.Fatalf("rows/sec = %v, %v", a.InsertRowsPerSec, b.InsertRowsPerSec)
+		}
+		got := AutoScaledRows([]Result{a, b, {DB: "mysql", Table: "bench_auto_concurrent", InsertRows: 50000, InsertSeconds: 1, InsertRowsPerSec: 50000, Workers: 4}})
+		if want := "mysql.bench_auto:50000,mysql(m84).bench_uuid_char:12000"; got != want {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("未指定なら何もしない", func(t *testing.T) {
+		r := Result{InsertRows: 10, InsertSeconds: 1}
+		insertThroughput(DefaultConfig(), &r)
+		if r.InsertRowsPerSec != 0 || AutoScaledRows([]Result{r}) != "" {
+			t.Fatalf("got %v", r.InsertRowsPerSec)
+		}
+	})
+}
@@ -0,0 +1,38 @@
+package bench
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// uuidBase64Len は UUID 16 バイトをパディングなしの Base64url にした長さ。
+const uuidBase64Len = 22
+
+// EncodeUUIDBase64 は u をパディングなしの Base64url（22 文字）にする。
+// URL やファイル名にそのまま使え、CHAR(36) より 14 文字短い。
+func EncodeUUIDBase64(u uuid.UUID) string {
+	return base64.RawURLEncoding.EncodeToString(u[:])
+}
+
+// DecodeUUIDBase64 は EncodeUUIDBase64 の出力を UUID へ戻す。
+// 長さや文字が不正な入力は明示的にエラーを返す。
+func DecodeUUIDBase64(s string) (uuid.UUID, error) {
+	if len(s) != uuidBase64Len {
+		return uuid.Nil, fmt.Errorf("base64 uuid length must be %d, got %d", uuidBase64Len, len(s))
+	}
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("base64 uuid %q: %w", s, err)
+	}
+	return BytesToUUID(b)
+}
+
+// benchUUIDBase64 は UUID を 22 文字の Base64url 文字列で保存する VARCHAR(22) 主キー (bench_uuid_b64) を計測する。
+// Base64 は大文字小文字を区別するため、列はバイナリ比較の照合順序で作る。
+func benchUUIDBase64(ctx context.Context, db *sql.DB, cfg Config, kind string) (Result, error) {
+	return benchUUIDText(ctx, db, cfg, kind, "bench_uuid_b64", uuidBase64Key)
+}
@@ -0,0 +1,40 @@
+package bench
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestUUIDBase64(t *testing.T) {
+	t.Run("Base64_22文字で往復できる", func(t *testing.T) {
+		for _, u := range []uuid.UUID{uuid.Nil, uuidBytesProbe, uuid.MustParse("6af613b6-569c-5c22-9c37-2ed93f31d3af"), uuid.New()} {
+			s := EncodeUUIDBase64(u)
+			if len(s) != 22 {
+				t.Fatalf("EncodeUUIDBase64(%s) = %q, want 22 chars", u, s)
+			}
+			got, err := DecodeUUIDBase64(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != u {
+				t.Fatalf("round trip = %s, want %s", got, u)
+			}
+		}
+	})
+
+	t.Run("Base64_URLで安全な文字だけを使う", func(t *testing.T) {
+		u := uuid.UUID{0xfb, 0xff, 0xbf, 0xfb, 0xff, 0xbf, 0xfb, 0xff, 0xbf, 0xfb, 0xff, 0xbf, 0xfb, 0xff, 0xbf, 0xfb}
+		if got, want := EncodeUUIDBase64(u), "-_-_-_-_-_-_-_-_-_-_-w"; got != want {
+			t.Fatalf("EncodeUUIDBase64 = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("Base64_不正な入力はエラー", func(t *testing.T) {
+		for _, s := range []string{"", "short", "AAAAAAAAAAAAAAAAAAAAAAAA", "AAAAAAAAAAAAAAAAAAAA+A", "AAAAAAAAAAAAAAAAAAAA=="} {
+			if _, err := DecodeUUIDBase64(s); err == nil {
+				t.Fatalf("DecodeUUIDBase64(%q) error = nil, want error", s)
+			}
+		}
+	})
+}
@@ -1,6 +1,8 @@
 package bench
 
 import (
+	"errors"
+	"flag"
 	"strings"
 	"testing"
 
@@ -17,12 +19,223 @@ func TestDefaultConfig(t *testing.T) {
 		if cfg.Lookups != 20000 {
 			t.Fatalf("Lookups = %d, want 20000", cfg.Lookups)
 		}
+		if cfg.Tenants != 16 {
+			t.Fatalf("Tenants = %d, want 16", cfg.Tenants)
+		}
 		if cfg.MySQLPort != 3306 {
 			t.Fatalf("MySQLPort = %d, want 3306", cfg.MySQLPort)
 		}
 		if cfg.PGPort != 5432 {
 			t.Fatalf("PGPort = %d, want 5432", cfg.PGPort)
 		}
+		if !cfg.Analyze {
+			t.Fatal("Analyze = false, want true")
+		}
+		if !cfg.MySQLVersionGate {
+			t.Fatal("MySQLVersionGate = false, want true")
+		}
+		if !cfg.CollationWarn {
+			t.Fatal("CollationWarn = false, want true")
+		}
+	})
+}
+
+func TestShuffledIDs(t *testing.T) {
+	t.Run("シャッフル連番_1からnの順列を返す", func(t *testing.T) {
+		ids := ShuffledIDs(100, 1)
+		seen := make(map[int64]bool, len(ids))
+		for _, id := range ids {
+			if id < 1 || id > 100 || seen[id] {
+				t.Fatalf("not a permutation of 1..100: %v", ids)
+			}
+			seen[id] = true
+		}
+		if len(seen) != 100 {
+			t.Fatalf("len = %d, want 100", len(seen))
+		}
+	})
+
+	t.Run("シャッフル連番_同じシードなら同じ順序", func(t *testing.T) {
+		a := ShuffledIDs(50, 7)
+		b := ShuffledIDs(50, 7)
+		for i := range a {
+			if a[i] != b[i] {
+				t.Fatalf("ids[%d] differ: %d vs %d", i, a[i], b[i])
+			}
+		}
+	})
+}
+
+func TestUUIDv5(t *testing.T) {
+	t.Run("UUIDv5_同じ名前空間と行番号なら同じ値", func(t *testing.T) {
+		a := UUIDv5(uuid.NameSpaceURL, 7)
+		if b := UUIDv5(uuid.NameSpaceURL, 7); a != b {
+			t.Fatalf("UUIDv5 not deterministic: %s vs %s", a, b)
+		}
+		if a.Version() != 5 || a.Variant() != uuid.RFC4122 {
+			t.Fatalf("UUIDv5 = %s, version %d variant %s", a, a.Version(), a.Variant())
+		}
+	})
+
+	t.Run("UUIDv5_既知の値と一致する", func(t *testing.T) {
+		// uuid5(NAMESPACE_DNS, "0") を Python の uuid モジュールで求めた値。
+		if got, want := UUIDv5(uuid.NameSpaceDNS, 0).String(), "6af613b6-569c-5c22-9c37-2ed93f31d3af"; got != want {
+			t.Fatalf("UUIDv5 = %s, want %s", got, want)
+		}
+	})
+
+	t.Run("UUIDv5_行番号や名前空間が違えば別の値", func(t *testing.T) {
+		if UUIDv5(uuid.NameSpaceURL, 1) == UUIDv5(uuid.NameSpaceURL, 2) {
+			t.Fatal("different rows produced the same UUID")
+		}
+		if UUIDv5(uuid.NameSpaceURL, 1) == UUIDv5(uuid.NameSpaceDNS, 1) {
+			t.Fatal("different namespaces produced the same UUID")
+		}
+	})
+
+	t.Run("UUIDv5_名前空間未設定なら乱数のv4", func(t *testing.T) {
+		if v := newUUID(DefaultConfig(), 0).Version(); v != 4 {
+			t.Fatalf("version = %d, want 4", v)
+		}
+		cfg := DefaultConfig()
+		cfg.UUIDNamespace = uuid.NameSpaceOID
+		if got := newUUID(cfg, 3); got != UUIDv5(uuid.NameSpaceOID, 3) {
+			t.Fatalf("newUUID = %s, want UUIDv5", got)
+		}
+	})
+}
+
+func TestParseUUIDNamespace(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    uuid.UUID
+		wantErr bool
+	}{
+		{"空は未設定", "", uuid.Nil, false},
+		{"定義済みの名前", "DNS", uuid.NameSpaceDNS, false},
+		{"UUID文字列", "6ba7b811-9dad-11d1-80b4-00c04fd430c8", uuid.NameSpaceURL, false},
+		{"nilは拒否", "00000000-0000-0000-0000-000000000000", uuid.Nil, true},
+		{"不正な文字列", "not-a-uuid", uuid.Nil, true},
+	}
+	for _, tt := range tests {
+		t.Run("名前空間_"+tt.name, func(t *testing.T) {
+			got, err := ParseUUIDNamespace(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseUUIDNamespace(%q) err = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("ParseUUIDNamespace(%q) = %s, want %s", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCount(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int
+		wantErr bool
+	}{
+		{"100000", 100000, false},
+		{"500k", 500000, false},
+		{"500K", 500000, false},
+		{"1M", 1000000, false},
+		{"1.5m", 1500000, false},
+		{"2G", 2000000000, false},
+		{"", 0, true},
+		{"k", 0, true},
+		{"-1k", 0, true},
+		{"1.5", 0, true},
+		{"1.0001k", 0, true},
+		{"1e3k", 0, true},
+		{"10x", 0, true},
+		{"99999999999G", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run("件数解析_"+tt.in, func(t *testing.T) {
+			got, err := ParseCount(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCount(%q) err = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("ParseCount(%q) = %d, want %d", tt.in, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("件数解析_フラグで接尾辞を受け付ける", func(t *testing.T) {
+		cfg := DefaultConfig()
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		RegisterFlags(fs, &cfg)
+		if err := fs.Parse([]string{"-rows", "1M", "-lookups", "20k"}); err != nil {
+			t.Fatal(err)
+		}
+		if cfg.Rows != 1000000 || cfg.Lookups != 20000 {
+			t.Fatalf("rows = %d, lookups = %d", cfg.Rows, cfg.Lookups)
+		}
+	})
+}
+
+func TestParseTableOption(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		wantKey string
+		wantErr bool
+	}{
+		{"MySQL", "mysql.bench_uuid_bin=ROW_FORMAT=COMPRESSED KEY_BLOCK_SIZE=8", "mysql.bench_uuid_bin", false},
+		{"PostgreSQL", "postgres.bench_uuid = WITH (autovacuum_enabled=false)", "postgres.bench_uuid", false},
+		{"DB名なし", "bench_uuid=WITH (fillfactor=70)", "", true},
+		{"オプションなし", "mysql.bench_auto=", "", true},
+		{"文の区切りを含む", "mysql.bench_auto=ENGINE=InnoDB; DROP TABLE x", "", true},
+	}
+	for _, tt := range tests {
+		t.Run("テーブルオプション解析_"+tt.name, func(t *testing.T) {
+			key, _, err := ParseTableOption(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if key != tt.wantKey {
+				t.Fatalf("key = %q, want %q", key, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestTenantPicker(t *testing.T) {
+	t.Run("テナント割り当て_偏りなしなら順番に割り当てる", func(t *testing.T) {
+		pick := TenantPicker(4, 0, 1)
+		for i, want := range []int64{0, 1, 2, 3, 0, 1} {
+			if got := pick(i); got != want {
+				t.Fatalf("pick(%d) = %d, want %d", i, got, want)
+			}
+		}
+	})
+
+	t.Run("テナント割り当て_Zipfなら先頭テナントへ偏る", func(t *testing.T) {
+		pick := TenantPicker(16, 1.2, 1)
+		counts := make([]int, 16)
+		for i := 0; i < 10000; i++ {
+			k := pick(i)
+			if k < 0 || k >= 16 {
+				t.Fatalf("pick(%d) = %d, out of range", i, k)
+			}
+			counts[k]++
+		}
+		if counts[0] <= counts[1] || counts[1] <= counts[15] {
+			t.Fatalf("counts not skewed toward tenant 0: %v", counts)
+		}
+	})
+
+	t.Run("テナント割り当て_同じシードなら同じ結果", func(t *testing.T) {
+		a := TenantPicker(16, 1.2, 7)
+		b := TenantPicker(16, 1.2, 7)
+		for i := 100; i >= 0; i-- {
+			if a(i) != b(i) {
+				t.Fatalf("pick(%d) differ: %d vs %d", i, a(i), b(i))
+			}
+		}
 	})
 }
 
@@ -65,6 +278,50 @@ func TestFormatResults(t *testing.T) {
 			t.Fatalf("missing csv row: %s", out)
 		}
 	})
+	t.Run("結果整形_小数桁数を指定できる", func(t *testing.T) {
+		out := FormatResultsPrecision([]Result{{DB: "mysql", Table: "bench_auto", InsertRows: 10, InsertSeconds: 1.23456, PointLookupCount: 5, PointSeconds: 0.5, RangeSeconds: 0.01}}, 2)
+		if !strings.Contains(out, "mysql,bench_auto,10,1.23,5,0.50,0.01") {
+			t.Fatalf("missing 2-digit row: %s", out)
+		}
+	})
+	t.Run("結果整形_ラベルがあれば先頭列に出す", func(t *testing.T) {
+		out := FormatResults([]Result{{Label: "ssd", DB: "mysql", Table: "bench_auto"}})
+		if !strings.Contains(out, "\nlabel,db,table,") || !strings.Contains(out, "\nssd,mysql,bench_auto,") {
+			t.Fatalf("missing leading label column: %s", out)
+		}
+	})
+	t.Run("結果整形_読み戻し計測があれば列を追加する", func(t *testing.T) {
+		out := FormatResults([]Result{
+			{DB: "mysql", Table: "bench_auto", InsertReadbackSeconds: 0.5},
+			{DB: "mysql", Table: "bench_uuid_bin"},
+		})
+		if !strings.Contains(out, "range_or_orderby_sec,insert_readback_sec\n") {
+			t.Fatalf("missing insert_readback_sec header: %s", out)
+		}
+		if !strings.Contains(out, "mysql,bench_uuid_bin,0,0.000000,0,0.000000,0.000000,0.000000") {
+			t.Fatalf("missing zero readback value: %s", out)
+		}
+	})
+}
+
+func TestFailedResults(t *testing.T) {
+	t.Run("失敗結果_error列は1セルに収める", func(t *testing.T) {
+		results := []Result{
+			{DB: "mysql", Table: "bench_auto"},
+			{DB: "mysql", Table: "bench_uuid_char", Err: "insert failed: \"x\", y\nz"},
+		}
+		failed := FailedResults(results)
+		if len(failed) != 1 || failed[0].Table != "bench_uuid_char" {
+			t.Fatalf("FailedResults = %+v", failed)
+		}
+		out := FormatResults(results)
+		if !strings.Contains(out, ",error\n") || !strings.HasSuffix(out, ",insert failed: 'x'; y z") {
+			t.Fatalf("unexpected output:\n%s", out)
+		}
+		if FailedResults(results[:1]) != nil {
+			t.Fatal("FailedResults without errors should be nil")
+		}
+	})
 }
 
 func TestChunkBounds(t *testing.T) {
@@ -82,3 +339,44 @@ func TestChunkBounds(t *testing.T) {
 		}
 	})
 }
+
+func TestValidateConfigSetupOnly(t *testing.T) {
+	t.Run("セットアップのみ_単独なら有効", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.SetupOnly = true
+		if err := ValidateConfig(cfg); err != nil {
+			t.Fatalf("ValidateConfig = %v, want nil", err)
+		}
+	})
+
+	t.Run("セットアップのみ_no-setupとは併用できない", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.SetupOnly = true
+		cfg.NoSetup = true
+		if err := ValidateConfig(cfg); err == nil {
+			t.Fatal("ValidateConfig error = nil, want error")
+		}
+	})
+}
+
+func TestCheckResults(t *testing.T) {
+	t.Run("空の結果_既定ではエラー", func(t *testing.T) {
+		if err := CheckResults(DefaultConfig(), nil); !errors.Is(err, ErrNoResults) {
+			t.Fatalf("CheckResults = %v, want ErrNoResults", err)
+		}
+	})
+
+	t.Run("空の結果_結果があればnil", func(t *testing.T) {
+		if err := CheckResults(DefaultConfig(), []Result{{DB: "mysql", Table: "bench_auto"}}); err != nil {
+			t.Fatalf("CheckResults = %v, want nil", err)
+		}
+	})
+
+	t.Run("空の結果_無効にすればnil", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.FailOnEmptyResult = false
+		if err := CheckResults(cfg, nil); err != nil {
+			t.Fatalf("CheckResults = %v, want nil", err)
+		}
+	})
+}
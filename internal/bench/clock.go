@@ -0,0 +1,71 @@
+package bench
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// keyClock は時刻を埋め込む方式で、生成したキーの時刻がそれまでに作ったキーの最大の時刻より
+// 前に戻った回数を数える。システム時刻が戻ると後から作ったキーが先のキーより小さくなり、
+// 「挿入順に並ぶ」という前提が崩れて挿入位置が散らばる。
+type keyClock struct {
+	mu          sync.Mutex
+	max         int64
+	regressions int64
+}
+
+// observe は生成したキーに埋め込んだ時刻 ts を記録する。単位は方式ごとに揃っていればよい。
+// 同じ時刻は逆行に数えない。
+func (c *keyClock) observe(ts int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if ts < c.max {
+		c.regressions++
+		return
+	}
+	c.max = ts
+}
+
+// count はこれまでに数えた逆行の回数を返す。
+func (c *keyClock) count() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.regressions
+}
+
+// withTimeRegressions は時刻順の方式の計測 run を実行し、その間に count が増えた分を
+// r.TimeRegressions に入れる。逆行があれば警告する。
+func withTimeRegressions(count func() int64, run func() (Result, error)) (Result, error) {
+	before := count()
+	r, err := run()
+	if err != nil {
+		return r, err
+	}
+	n := count() - before
+	r.TimeRegressions = &n
+	if n > 0 {
+		slog.Warn("key timestamps went backward during generation", "db", r.DB, "table", r.Table, "count", n)
+	}
+	return r, nil
+}
+
+// clockSkewTolerance は WallClockBackward が無視する、壁時計と単調時計の差。
+// NTP が時刻を徐々に合わせる補正（slew）で生じる程度のずれは報告しない。
+const clockSkewTolerance = 100 * time.Millisecond
+
+// WallClockBackward は start から end までの間に、システム時刻（壁時計）が単調時計より遅れた量を返す。
+// NTP のステップ補正や手動の設定でシステム時刻が戻ると正になり、時刻を埋め込むキーが逆行した原因の
+// 手がかりになる。start と end には単調時計の読みを含む time.Now() の値を渡す。差が clockSkewTolerance
+// 以下なら 0。
+func WallClockBackward(start, end time.Time) time.Duration {
+	return wallBehind(end.Sub(start), end.Round(0).Sub(start.Round(0)))
+}
+
+// wallBehind は単調時計で測った経過 mono に対して、壁時計の経過 wall が短い分を返す。
+func wallBehind(mono, wall time.Duration) time.Duration {
+	if d := mono - wall; d > clockSkewTolerance {
+		return d
+	}
+	return 0
+}
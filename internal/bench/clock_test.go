@@ -0,0 +1,69 @@
+package bench
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestKeyClock(t *testing.T) {
+	t.Run("逆行_最大より前の時刻だけを数える", func(t *testing.T) {
+		var c keyClock
+		for _, ts := range []int64{10, 20, 20, 15, 30, 25, 29, 31} {
+			c.observe(ts)
+		}
+		if got := c.count(); got != 3 {
+			t.Fatalf("count = %d, want 3", got)
+		}
+	})
+
+	t.Run("結果_計測中に増えた分だけを入れる", func(t *testing.T) {
+		var c keyClock
+		c.observe(10)
+		c.observe(5)
+		r, err := withTimeRegressions(c.count, func() (Result, error) {
+			c.observe(3)
+			c.observe(11)
+			return Result{DB: "mysql", Table: "bench_uuid_v1"}, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.TimeRegressions == nil || *r.TimeRegressions != 1 {
+			t.Fatalf("TimeRegressions = %v, want 1", r.TimeRegressions)
+		}
+	})
+
+	t.Run("結果_失敗したら入れない", func(t *testing.T) {
+		var c keyClock
+		r, err := withTimeRegressions(c.count, func() (Result, error) {
+			return Result{}, errors.New("boom")
+		})
+		if err == nil || r.TimeRegressions != nil {
+			t.Fatalf("err = %v, TimeRegressions = %v", err, r.TimeRegressions)
+		}
+	})
+}
+
+func TestWallClockBackward(t *testing.T) {
+	t.Run("壁時計_単調時計より遅れた量を返す", func(t *testing.T) {
+		if got := wallBehind(10*time.Second, 8*time.Second); got != 2*time.Second {
+			t.Fatalf("wallBehind = %v, want 2s", got)
+		}
+	})
+
+	t.Run("壁時計_許容範囲内や進んだ場合は0", func(t *testing.T) {
+		for _, wall := range []time.Duration{10 * time.Second, 10*time.Second - clockSkewTolerance, 12 * time.Second} {
+			if got := wallBehind(10*time.Second, wall); got != 0 {
+				t.Fatalf("wallBehind(10s, %v) = %v, want 0", wall, got)
+			}
+		}
+	})
+
+	t.Run("壁時計_通常の経過では0", func(t *testing.T) {
+		start := time.Now()
+		if got := WallClockBackward(start, start.Add(time.Minute)); got != 0 {
+			t.Fatalf("WallClockBackward = %v, want 0", got)
+		}
+	})
+}
@@ -0,0 +1,44 @@
+package bench
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// charKeyTables は -char-collation の照合順序で id 列を作る、UUID を文字列で持つ MySQL のテーブル。
+var charKeyTables = []string{"bench_uuid_char", "bench_uuid_char32"}
+
+// binaryCollation は collation がバイト列として比べる照合順序（binary や ascii_bin などの *_bin）かを返す。
+// *_ci の照合順序は大文字小文字を同一視するため、16 進の大小だけが違う UUID が同じキーとして衝突し、
+// 比較のたびに照合の重みを求める分だけ検索も遅くなる。
+func binaryCollation(collation string) bool {
+	return collation == "binary" || strings.HasSuffix(collation, "_bin")
+}
+
+// checkCharCollation は charKeyTables のうち存在するテーブルの id 列に実際に付いた照合順序を
+// information_schema から読み、バイナリ比較でなければ -char-collation を勧める警告を出す。
+// -char-collation を省くとサーバ既定（utf8mb4_0900_ai_ci など）になり、文字列キーの結果が気付かないうちに偏る。
+func checkCharCollation(ctx context.Context, db *sql.DB) error {
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(
+		"SELECT TABLE_NAME, COLLATION_NAME FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND COLUMN_NAME = 'id' AND TABLE_NAME IN ('%s')",
+		strings.Join(charKeyTables, "', '")))
+	if err != nil {
+		return fmt.Errorf("read char key collation: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var table string
+		var collation sql.NullString
+		if err := rows.Scan(&table, &collation); err != nil {
+			return fmt.Errorf("read char key collation: %w", err)
+		}
+		if collation.Valid && !binaryCollation(collation.String) {
+			slog.Warn("char key column uses a non-binary collation: UUIDs differing only in hex case collide and lookups pay for collation; set -char-collation ascii_bin",
+				"db", "mysql", "table", table, "collation", collation.String)
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,23 @@
+package bench
+
+import "testing"
+
+func TestBinaryCollation(t *testing.T) {
+	for _, tc := range []struct {
+		collation string
+		want      bool
+	}{
+		{"ascii_bin", true},
+		{"utf8mb4_bin", true},
+		{"binary", true},
+		{"utf8mb4_0900_ai_ci", false},
+		{"ascii_general_ci", false},
+		{"utf8mb4_0900_as_cs", false},
+	} {
+		t.Run("照合順序_"+tc.collation, func(t *testing.T) {
+			if got := binaryCollation(tc.collation); got != tc.want {
+				t.Fatalf("binaryCollation(%q) = %v, want %v", tc.collation, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package bench
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ColumnSpec は全ベンチテーブルへ追加するカラム 1 つぶんの定義を表す。
+// Type は ParseColumnsSpec が受け付ける正規化済みの型名を保持する。
+type ColumnSpec struct {
+	Name string
+	Type string
+	// Size は varchar(N) の N。その他の型では 0。
+	Size int
+}
+
+// columnNamePattern は追加カラム名として許可する識別子の形式。
+var columnNamePattern = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// reservedColumns はベンチテーブルが既に使っているカラム名。
+var reservedColumns = map[string]bool{"id": true, "payload": true, "tenant_id": true, "public_id": true, "seq": true, "country": true, "email": true}
+
+// textColumnLen は text 型カラムへ入れる値の長さ。
+const textColumnLen = 256
+
+// ParseColumnsSpec は "name:type,name:type" 形式の指定を解析する。
+// 対応型は int, bigint, double, bool, text, timestamp, varchar(N)。
+func ParseColumnsSpec(spec string) ([]ColumnSpec, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, nil
+	}
+	seen := make(map[string]bool)
+	var cols []ColumnSpec
+	for _, part := range strings.Split(spec, ",") {
+		name, typ, ok := strings.Cut(strings.TrimSpace(part), ":")
+		if !ok {
+			return nil, fmt.Errorf("column spec %q must be name:type", part)
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		typ = strings.ToLower(strings.TrimSpace(typ))
+		if !columnNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("column name %q must match %s", name, columnNamePattern)
+		}
+		if reservedColumns[name] {
+			return nil, fmt.Errorf("column name %q is reserved", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("column name %q is duplicated", name)
+		}
+		seen[name] = true
+
+		col := ColumnSpec{Name: name, Type: typ}
+		switch {
+		case typ == "int", typ == "bigint", typ == "double", typ == "bool", typ == "text", typ == "timestamp":
+		case strings.HasPrefix(typ, "varchar(") && strings.HasSuffix(typ, ")"):
+			n, err := strconv.Atoi(typ[len("varchar(") : len(typ)-1])
+			if err != nil || n <= 0 || n > 4096 {
+				return nil, fmt.Errorf("column %q: varchar size must be 1..4096", name)
+			}
+			col.Type = "varchar"
+			col.Size = n
+		default:
+			return nil, fmt.Errorf("column %q: unsupported type %q (want int, bigint, double, bool, text, timestamp or varchar(N))", name, typ)
+		}
+		cols = append(cols, col)
+	}
+	return cols, nil
+}
+
+// SQLType は db ("mysql" / "postgres") 向けのカラム型を返す。
+func (c ColumnSpec) SQLType(db string) string {
+	switch c.Type {
+	case "int":
+		if db == "postgres" {
+			return "INTEGER"
+		}
+		return "INT"
+	case "bigint":
+		return "BIGINT"
+	case "double":
+		if db == "postgres" {
+			return "DOUBLE PRECISION"
+		}
+		return "DOUBLE"
+	case "bool":
+		return "BOOLEAN"
+	case "text":
+		return "TEXT"
+	case "timestamp":
+		if db == "postgres" {
+			return "TIMESTAMP(6)"
+		}
+		return "DATETIME(6)"
+	case "varchar":
+		return fmt.Sprintf("VARCHAR(%d)", c.Size)
+	}
+	return ""
+}
+
+// Value は i 行目に挿入する決定的な値を返す。
+// 文字列型は宣言長いっぱいまで埋め、行幅を本番に近づける。
+func (c ColumnSpec) Value(i int) any {
+	switch c.Type {
+	case "int":
+		return int32(i)
+	case "bigint":
+		return int64(i)
+	case "double":
+		return float64(i) / 3
+	case "bool":
+		return i%2 == 0
+	case "text":
+		return fillString(fmt.Sprintf("%s-%d-", c.Name, i), textColumnLen)
+	case "timestamp":
+		return time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(i) * time.Second)
+	case "varchar":
+		return fillString(fmt.Sprintf("%s-%d-", c.Name, i), c.Size)
+	}
+	return nil
+}
+
+// fillString は s を n 文字ちょうどに切り詰めるか 'x' で埋める。
+func fillString(s string, n int) string {
+	if len(s) >= n {
+		return s[:n]
+	}
+	return s + strings.Repeat("x", n-len(s))
+}
+
+// columnsDDL は CREATE TABLE へ差し込む追加カラム定義を返す。
+// 空でなければ先頭にカンマを含む。
+func columnsDDL(db string, cols []ColumnSpec) string {
+	var b strings.Builder
+	for _, c := range cols {
+		fmt.Fprintf(&b, ",\n\t\t\t%s %s NOT NULL", c.Name, c.SQLType(db))
+	}
+	return b.String()
+}
+
+// insertSQL は base カラムと追加カラムへ値を入れる INSERT 文を組み立てる。
+// プレースホルダは db の方言に合わせる。
+func insertSQL(db, table string, base []string, cols []ColumnSpec) string {
+	names := append([]string{}, base...)
+	for _, c := range cols {
+		names = append(names, c.Name)
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table, strings.Join(names, ", "), placeholders(db, len(names)))
+}
+
+// insertArgs は keys の値に、gen が作る i 行目の payload と追加カラムの値を続けた引数列を返す。
+// 計測ループの 1 行ごとに呼ぶため、gen は payloadFor で方式ごとに 1 度だけ作って渡す。
+func insertArgs(gen PayloadGenerator, i int, keys ...any) []any {
+	return append(keys, gen.Value(i)...)
+}
+
+// placeholders は n 個ぶんのプレースホルダをカンマ区切りで返す。
+func placeholders(db string, n int) string {
+	ps := make([]string, n)
+	for i := range ps {
+		if db == "postgres" {
+			ps[i] = fmt.Sprintf("$%d", i+1)
+		} else {
+			ps[i] = "?"
+		}
+	}
+	return strings.Join(ps, ", ")
+}
+
+// payloadIsNull は i 行目を NULL にするかを返す。行番号をフィボナッチハッシュで [0, 1) へ散らして fraction と比べるため、
+// 実行ごとに同じ行が NULL になり、連続した行に偏らない。
+func payloadIsNull(i int, fraction float64) bool {
+	h := uint64(i) * 0x9E3779B97F4A7C15
+	return float64(h>>11)/(1<<53) < fraction
+}
+
+// withNullablePayload は stmts の CREATE TABLE にある payload 列の NOT NULL を外した文の一覧を返す。
+func withNullablePayload(stmts []string) []string {
+	out := make([]string, len(stmts))
+	for i, stmt := range stmts {
+		out[i] = stmt
+		// -pg-unlogged で先に CREATE UNLOGGED TABLE へ変えた文も対象にする。
+		if strings.HasPrefix(stmt, "CREATE TABLE ") || strings.HasPrefix(stmt, "CREATE UNLOGGED TABLE ") {
+			out[i] = strings.NewReplacer("payload VARCHAR(100) NOT NULL", "payload VARCHAR(100) NULL", "payload TEXT NOT NULL", "payload TEXT NULL").Replace(stmt)
+		}
+	}
+	return out
+}
@@ -0,0 +1,147 @@
+package bench
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParseColumnsSpec(t *testing.T) {
+	t.Run("カラム指定_複数の型を解析する", func(t *testing.T) {
+		cols, err := ParseColumnsSpec("note:varchar(20), score:DOUBLE,flag:bool")
+		if err != nil {
+			t.Fatalf("ParseColumnsSpec error: %v", err)
+		}
+		want := []ColumnSpec{
+			{Name: "note", Type: "varchar", Size: 20},
+			{Name: "score", Type: "double"},
+			{Name: "flag", Type: "bool"},
+		}
+		if len(cols) != len(want) {
+			t.Fatalf("len = %d, want %d", len(cols), len(want))
+		}
+		for i := range cols {
+			if cols[i] != want[i] {
+				t.Fatalf("cols[%d] = %+v, want %+v", i, cols[i], want[i])
+			}
+		}
+	})
+
+	t.Run("カラム指定_空文字はカラムなし", func(t *testing.T) {
+		cols, err := ParseColumnsSpec("")
+		if err != nil || cols != nil {
+			t.Fatalf("got %v, %v; want nil, nil", cols, err)
+		}
+	})
+
+	tests := []struct {
+		name string
+		spec string
+	}{
+		{"区切り文字なし", "note"},
+		{"未対応の型", "note:blob"},
+		{"予約済みカラム名", "payload:text"},
+		{"重複カラム名", "a:int,a:bigint"},
+		{"不正な識別子", "1a:int"},
+		{"varcharサイズ不正", "note:varchar(0)"},
+	}
+	for _, tt := range tests {
+		t.Run("カラム指定_"+tt.name+"はエラー", func(t *testing.T) {
+			if _, err := ParseColumnsSpec(tt.spec); err == nil {
+				t.Fatalf("ParseColumnsSpec(%q) error = nil, want error", tt.spec)
+			}
+		})
+	}
+}
+
+func TestColumnSpecValue(t *testing.T) {
+	t.Run("カラム値_varcharは宣言長で埋める", func(t *testing.T) {
+		c := ColumnSpec{Name: "note", Type: "varchar", Size: 12}
+		v, ok := c.Value(3).(string)
+		if !ok || len(v) != 12 || !strings.HasPrefix(v, "note-3-") {
+			t.Fatalf("Value(3) = %v, want 12-char string starting with note-3-", c.Value(3))
+		}
+	})
+}
+
+func TestInsertSQL(t *testing.T) {
+	t.Run("INSERT文_PostgreSQLは番号付きプレースホルダ", func(t *testing.T) {
+		cols := []ColumnSpec{{Name: "score", Type: "double"}}
+		got := insertSQL("postgres", "bench_uuid", []string{"id", "payload"}, cols)
+		want := "INSERT INTO bench_uuid (id, payload, score) VALUES ($1, $2, $3)"
+		if got != want {
+			t.Fatalf("insertSQL = %q, want %q", got, want)
+		}
+	})
+}
+
+func TestPayloadValue(t *testing.T) {
+	t.Run("既定_NULLにしない", func(t *testing.T) {
+		if got := payloadValue(payloadFor(DefaultConfig()), 7); got != "p-7" {
+			t.Fatalf("payloadValue = %v", got)
+		}
+	})
+
+	t.Run("NULL許容_指定した割合に近い行をNULLにする", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.PayloadNullable = true
+		cfg.PayloadNullFraction = 0.3
+		gen := payloadFor(cfg)
+		nulls := 0
+		for i := range 10000 {
+			v := payloadValue(gen, i)
+			if v == nil {
+				nulls++
+			} else if v != fmt.Sprintf("p-%d", i) {
+				t.Fatalf("payloadValue(%d) = %v", i, v)
+			}
+		}
+		if nulls < 2800 || nulls > 3200 {
+			t.Fatalf("nulls = %d of 10000, want about 3000", nulls)
+		}
+	})
+
+	t.Run("NULL許容_割合0と1", func(t *testing.T) {
+		if payloadIsNull(3, 0) || !payloadIsNull(3, 1) {
+			t.Fatal("fraction 0 must never and 1 must always be NULL")
+		}
+	})
+
+	t.Run("追加カラムあり_Valueの先頭と一致する", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.PayloadNullable = true
+		cfg.PayloadNullFraction = 0.5
+		cfg.ExtraColumns = []ColumnSpec{{Name: "score", Type: "double"}}
+		gen := payloadFor(cfg)
+		for i := range 100 {
+			if got, want := payloadValue(gen, i), gen.Value(i)[0]; got != want {
+				t.Fatalf("payloadValue(%d) = %v, want %v", i, got, want)
+			}
+		}
+	})
+}
+
+func TestWithNullablePayload(t *testing.T) {
+	stmts := withNullablePayload([]string{
+		"DROP TABLE IF EXISTS bench_auto",
+		"CREATE TABLE bench_auto (id BIGINT, payload VARCHAR(100) NOT NULL)",
+		"CREATE TABLE bench_uuid (id UUID, payload TEXT NOT NULL, note TEXT NOT NULL)",
+	})
+	if stmts[1] != "CREATE TABLE bench_auto (id BIGINT, payload VARCHAR(100) NULL)" {
+		t.Fatalf("mysql = %s", stmts[1])
+	}
+	if stmts[2] != "CREATE TABLE bench_uuid (id UUID, payload TEXT NULL, note TEXT NOT NULL)" {
+		t.Fatalf("postgres = %s", stmts[2])
+	}
+
+	t.Run("NULL許容_UNLOGGEDのテーブルも外す", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.PGUnlogged = true
+		cfg.PayloadNullable = true
+		for _, stmt := range pgSetupStmts(cfg) {
+			if strings.HasPrefix(stmt, "CREATE UNLOGGED TABLE ") && strings.Contains(stmt, "payload TEXT NOT NULL") {
+				t.Fatalf("payload is still NOT NULL: %s", stmt)
+			}
+		}
+	})
+}
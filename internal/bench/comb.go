@@ -0,0 +1,56 @@
+package bench
+
+import (
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// combClock は NewCombUUID の時刻が巻き戻らないよう、直前に使ったミリ秒を覚えておく。
+// regressions はシステム時刻が戻って直前の値に切り上げた回数。
+var combClock struct {
+	sync.Mutex
+	last        int64
+	regressions int64
+}
+
+// NewCombUUID は乱数の UUIDv4 の先頭 6 バイトを Unix エポックからのミリ秒（ビッグエンディアン）で
+// 置き換えた COMB 形式の UUID を返す。バージョン/バリアントのビットは v4 のまま残すため、
+// UUID として受け付ける列や API へそのまま渡せる。
+// 先頭が時刻なので BINARY(16) のバイト順や PostgreSQL の uuid 型の比較で挿入順にほぼ並ぶ。
+// 同じミリ秒の中の並びは乱数で決まる。システム時刻が戻っても直前の値より小さくはしない。
+func NewCombUUID() uuid.UUID {
+	return combUUID(uuid.New(), time.Now())
+}
+
+// combUUID は u の先頭 6 バイトを now のミリ秒で置き換える。NewCombUUID の本体。
+func combUUID(u uuid.UUID, now time.Time) uuid.UUID {
+	ms := now.UnixMilli()
+	combClock.Lock()
+	if ms < combClock.last {
+		ms = combClock.last
+		combClock.regressions++
+	}
+	combClock.last = ms
+	combClock.Unlock()
+
+	var ts [8]byte
+	binary.BigEndian.PutUint64(ts[:], uint64(ms))
+	copy(u[:6], ts[2:])
+	return u
+}
+
+// combRegressions はシステム時刻が戻って COMB の時刻を切り上げた回数を返す。
+// 切り上げたキーは逆行しないが、同じミリ秒に詰まって挿入順に並ばなくなる。
+func combRegressions() int64 {
+	combClock.Lock()
+	defer combClock.Unlock()
+	return combClock.regressions
+}
+
+// uuidCombKey は COMB 方式の i 行目のキーを返す。時刻を埋め込むため -uuid-v5-namespace は効かない。
+func uuidCombKey(Config, int) uuid.UUID {
+	return NewCombUUID()
+}
@@ -0,0 +1,57 @@
+package bench
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewCombUUID(t *testing.T) {
+	t.Run("COMB_先頭6バイトにミリ秒を入れてv4のビットは残す", func(t *testing.T) {
+		now := time.UnixMilli(0x0123456789ab)
+		u := combUUID(uuid.New(), now)
+		if want := []byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xab}; !bytes.Equal(u[:6], want) {
+			t.Fatalf("prefix = %x, want %x", u[:6], want)
+		}
+		if u.Version() != 4 || u.Variant() != uuid.RFC4122 {
+			t.Fatalf("version = %d, variant = %v", u.Version(), u.Variant())
+		}
+	})
+
+	t.Run("COMB_時刻が戻っても先頭は減らない", func(t *testing.T) {
+		saved := combClock.last
+		defer func() { combClock.last = saved }()
+		later := combUUID(uuid.New(), time.Now().Add(time.Hour))
+		earlier := combUUID(uuid.New(), time.Now())
+		if bytes.Compare(earlier[:6], later[:6]) < 0 {
+			t.Fatalf("prefix went backwards: %x < %x", earlier[:6], later[:6])
+		}
+	})
+
+	t.Run("COMB_時刻が戻って切り上げた回数を数える", func(t *testing.T) {
+		saved := combClock.last
+		defer func() { combClock.last = saved }()
+		before := combRegressions()
+		combUUID(uuid.New(), time.Now().Add(time.Hour))
+		combUUID(uuid.New(), time.Now())
+		if got := combRegressions() - before; got != 1 {
+			t.Fatalf("regressions = %d, want 1", got)
+		}
+	})
+
+	t.Run("COMB_連続生成はバイト順の先頭が単調非減少", func(t *testing.T) {
+		prev := NewCombUUID()
+		for range 1000 {
+			u := NewCombUUID()
+			if bytes.Compare(u[:6], prev[:6]) < 0 {
+				t.Fatalf("prefix went backwards: %x < %x", u[:6], prev[:6])
+			}
+			if u == prev {
+				t.Fatal("duplicate COMB UUID")
+			}
+			prev = u
+		}
+	})
+}
@@ -0,0 +1,119 @@
+package bench
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CompactPhase は 1 フェーズで最も速かった方式と、最も遅かった方式との開き。
+type CompactPhase struct {
+	Phase  string
+	Winner string
+	// Seconds は最速方式の 1 件あたりの秒数（range は 1 回の秒数）。
+	Seconds float64
+	// Spread は最遅 / 最速の倍率。比べる方式が 1 つなら 1。
+	Spread float64
+}
+
+// CompactSummary は 1 つの DB・接続先について、フェーズごとの勝者をまとめたもの。
+type CompactSummary struct {
+	DB     string
+	Server string
+	Label  string
+	Phases []CompactPhase
+}
+
+// compactComparable は r を -compact-output の勝者比較に含めるかを返す。
+// 並列挿入・混合負荷・pgx のパイプライン投入・外部キーの子テーブルは計測の仕方が違うため除く。
+func compactComparable(r Result) bool {
+	return r.Err == "" && r.Workers == 0 && !strings.HasPrefix(r.Table, "bench_child_") && !strings.HasSuffix(r.Table, "_pgx")
+}
+
+// Compact は results を DB・接続先ごとにまとめ、insert（1 行あたり）・point（1 件あたり）・
+// range のそれぞれで最も速かった方式と、最も遅かった方式との開きを求める。
+// 計測していないフェーズは含めない。同じ秒数なら結果の並びで先の方式を勝者にする。
+func Compact(results []Result) []CompactSummary {
+	type key struct{ db, server, label string }
+	var (
+		order  []key
+		byKey  = make(map[key][]Result)
+		phases = []struct {
+			name string
+			sec  func(Result) float64
+		}{
+			{"insert", func(r Result) float64 { return perOp(r.InsertSeconds, r.InsertRows) }},
+			{"point", func(r Result) float64 { return perOp(r.PointSeconds, r.PointLookupCount) }},
+			{"range", func(r Result) float64 { return r.RangeSeconds }},
+		}
+	)
+	for _, r := range results {
+		if !compactComparable(r) {
+			continue
+		}
+		k := key{r.DB, r.Server, r.Label}
+		if _, ok := byKey[k]; !ok {
+			order = append(order, k)
+		}
+		byKey[k] = append(byKey[k], r)
+	}
+	var out []CompactSummary
+	for _, k := range order {
+		s := CompactSummary{DB: k.db, Server: k.server, Label: k.label}
+		for _, p := range phases {
+			var best CompactPhase
+			var slowest float64
+			for _, r := range byKey[k] {
+				sec := p.sec(r)
+				if sec <= 0 {
+					continue
+				}
+				if best.Winner == "" || sec < best.Seconds {
+					best = CompactPhase{Phase: p.name, Winner: r.Table, Seconds: sec}
+				}
+				slowest = max(slowest, sec)
+			}
+			if best.Winner == "" {
+				continue
+			}
+			best.Spread = slowest / best.Seconds
+			s.Phases = append(s.Phases, best)
+		}
+		if len(s.Phases) > 0 {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// FormatCompact は Compact の結果を 1 DB 1 行で整形する。
+// 例: "MySQL: insert bench_auto 41.2us/row (spread 2.31x) | point bench_uuid_bin 30.5us (1.12x) | range bench_auto 3.40ms (4.50x)"
+func FormatCompact(summaries []CompactSummary) string {
+	var out strings.Builder
+	for _, s := range summaries {
+		name := dbLabels[s.DB]
+		if name == "" {
+			name = s.DB
+		}
+		if s.Server != "" {
+			name += " (" + s.Server + ")"
+		}
+		if s.Label != "" {
+			name += " [" + s.Label + "]"
+		}
+		parts := make([]string, len(s.Phases))
+		for i, p := range s.Phases {
+			var took string
+			switch p.Phase {
+			case "insert":
+				took = fmt.Sprintf("%.1fus/row", p.Seconds*1e6)
+			case "point":
+				took = fmt.Sprintf("%.1fus", p.Seconds*1e6)
+			default:
+				took = fmt.Sprintf("%.2fms", p.Seconds*1e3)
+			}
+			parts[i] = fmt.Sprintf("%s %s %s (spread %.2fx)", p.Phase, p.Winner, took, p.Spread)
+		}
+		fmt.Fprintf(&out, "%s: %s\n", name, strings.Join(parts, " | "))
+	}
+	return out.String()
+}
@@ -0,0 +1,54 @@
+package bench
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCompact(t *testing.T) {
+	results := []Result{
+		{DB: "mysql", Table: "bench_auto", InsertRows: 100, InsertSeconds: 1, PointLookupCount: 10, PointSeconds: 0.2, RangeSeconds: 0.01},
+		{DB: "mysql", Table: "bench_uuid_bin", InsertRows: 100, InsertSeconds: 2, PointLookupCount: 10, PointSeconds: 0.1, RangeSeconds: 0.04},
+		{DB: "mysql", Table: "bench_uuid_char", Err: "boom"},
+		// 並列挿入は 1 本の挿入と比べられないため勝者にしない。
+		{DB: "mysql", Table: "bench_auto_concurrent", InsertRows: 100, InsertSeconds: 0.1, Workers: 4},
+		{DB: "postgres", Table: "bench_auto", InsertRows: 100, InsertSeconds: 1},
+	}
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+
+	t.Run("集計_フェーズごとの最速と開き", func(t *testing.T) {
+		got := Compact(results)
+		if len(got) != 2 {
+			t.Fatalf("summaries = %+v, want 2", got)
+		}
+		my := got[0]
+		if my.DB != "mysql" || len(my.Phases) != 3 {
+			t.Fatalf("mysql = %+v", my)
+		}
+		if p := my.Phases[0]; p.Winner != "bench_auto" || !near(p.Seconds, 0.01) || !near(p.Spread, 2) {
+			t.Fatalf("insert = %+v", p)
+		}
+		if p := my.Phases[1]; p.Winner != "bench_uuid_bin" || !near(p.Spread, 2) {
+			t.Fatalf("point = %+v", p)
+		}
+		if p := my.Phases[2]; p.Winner != "bench_auto" || !near(p.Spread, 4) {
+			t.Fatalf("range = %+v", p)
+		}
+	})
+
+	t.Run("集計_計測していないフェーズは含めない", func(t *testing.T) {
+		pg := Compact(results)[1]
+		if len(pg.Phases) != 1 || pg.Phases[0].Phase != "insert" || pg.Phases[0].Spread != 1 {
+			t.Fatalf("postgres = %+v", pg)
+		}
+	})
+
+	t.Run("整形_1DB1行", func(t *testing.T) {
+		got := FormatCompact(Compact(results))
+		want := "MySQL: insert bench_auto 10000.0us/row (spread 2.00x) | point bench_uuid_bin 10000.0us (spread 2.00x) | range bench_auto 10.00ms (spread 4.00x)\n" +
+			"PostgreSQL: insert bench_auto 10000.0us/row (spread 1.00x)\n"
+		if got != want {
+			t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+		}
+	})
+}
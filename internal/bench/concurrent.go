@@ -0,0 +1,250 @@
+package bench
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// maxDeadlockRetries は並列挿入でデッドロックにより失敗した 1 行を再試行する上限回数。
+const maxDeadlockRetries = 3
+
+// lockCounters は並列挿入フェーズ前後で差分を取るサーバ側のロック統計。
+// 取得できない項目は nil のままにする。
+type lockCounters struct {
+	LockWaits *int64
+	Deadlocks *int64
+}
+
+// sub は c - before を返す。どちらかが nil の項目は nil。
+func (c lockCounters) sub(before lockCounters) lockCounters {
+	diff := func(a, b *int64) *int64 {
+		if a == nil || b == nil {
+			return nil
+		}
+		d := *a - *b
+		return &d
+	}
+	return lockCounters{LockWaits: diff(c.LockWaits, before.LockWaits), Deadlocks: diff(c.Deadlocks, before.Deadlocks)}
+}
+
+// mysqlLockCounters は Innodb_row_lock_waits と INNODB_METRICS の lock_deadlocks を読む。
+// lock_deadlocks はメトリクスが無効なサーバでは取得できないため nil のままにする。
+func mysqlLockCounters(ctx context.Context, db *sql.DB) (lockCounters, error) {
+	var c lockCounters
+	var name string
+	var waits int64
+	if err := db.QueryRowContext(ctx, "SHOW GLOBAL STATUS LIKE 'Innodb_row_lock_waits'").Scan(&name, &waits); err != nil {
+		return c, fmt.Errorf("mysql lock wait query failed: %w", err)
+	}
+	c.LockWaits = &waits
+	var deadlocks int64
+	if err := db.QueryRowContext(ctx, "SELECT `COUNT` FROM information_schema.INNODB_METRICS WHERE NAME = 'lock_deadlocks' AND STATUS = 'enabled'").Scan(&deadlocks); err == nil {
+		c.Deadlocks = &deadlocks
+	}
+	return c, nil
+}
+
+// pgLockCounters は pg_stat_database の deadlocks を読む。
+// PostgreSQL には行ロック待ちの累計カウンタがないため LockWaits は nil。
+// 統計はバックエンドから遅れて反映されるため、直後のデッドロックは数に入らないことがある。
+func pgLockCounters(ctx context.Context, db *sql.DB) (lockCounters, error) {
+	var c lockCounters
+	var deadlocks int64
+	if err := db.QueryRowContext(ctx, "SELECT deadlocks FROM pg_stat_database WHERE datname = current_database()").Scan(&deadlocks); err != nil {
+		return c, fmt.Errorf("postgres deadlock query failed: %w", err)
+	}
+	c.Deadlocks = &deadlocks
+	return c, nil
+}
+
+// isDeadlock はドライバのエラーがデッドロックによるロールバックかを返す。
+func isDeadlock(err error) bool {
+	var myErr *mysql.MySQLError
+	if errors.As(err, &myErr) {
+		return myErr.Number == 1213
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == "40P01"
+	}
+	return false
+}
+
+// concurrentInsert は cfg.Rows 行を cfg.ConcurrentWorkers 個のゴルーチンで分担して挿入し、
+// 全体の所要秒数を返す。デッドロックで失敗した行は maxDeadlockRetries 回まで再試行する。
+// いずれかのワーカーが失敗したら残りを中断し、最初のエラーを返す。
+func concurrentInsert(ctx context.Context, cfg Config, log *slog.Logger, insert func(ctx context.Context, i int) error) (float64, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	chunk := (cfg.Rows + cfg.ConcurrentWorkers - 1) / cfg.ConcurrentWorkers
+	log.Debug("concurrent insert start", "rows", cfg.Rows, "workers", cfg.ConcurrentWorkers)
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+		retries  atomic.Int64
+	)
+	start := time.Now()
+	for _, b := range ChunkBounds(cfg.Rows, chunk) {
+		wg.Add(1)
+		go func(lo, hi int) {
+			defer wg.Done()
+			for i := lo; i < hi; i++ {
+				if err := ctx.Err(); err != nil {
+					return
+				}
+				// 再試行も 1 往復ずつかかる。
+				try := func() error {
+					if err := simulateRTT(ctx, cfg); err != nil {
+						return err
+					}
+					return withQueryTimeout(ctx, cfg, i, insert)
+				}
+				err := try()
+				for attempt := 0; err != nil && isDeadlock(err) && attempt < maxDeadlockRetries; attempt++ {
+					retries.Add(1)
+					err = try()
+				}
+				if err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					return
+				}
+			}
+		}(b[0], b[1])
+	}
+	wg.Wait()
+	sec := time.Since(start).Seconds()
+	if firstErr != nil {
+		return sec, firstErr
+	}
+	log.Info("concurrent insert done", "rows", cfg.Rows, "workers", cfg.ConcurrentWorkers, "sec", sec, "deadlock_retries", retries.Load())
+	return sec, nil
+}
+
+// benchConcurrent は table へ並列挿入し、前後のロック統計の差分とともに結果を返す。
+func benchConcurrent(ctx context.Context, db *sql.DB, cfg Config, kind, table string, counters func(context.Context, *sql.DB) (lockCounters, error), insert func(ctx context.Context, i int) error) (Result, error) {
+	log := slog.With("db", kind, "table", table)
+	before, err := counters(ctx, db)
+	if err != nil {
+		return Result{}, err
+	}
+	sec, err := concurrentInsert(ctx, cfg, log, insert)
+	if err != nil {
+		return Result{}, err
+	}
+	after, err := counters(ctx, db)
+	if err != nil {
+		return Result{}, err
+	}
+	delta := after.sub(before)
+	r := Result{
+		DB:            kind,
+		Table:         table,
+		InsertRows:    cfg.Rows,
+		InsertSeconds: sec,
+		Workers:       cfg.ConcurrentWorkers,
+		LockWaits:     delta.LockWaits,
+		Deadlocks:     delta.Deadlocks,
+	}
+	// 並列挿入は -auto-scale-rows の対象外なので、行数を選んだ方式と区別できるよう -simulated-rtt のときだけ書く。
+	if cfg.SimulatedRTT > 0 && sec > 0 {
+		r.InsertRowsPerSec = float64(r.InsertRows) / sec
+	}
+	return r, nil
+}
+
+// concurrentTables は -concurrent-workers で並列挿入するテーブル。-gapless 時は欠番なしの連番の表も加える。
+func concurrentTables(cfg Config) []string {
+	tables := []string{"bench_auto_concurrent", "bench_uuid_concurrent"}
+	if cfg.Gapless {
+		tables = append(tables, "bench_gapless_concurrent")
+	}
+	return tables
+}
+
+// concurrentGapless は cfg.Gapless なら bench_gapless_concurrent へ欠番なしの連番で並列挿入する。
+// どのワーカーも同じカウンタ行のロックを待つため、並列度を上げても挿入は直列になる。無効なら nil を返す。
+func concurrentGapless(ctx context.Context, db *sql.DB, cfg Config, kind string, counters func(context.Context, *sql.DB) (lockCounters, error)) ([]Result, error) {
+	if !cfg.Gapless {
+		return nil, nil
+	}
+	seq, err := newGaplessSeq(ctx, db, cfg, kind, "bench_gapless_concurrent")
+	if err != nil {
+		return nil, err
+	}
+	defer seq.Close()
+	r, err := benchConcurrent(ctx, db, cfg, kind, "bench_gapless_concurrent", counters, func(ctx context.Context, i int) error {
+		_, err := seq.next(ctx, i)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []Result{r}, nil
+}
+
+// runMySQLConcurrent は AUTO_INCREMENT と BINARY(16) UUID の各主キーへ並列挿入する。
+// AUTO_INCREMENT は採番ロックで直列化しうるのに対し、UUID は挿入先が分散する差を見る。
+func runMySQLConcurrent(ctx context.Context, db *sql.DB, cfg Config) ([]Result, error) {
+	gen := payloadFor(cfg)
+	autoSQL := insertSQL("mysql", "bench_auto_concurrent", []string{"payload"}, gen.Columns())
+	auto, err := benchConcurrent(ctx, db, cfg, "mysql", "bench_auto_concurrent", mysqlLockCounters, func(ctx context.Context, i int) error {
+		_, err := db.ExecContext(ctx, autoSQL, insertArgs(gen, i)...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	uuidSQL := insertSQL("mysql", "bench_uuid_concurrent", []string{"id", "payload"}, gen.Columns())
+	uuidRes, err := benchConcurrent(ctx, db, cfg, "mysql", "bench_uuid_concurrent", mysqlLockCounters, func(ctx context.Context, i int) error {
+		_, err := db.ExecContext(ctx, uuidSQL, insertArgs(gen, i, uuidBinKey(cfg, i))...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	gapless, err := concurrentGapless(ctx, db, cfg, "mysql", mysqlLockCounters)
+	if err != nil {
+		return nil, err
+	}
+	return append([]Result{auto, uuidRes}, gapless...), nil
+}
+
+// runPGConcurrent は BIGSERIAL と UUID の各主キーへ並列挿入する。
+func runPGConcurrent(ctx context.Context, db *sql.DB, cfg Config) ([]Result, error) {
+	gen := payloadFor(cfg)
+	autoSQL := insertSQL("postgres", "bench_auto_concurrent", []string{"payload"}, gen.Columns())
+	auto, err := benchConcurrent(ctx, db, cfg, "postgres", "bench_auto_concurrent", pgLockCounters, func(ctx context.Context, i int) error {
+		_, err := db.ExecContext(ctx, autoSQL, insertArgs(gen, i)...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	uuidSQL := insertSQL("postgres", "bench_uuid_concurrent", []string{"id", "payload"}, gen.Columns())
+	uuidRes, err := benchConcurrent(ctx, db, cfg, "postgres", "bench_uuid_concurrent", pgLockCounters, func(ctx context.Context, i int) error {
+		_, err := db.ExecContext(ctx, uuidSQL, insertArgs(gen, i, newUUID(cfg, i))...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	gapless, err := concurrentGapless(ctx, db, cfg, "postgres", pgLockCounters)
+	if err != nil {
+		return nil, err
+	}
+	return append([]Result{auto, uuidRes}, gapless...), nil
+}
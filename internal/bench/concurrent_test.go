@@ -0,0 +1,93 @@
+package bench
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestIsDeadlock(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"MySQLのデッドロック", fmt.Errorf("insert: %w", &mysql.MySQLError{Number: 1213}), true},
+		{"MySQLの重複キー", &mysql.MySQLError{Number: 1062}, false},
+		{"PostgreSQLのデッドロック", &pgconn.PgError{Code: "40P01"}, true},
+		{"その他のエラー", errors.New("boom"), false},
+	}
+	for _, tt := range tests {
+		t.Run("デッドロック判定_"+tt.name, func(t *testing.T) {
+			if got := isDeadlock(tt.err); got != tt.want {
+				t.Fatalf("isDeadlock = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLockCountersSub(t *testing.T) {
+	t.Run("ロック統計差分_取得できない項目はnil", func(t *testing.T) {
+		n := func(v int64) *int64 { return &v }
+		got := lockCounters{LockWaits: n(15), Deadlocks: n(2)}.sub(lockCounters{LockWaits: n(10)})
+		if got.LockWaits == nil || *got.LockWaits != 5 {
+			t.Fatalf("LockWaits = %v, want 5", got.LockWaits)
+		}
+		if got.Deadlocks != nil {
+			t.Fatalf("Deadlocks = %v, want nil", *got.Deadlocks)
+		}
+	})
+}
+
+func TestConcurrentInsert(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Rows = 1000
+	cfg.ConcurrentWorkers = 4
+
+	t.Run("並列挿入_全行を一度ずつ挿入する", func(t *testing.T) {
+		seen := make([]atomic.Int32, cfg.Rows)
+		if _, err := concurrentInsert(context.Background(), cfg, slog.Default(), func(_ context.Context, i int) error {
+			seen[i].Add(1)
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		for i := range seen {
+			if seen[i].Load() != 1 {
+				t.Fatalf("row %d inserted %d times", i, seen[i].Load())
+			}
+		}
+	})
+
+	t.Run("並列挿入_デッドロックは再試行する", func(t *testing.T) {
+		var failed atomic.Bool
+		_, err := concurrentInsert(context.Background(), cfg, slog.Default(), func(_ context.Context, i int) error {
+			if i == 10 && failed.CompareAndSwap(false, true) {
+				return &mysql.MySQLError{Number: 1213}
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("err = %v, want retried success", err)
+		}
+	})
+
+	t.Run("並列挿入_その他のエラーで中断する", func(t *testing.T) {
+		boom := errors.New("boom")
+		_, err := concurrentInsert(context.Background(), cfg, slog.Default(), func(_ context.Context, i int) error {
+			if i == 10 {
+				return boom
+			}
+			return nil
+		})
+		if !errors.Is(err, boom) {
+			t.Fatalf("err = %v, want boom", err)
+		}
+	})
+}
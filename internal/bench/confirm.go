@@ -0,0 +1,40 @@
+package bench
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DefaultRowsWarnThreshold は -rows-warn-threshold の既定値。これを超える -rows は実行前に確認する。
+const DefaultRowsWarnThreshold = 10_000_000
+
+// RowsNeedConfirmation は cfg.Rows が確認の必要な件数かを返す。
+// しきい値が 0 以下か、-yes が指定されていれば確認しない。
+func RowsNeedConfirmation(cfg Config) bool {
+	return cfg.RowsWarnThreshold > 0 && cfg.Rows > cfg.RowsWarnThreshold && !cfg.Yes
+}
+
+// ConfirmRows は -rows がしきい値を超えるとき、out へ見積もりサイズ付きの確認を出して in から応答を読む。
+// y / yes なら nil を返す。interactive が偽（stdin が端末でない）なら尋ねずにエラーを返し、-yes を促す。
+func ConfirmRows(cfg Config, in io.Reader, out io.Writer, interactive bool) error {
+	if !RowsNeedConfirmation(cfg) {
+		return nil
+	}
+	largest := max(EstimatedTableBytes("mysql", cfg.Rows, payloadFor(cfg).Columns()), EstimatedTableBytes("postgres", cfg.Rows, payloadFor(cfg).Columns()))
+	msg := fmt.Sprintf("-rows %d exceeds -rows-warn-threshold %d; each bench table may need more than %d MB of disk", cfg.Rows, cfg.RowsWarnThreshold, largest>>20)
+	if !interactive {
+		return fmt.Errorf("%s; pass -yes to run without a prompt", msg)
+	}
+	fmt.Fprintf(out, "%s. Continue? [y/N] ", msg)
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return fmt.Errorf("read confirmation: %w", err)
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	}
+	return fmt.Errorf("aborted: -rows %d not confirmed", cfg.Rows)
+}
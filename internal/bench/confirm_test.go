@@ -0,0 +1,58 @@
+package bench
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConfirmRows(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Rows = 20_000_000
+
+	t.Run("しきい値以下_確認しない", func(t *testing.T) {
+		c := DefaultConfig()
+		var out strings.Builder
+		if err := ConfirmRows(c, strings.NewReader(""), &out, true); err != nil || out.Len() > 0 {
+			t.Fatalf("err = %v, out = %q", err, out.String())
+		}
+	})
+
+	t.Run("yes指定_確認しない", func(t *testing.T) {
+		c := cfg
+		c.Yes = true
+		if err := ConfirmRows(c, strings.NewReader(""), &strings.Builder{}, false); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("しきい値0_無効", func(t *testing.T) {
+		c := cfg
+		c.RowsWarnThreshold = 0
+		if RowsNeedConfirmation(c) {
+			t.Fatal("threshold 0 must disable the prompt")
+		}
+	})
+
+	t.Run("非対話_yesを促して失敗する", func(t *testing.T) {
+		err := ConfirmRows(cfg, strings.NewReader("y\n"), &strings.Builder{}, false)
+		if err == nil || !strings.Contains(err.Error(), "-yes") {
+			t.Fatalf("err = %v", err)
+		}
+	})
+
+	t.Run("対話_yで続行", func(t *testing.T) {
+		var out strings.Builder
+		if err := ConfirmRows(cfg, strings.NewReader("Y\n"), &out, true); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(out.String(), "Continue? [y/N]") {
+			t.Fatalf("prompt = %q", out.String())
+		}
+	})
+
+	t.Run("対話_空応答は中止", func(t *testing.T) {
+		if err := ConfirmRows(cfg, strings.NewReader("\n"), &strings.Builder{}, true); err == nil {
+			t.Fatal("empty answer must abort")
+		}
+	})
+}
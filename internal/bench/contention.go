@@ -0,0 +1,116 @@
+package bench
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ContentionResult は UUID 生成器 1 つを goroutine 数 1 つで回した計測結果を表す。
+type ContentionResult struct {
+	Generator   string
+	Goroutines  int
+	N           int
+	UUIDsPerSec float64
+}
+
+// contentionReaderSize は per_goroutine 生成器が 1 回の読み出しで crypto/rand から取る乱数のバイト数（UUID 16 個ぶん）。
+const contentionReaderSize = 16 * 16
+
+// contentionGenerator は -uuid-contention で比べる生成器 1 つぶん。
+// worker は goroutine ごとに 1 回呼び、その goroutine 専用の生成関数を返す。
+// setup / teardown はパッケージ全体の設定を切り替える生成器だけが持つ。
+type contentionGenerator struct {
+	name     string
+	setup    func()
+	teardown func()
+	worker   func() func() uuid.UUID
+}
+
+// contentionGenerators は既定の uuid.New と、乱数の取り方を変えた 2 方式。
+// rand_pool は uuid.EnableRandPool の共有バッファ（1 つのミューテックスで守られる）、
+// per_goroutine は goroutine ごとにバッファした crypto/rand から生成する。
+var contentionGenerators = []contentionGenerator{
+	{name: "default", worker: func() func() uuid.UUID { return uuid.New }},
+	{
+		name:     "rand_pool",
+		setup:    uuid.EnableRandPool,
+		teardown: uuid.DisableRandPool,
+		worker:   func() func() uuid.UUID { return uuid.New },
+	},
+	{name: "per_goroutine", worker: func() func() uuid.UUID {
+		r := bufio.NewReaderSize(rand.Reader, contentionReaderSize)
+		return func() uuid.UUID { return uuid.Must(uuid.NewRandomFromReader(r)) }
+	}},
+}
+
+// contentionGoroutines は計測する goroutine 数。1 と goroutines の両方を測り、並列化でどれだけ伸びるかを見る。
+func contentionGoroutines(goroutines int) []int {
+	if goroutines <= 1 {
+		return []int{1}
+	}
+	return []int{1, goroutines}
+}
+
+// RunUUIDContention は各生成器で n 個の UUID を goroutine 数 1 と goroutines に分けて生成し、秒間生成数を返す。
+// 直列の挿入ループでは見えない、クライアント側の UUID 生成が並列時に詰まるかどうかを切り出して見るために使う。
+func RunUUIDContention(n, goroutines int) []ContentionResult {
+	var results []ContentionResult
+	for _, g := range contentionGenerators {
+		if g.setup != nil {
+			g.setup()
+		}
+		for _, workers := range contentionGoroutines(goroutines) {
+			sec := generateConcurrently(g, n, workers)
+			results = append(results, ContentionResult{Generator: g.name, Goroutines: workers, N: n, UUIDsPerSec: float64(n) / sec})
+		}
+		if g.teardown != nil {
+			g.teardown()
+		}
+	}
+	return results
+}
+
+// generateConcurrently は n 個の UUID を workers 個の goroutine で分担して生成し、かかった秒数を返す。
+func generateConcurrently(g contentionGenerator, n, workers int) float64 {
+	var wg sync.WaitGroup
+	sinks := make([]int, workers)
+	start := time.Now()
+	for w := range workers {
+		count := n / workers
+		if w < n%workers {
+			count++
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			gen := g.worker()
+			for range count {
+				u := gen()
+				sinks[w] += int(u[0])
+			}
+		}()
+	}
+	wg.Wait()
+	elapsed := time.Since(start).Seconds()
+	for _, s := range sinks {
+		microSink += s
+	}
+	return elapsed
+}
+
+// FormatUUIDContention は RunUUIDContention の結果を見出し付き CSV 文字列に整形する。
+func FormatUUIDContention(results []ContentionResult) string {
+	var out bytes.Buffer
+	out.WriteString("=== UUID Generation Contention ===\n")
+	out.WriteString("generator,goroutines,n,uuids_per_sec\n")
+	for _, r := range results {
+		fmt.Fprintf(&out, "%s,%d,%d,%.0f\n", r.Generator, r.Goroutines, r.N, r.UUIDsPerSec)
+	}
+	return out.String()
+}
@@ -0,0 +1,55 @@
+package bench
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestContentionGoroutines(t *testing.T) {
+	tests := []struct {
+		name       string
+		goroutines int
+		want       []int
+	}{
+		{"goroutine数_1なら1だけ", 1, []int{1}},
+		{"goroutine数_2以上なら1と指定数", 8, []int{1, 8}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := contentionGoroutines(tt.goroutines); !slices.Equal(got, tt.want) {
+				t.Fatalf("contentionGoroutines(%d) = %v, want %v", tt.goroutines, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunUUIDContention(t *testing.T) {
+	t.Run("生成の競合_全生成器を計測して整形する", func(t *testing.T) {
+		results := RunUUIDContention(101, 4)
+		if len(results) != 2*len(contentionGenerators) {
+			t.Fatalf("len = %d, want %d", len(results), 2*len(contentionGenerators))
+		}
+		out := FormatUUIDContention(results)
+		if !strings.Contains(out, "generator,goroutines,n,uuids_per_sec\n") {
+			t.Fatalf("missing header: %s", out)
+		}
+		for _, r := range results {
+			if r.N != 101 || r.UUIDsPerSec <= 0 {
+				t.Fatalf("unexpected result: %+v", r)
+			}
+			if !strings.Contains(out, r.Generator+","+strconv.Itoa(r.Goroutines)+",101,") {
+				t.Fatalf("missing row for %s: %s", r.Generator, out)
+			}
+		}
+	})
+
+	t.Run("生成の競合_設定を切り替える生成器はsetupとteardownを対で持つ", func(t *testing.T) {
+		for _, g := range contentionGenerators {
+			if (g.setup == nil) != (g.teardown == nil) {
+				t.Fatalf("%s: setup and teardown must be set together", g.name)
+			}
+		}
+	})
+}
@@ -0,0 +1,57 @@
+package bench
+
+import (
+	"context"
+	"sync"
+)
+
+// cpuMeterKey は ctx に載せる cpuMeter のキー。
+type cpuMeterKey struct{}
+
+// cpuMeter は -client-cpu 指定時に、フェーズ名ごとのクライアントプロセスの CPU 秒数を積み上げる。
+// 計測はプロセス全体の CPU 時間の差分なので、同時に動く他の処理（GC など）も含む。
+type cpuMeter struct {
+	mu      sync.Mutex
+	seconds map[string]float64
+}
+
+// withCPUMeter は cfg.ClientCPU が真なら cpuMeter を載せた ctx とその cpuMeter を返す。
+// 偽なら ctx をそのまま返し、cpuMeter は nil（何も記録しない）。
+func withCPUMeter(ctx context.Context, cfg Config) (context.Context, *cpuMeter) {
+	if !cfg.ClientCPU {
+		return ctx, nil
+	}
+	m := &cpuMeter{seconds: make(map[string]float64)}
+	return context.WithValue(ctx, cpuMeterKey{}, m), m
+}
+
+// measureCPU は ctx に cpuMeter が載っていれば、呼び出しから終了関数までの CPU 秒数を phase に加える。
+// 載っていないか、この OS で CPU 時間を取れなければ何もしない終了関数を返す。
+func measureCPU(ctx context.Context, phase string) func() {
+	m, _ := ctx.Value(cpuMeterKey{}).(*cpuMeter)
+	if m == nil {
+		return func() {}
+	}
+	start, ok := processCPUSeconds()
+	if !ok {
+		return func() {}
+	}
+	return func() {
+		end, _ := processCPUSeconds()
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.seconds[phase] += end - start
+	}
+}
+
+// apply は積み上げた insert / point / range の CPU 秒数を r へ書き込む。m が nil なら何もしない。
+func (m *cpuMeter) apply(r *Result) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r.InsertCPUSeconds = m.seconds["insert"]
+	r.PointCPUSeconds = m.seconds["point"]
+	r.RangeCPUSeconds = m.seconds["range"]
+}
@@ -0,0 +1,8 @@
+//go:build !unix
+
+package bench
+
+// processCPUSeconds は getrusage のない OS では CPU 時間を取れないため false を返す。
+func processCPUSeconds() (float64, bool) {
+	return 0, false
+}
@@ -0,0 +1,41 @@
+package bench
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCPUMeter(t *testing.T) {
+	t.Run("無効_何も記録しない", func(t *testing.T) {
+		ctx, m := withCPUMeter(context.Background(), Config{})
+		if m != nil {
+			t.Fatal("meter must be nil when ClientCPU is off")
+		}
+		measureCPU(ctx, "insert")()
+		var r Result
+		m.apply(&r)
+		if r.InsertCPUSeconds != 0 {
+			t.Fatalf("insert cpu = %v", r.InsertCPUSeconds)
+		}
+	})
+
+	t.Run("有効_スパンの間のCPU時間をフェーズに加える", func(t *testing.T) {
+		start, ok := processCPUSeconds()
+		if !ok {
+			t.Skip("process CPU time is not available on this OS")
+		}
+		ctx, m := withCPUMeter(context.Background(), Config{ClientCPU: true})
+		_, end := startSpan(ctx, "point")
+		// CPU 時間の刻みを超えるまで回す。
+		deadline := time.Now().Add(2 * time.Second)
+		for now, _ := processCPUSeconds(); now-start < 0.01 && time.Now().Before(deadline); now, _ = processCPUSeconds() {
+		}
+		end(nil)
+		var r Result
+		m.apply(&r)
+		if r.PointCPUSeconds <= 0 || r.InsertCPUSeconds != 0 {
+			t.Fatalf("result = %+v", r)
+		}
+	})
+}
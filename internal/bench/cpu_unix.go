@@ -0,0 +1,15 @@
+//go:build unix
+
+package bench
+
+import "syscall"
+
+// processCPUSeconds はこのプロセスがこれまでに使ったユーザー + システム CPU 秒数を返す。
+func processCPUSeconds() (float64, bool) {
+	var ru syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &ru); err != nil {
+		return 0, false
+	}
+	tv := func(t syscall.Timeval) float64 { return float64(t.Sec) + float64(t.Usec)/1e6 }
+	return tv(ru.Utime) + tv(ru.Stime), true
+}
@@ -0,0 +1,44 @@
+package bench
+
+import (
+	"strings"
+)
+
+// ExportDDL は cfg で計測するときに作られるテーブルの CREATE 文（PostgreSQL の autovacuum 指定などの ALTER 文を含む）を、
+// DB ごとの見出し付きで 1 文ずつ ; で区切って返す。DROP 文は含めない。
+// 計測と同じスキーマを手元で再現したり、比較条件が公平かを確かめたりするために使う。
+// RegisterStrategy で追加した方式の Setup は DB へ直接実行するため含まれない。
+func ExportDDL(cfg Config) string {
+	var b strings.Builder
+	b.WriteString("-- MySQL\n")
+	writeDDL(&b, mysqlSetupStmts(cfg))
+	if !cfg.SkipPostgres {
+		b.WriteString("\n-- PostgreSQL\n")
+		writeDDL(&b, pgSetupStmts(cfg))
+	}
+	return b.String()
+}
+
+// writeDDL は stmts のうち DROP 以外の文を formatDDL で整えて b へ書く。
+func writeDDL(b *strings.Builder, stmts []string) {
+	for _, stmt := range stmts {
+		if strings.HasPrefix(stmt, "DROP ") {
+			continue
+		}
+		b.WriteString(formatDDL(stmt))
+		b.WriteString(";\n")
+	}
+}
+
+// formatDDL はソース中の字下げが残った文を、列定義だけを 2 文字下げた形に揃える。
+func formatDDL(stmt string) string {
+	lines := strings.Split(stmt, "\n")
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if i > 0 && !strings.HasPrefix(line, ")") {
+			line = "  " + line
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
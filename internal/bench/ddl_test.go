@@ -0,0 +1,50 @@
+package bench
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatDDL(t *testing.T) {
+	t.Run("DDL出力_列定義だけを字下げする", func(t *testing.T) {
+		got := formatDDL("CREATE TABLE bench_auto (\n\t\t\tid BIGINT NOT NULL,\n\t\t\tpayload TEXT\n\t\t) ENGINE=InnoDB")
+		want := "CREATE TABLE bench_auto (\n  id BIGINT NOT NULL,\n  payload TEXT\n) ENGINE=InnoDB"
+		if got != want {
+			t.Fatalf("formatDDL = %q, want %q", got, want)
+		}
+	})
+}
+
+func TestExportDDL(t *testing.T) {
+	t.Run("DDL出力_両DBのCREATE文だけを出す", func(t *testing.T) {
+		out := ExportDDL(DefaultConfig())
+		for _, want := range []string{"-- MySQL\n", "-- PostgreSQL\n", "CREATE TABLE bench_uuid_bin (", "CREATE TABLE bench_uuid (", ") ENGINE=InnoDB;\n"} {
+			if !strings.Contains(out, want) {
+				t.Fatalf("missing %q:\n%s", want, out)
+			}
+		}
+		if strings.Contains(out, "DROP ") {
+			t.Fatalf("DROP statements should be omitted:\n%s", out)
+		}
+	})
+
+	t.Run("DDL出力_有効にした方式とオプションを反映する", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.PGUUIDBytea = true
+		cfg.PGAutovacuum = "off"
+		out := ExportDDL(cfg)
+		for _, want := range []string{"CREATE TABLE bench_uuid_bytea (", "ALTER TABLE bench_uuid_bytea SET (autovacuum_enabled = off);\n"} {
+			if !strings.Contains(out, want) {
+				t.Fatalf("missing %q:\n%s", want, out)
+			}
+		}
+	})
+
+	t.Run("DDL出力_PostgreSQLを使わなければMySQLだけ", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.SkipPostgres = true
+		if out := ExportDDL(cfg); strings.Contains(out, "PostgreSQL") {
+			t.Fatalf("unexpected PostgreSQL section:\n%s", out)
+		}
+	})
+}
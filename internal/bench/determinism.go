@@ -0,0 +1,147 @@
+package bench
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// RankingCheck は 1 つの DB・接続先・指標について、2 回の実行で方式の速い順が変わらなかったか。
+type RankingCheck struct {
+	DB     string
+	Server string
+	Metric string
+	// First / Second はそれぞれの実行での速い順の方式名。両方の実行で計測できた方式だけを含む。
+	First  []string
+	Second []string
+}
+
+// Stable は 2 回の実行で順位が同じなら真を返す。
+func (c RankingCheck) Stable() bool {
+	return slices.Equal(c.First, c.Second)
+}
+
+// Flipped は 2 回の実行で前後が入れ替わった方式の組を "a/b" の形で返す。
+func (c RankingCheck) Flipped() []string {
+	pos := make(map[string]int, len(c.Second))
+	for i, t := range c.Second {
+		pos[t] = i
+	}
+	var out []string
+	for i, a := range c.First {
+		for _, b := range c.First[i+1:] {
+			if pos[a] > pos[b] {
+				out = append(out, a+"/"+b)
+			}
+		}
+	}
+	return out
+}
+
+// determinismMetrics は -determinism-check で順位を比べる指標。値が小さいほど速い。
+var determinismMetrics = []struct {
+	name string
+	sec  func(Result) float64
+}{
+	{"insert", func(r Result) float64 { return perOp(r.InsertSeconds, r.InsertRows) }},
+	{"point", func(r Result) float64 { return perOp(r.PointSeconds, r.PointLookupCount) }},
+	{"range", func(r Result) float64 { return r.RangeSeconds }},
+}
+
+// CheckDeterminism は同じ設定で 2 回実行した結果 first / second を DB・接続先ごとにまとめ、
+// 指標ごとに方式の速い順が一致したかを返す。比べるのは -compact-output と同じ単一テーブルの方式で、
+// 片方の実行でしか計測できなかった方式と、比べる方式が 2 つ未満の指標は含めない。
+func CheckDeterminism(first, second []Result) []RankingCheck {
+	type key struct{ db, server string }
+	group := func(results []Result) ([]key, map[key][]Result) {
+		var order []key
+		by := make(map[key][]Result)
+		for _, r := range results {
+			if !compactComparable(r) {
+				continue
+			}
+			k := key{r.DB, r.Server}
+			if _, ok := by[k]; !ok {
+				order = append(order, k)
+			}
+			by[k] = append(by[k], r)
+		}
+		return order, by
+	}
+	order, firstBy := group(first)
+	_, secondBy := group(second)
+	var out []RankingCheck
+	for _, k := range order {
+		for _, m := range determinismMetrics {
+			a, b := determinismRanking(firstBy[k], secondBy[k], m.sec)
+			if len(a) < 2 {
+				continue
+			}
+			out = append(out, RankingCheck{DB: k.db, Server: k.server, Metric: m.name, First: a, Second: b})
+		}
+	}
+	return out
+}
+
+// determinismRanking は first / second の両方で sec が正の方式を、それぞれの実行で速い順に並べて返す。
+// 同じ秒数なら結果の並びで先の方式を前にする。
+func determinismRanking(first, second []Result, sec func(Result) float64) ([]string, []string) {
+	secondSec := make(map[string]float64)
+	for _, r := range second {
+		if s := sec(r); s > 0 {
+			secondSec[r.Table] = s
+		}
+	}
+	var common []Result
+	for _, r := range first {
+		if _, ok := secondSec[r.Table]; ok && sec(r) > 0 {
+			common = append(common, r)
+		}
+	}
+	rank := func(sec func(Result) float64) []string {
+		rs := slices.Clone(common)
+		slices.SortStableFunc(rs, func(a, b Result) int {
+			switch sa, sb := sec(a), sec(b); {
+			case sa < sb:
+				return -1
+			case sa > sb:
+				return 1
+			}
+			return 0
+		})
+		names := make([]string, len(rs))
+		for i, r := range rs {
+			names[i] = r.Table
+		}
+		return names
+	}
+	return rank(sec), rank(func(r Result) float64 { return secondSec[r.Table] })
+}
+
+// FormatDeterminism は CheckDeterminism の結果を 1 指標 1 行で整形し、最後に安定した指標の数を出す。
+// 例: "MySQL point: FLIPPED bench_auto/bench_uuid_bin (run 1: bench_auto < bench_uuid_bin, run 2: bench_uuid_bin < bench_auto)"
+func FormatDeterminism(checks []RankingCheck) string {
+	var out strings.Builder
+	stable := 0
+	for _, c := range checks {
+		name := dbLabels[c.DB]
+		if name == "" {
+			name = c.DB
+		}
+		if c.Server != "" {
+			name += " (" + c.Server + ")"
+		}
+		if c.Stable() {
+			stable++
+			fmt.Fprintf(&out, "%s %s: stable (%s)\n", name, c.Metric, strings.Join(c.First, " < "))
+			continue
+		}
+		fmt.Fprintf(&out, "%s %s: FLIPPED %s (run 1: %s, run 2: %s)\n", name, c.Metric, strings.Join(c.Flipped(), ", "), strings.Join(c.First, " < "), strings.Join(c.Second, " < "))
+	}
+	fmt.Fprintf(&out, "determinism: %d/%d rankings stable", stable, len(checks))
+	if stable < len(checks) {
+		out.WriteString("; flipped rankings differ by less than the run-to-run noise")
+	}
+	out.WriteString("\n")
+	return out.String()
+}
@@ -0,0 +1,59 @@
+package bench
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCheckDeterminism(t *testing.T) {
+	run := func(auto, uuidBin, uuidChar float64) []Result {
+		return []Result{
+			{DB: "mysql", Table: "bench_auto", InsertRows: 100, InsertSeconds: auto, PointLookupCount: 10, PointSeconds: 1},
+			{DB: "mysql", Table: "bench_uuid_bin", InsertRows: 100, InsertSeconds: uuidBin, PointLookupCount: 10, PointSeconds: 2},
+			{DB: "mysql", Table: "bench_uuid_char", InsertRows: 100, InsertSeconds: uuidChar, PointLookupCount: 10, PointSeconds: 3},
+			{DB: "mysql", Table: "bench_auto_concurrent", InsertRows: 100, InsertSeconds: 0.1, Workers: 4},
+		}
+	}
+
+	t.Run("順位が同じなら安定", func(t *testing.T) {
+		checks := CheckDeterminism(run(1, 2, 3), run(1.1, 2.5, 2.9))
+		if len(checks) != 2 {
+			t.Fatalf("got %d checks, want insert and point", len(checks))
+		}
+		for _, c := range checks {
+			if !c.Stable() {
+				t.Fatalf("%s: %v vs %v", c.Metric, c.First, c.Second)
+			}
+		}
+		if !slices.Equal(checks[0].First, []string{"bench_auto", "bench_uuid_bin", "bench_uuid_char"}) {
+			t.Fatalf("並列挿入は比べない: %v", checks[0].First)
+		}
+	})
+
+	t.Run("入れ替わった組を挙げる", func(t *testing.T) {
+		checks := CheckDeterminism(run(1, 2, 3), run(1, 3.1, 3))
+		c := checks[0]
+		if c.Stable() || !slices.Equal(c.Flipped(), []string{"bench_uuid_bin/bench_uuid_char"}) {
+			t.Fatalf("got %v (first %v, second %v)", c.Flipped(), c.First, c.Second)
+		}
+		out := FormatDeterminism(checks)
+		for _, want := range []string{
+			"MySQL insert: FLIPPED bench_uuid_bin/bench_uuid_char (run 1: bench_auto < bench_uuid_bin < bench_uuid_char, run 2: bench_auto < bench_uuid_char < bench_uuid_bin)",
+			"MySQL point: stable (bench_auto < bench_uuid_bin < bench_uuid_char)",
+			"determinism: 1/2 rankings stable; flipped rankings differ by less than the run-to-run noise",
+		} {
+			if !strings.Contains(out, want) {
+				t.Fatalf("missing %q in\n%s", want, out)
+			}
+		}
+	})
+
+	t.Run("片方の実行にしかない方式は比べない", func(t *testing.T) {
+		second := run(1, 2, 3)[:2]
+		checks := CheckDeterminism(run(1, 2, 3), second)
+		if !slices.Equal(checks[0].First, []string{"bench_auto", "bench_uuid_bin"}) {
+			t.Fatalf("got %v", checks[0].First)
+		}
+	})
+}
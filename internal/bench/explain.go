@@ -0,0 +1,111 @@
+package bench
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// explainRange は cfg.ExplainRange が有効なら範囲検索 query の実行計画を取得し、インデックスを使うかを返す。
+// 使わない場合は全表走査の時間を範囲検索の性能と取り違えないよう警告する。無効なら nil を返す。
+func explainRange(ctx context.Context, db queryer, cfg Config, log *slog.Logger, kind, query string, args ...any) (*bool, error) {
+	if !cfg.ExplainRange {
+		return nil, nil
+	}
+	var used bool
+	var plan string
+	var err error
+	if kind == "mysql" {
+		used, plan, err = explainMySQL(ctx, db, query, args...)
+	} else {
+		used, plan, err = explainPG(ctx, db, query, args...)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s explain range query failed: %w", kind, err)
+	}
+	if !used {
+		log.Warn("range query does not use an index; range_or_orderby_sec is a full scan time", "query", query, "plan", plan)
+	}
+	return &used, nil
+}
+
+// explainMySQL は MySQL の EXPLAIN を実行し、全行がインデックスを使うかと計画の要約を返す。
+func explainMySQL(ctx context.Context, db queryer, query string, args ...any) (bool, string, error) {
+	rows, err := db.QueryContext(ctx, "EXPLAIN "+query, args...)
+	if err != nil {
+		return false, "", err
+	}
+	defer rows.Close()
+	cols, err := rows.Columns()
+	if err != nil {
+		return false, "", err
+	}
+	used := true
+	var summary []string
+	for rows.Next() {
+		vals := make([]sql.NullString, len(cols))
+		dest := make([]any, len(cols))
+		for i := range vals {
+			dest[i] = &vals[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return false, "", err
+		}
+		var typ, key string
+		for i, c := range cols {
+			switch strings.ToLower(c) {
+			case "type":
+				typ = vals[i].String
+			case "key":
+				key = vals[i].String
+			}
+		}
+		used = used && MySQLPlanUsesIndex(typ, key)
+		summary = append(summary, fmt.Sprintf("type=%s key=%s", typ, key))
+	}
+	return used, strings.Join(summary, "; "), rows.Err()
+}
+
+// explainPG は PostgreSQL の EXPLAIN を実行し、インデックスを使うかと計画の全行を返す。
+func explainPG(ctx context.Context, db queryer, query string, args ...any) (bool, string, error) {
+	rows, err := db.QueryContext(ctx, "EXPLAIN "+query, args...)
+	if err != nil {
+		return false, "", err
+	}
+	defer rows.Close()
+	var lines []string
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return false, "", err
+		}
+		lines = append(lines, line)
+	}
+	if err := rows.Err(); err != nil {
+		return false, "", err
+	}
+	return PGPlanUsesIndex(lines), strings.Join(lines, " | "), nil
+}
+
+// MySQLPlanUsesIndex は MySQL の EXPLAIN 1 行の type と key 列から、インデックスを使う計画かを返す。
+// type=ALL は全表走査、key が空ならインデックスを選んでいない。
+func MySQLPlanUsesIndex(typ, key string) bool {
+	return key != "" && !strings.EqualFold(typ, "ALL")
+}
+
+// PGPlanUsesIndex は PostgreSQL の EXPLAIN（テキスト形式）の各行から、インデックスを使う計画かを返す。
+// Seq Scan を含まず、Index Scan / Index Only Scan / Bitmap Index Scan のいずれかを含むときに真。
+func PGPlanUsesIndex(lines []string) bool {
+	used := false
+	for _, l := range lines {
+		if strings.Contains(l, "Seq Scan") {
+			return false
+		}
+		if strings.Contains(l, "Index Scan") || strings.Contains(l, "Index Only Scan") {
+			used = true
+		}
+	}
+	return used
+}
@@ -0,0 +1,43 @@
+package bench
+
+import "testing"
+
+func TestMySQLPlanUsesIndex(t *testing.T) {
+	tests := []struct {
+		name     string
+		typ, key string
+		want     bool
+	}{
+		{"主キー範囲", "range", "PRIMARY", true},
+		{"インデックス順の全走査", "index", "PRIMARY", true},
+		{"全表走査", "ALL", "", false},
+		{"キー未選択", "ref", "", false},
+	}
+	for _, tt := range tests {
+		t.Run("MySQL実行計画_"+tt.name, func(t *testing.T) {
+			if got := MySQLPlanUsesIndex(tt.typ, tt.key); got != tt.want {
+				t.Fatalf("MySQLPlanUsesIndex(%q, %q) = %v, want %v", tt.typ, tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPGPlanUsesIndex(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  bool
+	}{
+		{"インデックスのみ走査", []string{"Limit  (cost=0.42..300.42 rows=10000 width=16)", "  ->  Index Only Scan using bench_uuid_pkey on bench_uuid  (cost=0.42..3000.42 rows=100000 width=16)"}, true},
+		{"ビットマップ走査", []string{"Aggregate", "  ->  Bitmap Heap Scan on bench_auto", "        ->  Bitmap Index Scan on bench_auto_pkey"}, true},
+		{"全表走査と並べ替え", []string{"Limit", "  ->  Sort", "        ->  Seq Scan on bench_uuid"}, false},
+		{"範囲でも全表走査", []string{"Aggregate", "  ->  Seq Scan on bench_auto", "        Filter: ((id >= 25001) AND (id <= 75001))"}, false},
+	}
+	for _, tt := range tests {
+		t.Run("PostgreSQL実行計画_"+tt.name, func(t *testing.T) {
+			if got := PGPlanUsesIndex(tt.lines); got != tt.want {
+				t.Fatalf("PGPlanUsesIndex = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
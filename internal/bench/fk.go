@@ -0,0 +1,153 @@
+package bench
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+
+	"github.com/google/uuid"
+)
+
+// fkSeed は子テーブルへ挿入する行の参照先を選ぶ乱数の既定シード。
+// 制約あり/なしの子テーブルで同じ参照先の並びになるよう、子テーブルごとに同じシードから始める。
+const fkSeed = 20260601
+
+// fkChild は外部キー計測の子テーブル 1 つぶん。
+// 制約なしの子テーブルも parent_id のインデックスは持ち、差が制約の検査だけになるようにする。
+type fkChild struct {
+	Table   string
+	Parent  string
+	KeyType string
+	FK      bool
+}
+
+// fkChildren は kind の子テーブルを返す。親は連番の bench_auto と、
+// UUID 主キーの bench_uuid_bin（MySQL）/ bench_uuid（PostgreSQL）。
+func fkChildren(kind string) []fkChild {
+	autoType, uuidParent, uuidType := "BIGINT", "bench_uuid_bin", "BINARY(16)"
+	if kind == "postgres" {
+		uuidParent, uuidType = "bench_uuid", "UUID"
+	}
+	return []fkChild{
+		{Table: "bench_child_auto", Parent: "bench_auto", KeyType: autoType, FK: true},
+		{Table: "bench_child_auto_nofk", Parent: "bench_auto", KeyType: autoType},
+		{Table: "bench_child_uuid", Parent: uuidParent, KeyType: uuidType, FK: true},
+		{Table: "bench_child_uuid_nofk", Parent: uuidParent, KeyType: uuidType},
+	}
+}
+
+// fkTables は kind の子テーブル名を返す。
+func fkTables(kind string) []string {
+	var tables []string
+	for _, c := range fkChildren(kind) {
+		tables = append(tables, c.Table)
+	}
+	return tables
+}
+
+// fkDropStmts は子テーブルを消す文を返す。外部キーで参照されている親は消せないため、親より先に実行する。
+func fkDropStmts(kind string) []string {
+	var stmts []string
+	for _, table := range fkTables(kind) {
+		stmts = append(stmts, "DROP TABLE IF EXISTS "+table)
+	}
+	return stmts
+}
+
+// fkCreateStmts は子テーブルを作る文を返す。親テーブルを作った後に実行する。
+// PostgreSQL は外部キーの参照元にインデックスを自動で作らないため、CREATE INDEX を別に発行する。
+func fkCreateStmts(kind, extra string) []string {
+	var stmts []string
+	for _, c := range fkChildren(kind) {
+		if kind == "postgres" {
+			ref := ""
+			if c.FK {
+				ref = fmt.Sprintf(" REFERENCES %s (id)", c.Parent)
+			}
+			stmts = append(stmts, fmt.Sprintf(`CREATE TABLE %s (
+			id BIGSERIAL PRIMARY KEY,
+			parent_id %s NOT NULL%s,
+			payload TEXT NOT NULL%s
+		)`, c.Table, c.KeyType, ref, extra),
+				fmt.Sprintf("CREATE INDEX idx_%s_parent ON %s (parent_id)", c.Table, c.Table))
+			continue
+		}
+		constraint := ""
+		if c.FK {
+			constraint = fmt.Sprintf(",\n\t\t\tCONSTRAINT fk_%s FOREIGN KEY (parent_id) REFERENCES %s (id)", c.Table, c.Parent)
+		}
+		stmts = append(stmts, fmt.Sprintf(`CREATE TABLE %s (
+			id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+			parent_id %s NOT NULL,
+			payload VARCHAR(100) NOT NULL%s,
+			KEY idx_%s_parent (parent_id)%s
+		) ENGINE=InnoDB`, c.Table, c.KeyType, extra, c.Table, constraint))
+	}
+	return stmts
+}
+
+// runForeignKeys は選択された子テーブルへ、親テーブルの既存キーをランダムに参照する行を挿入して計測する。
+// 親テーブルは通常の計測で埋まっている必要がある。
+func runForeignKeys(ctx context.Context, db *sql.DB, cfg Config, kind string) ([]Result, error) {
+	var results []Result
+	for _, c := range fkChildren(kind) {
+		if !strategySelected(cfg, c.Table) {
+			continue
+		}
+		var (
+			r   Result
+			err error
+		)
+		switch {
+		case c.Parent == "bench_auto":
+			r, err = benchChild[int64](ctx, db, cfg, kind, c)
+		case kind == "mysql":
+			r, err = benchChild[[]byte](ctx, db, cfg, kind, c)
+		default:
+			r, err = benchChild[uuid.UUID](ctx, db, cfg, kind, c)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", c.Table, err)
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+// benchChild は c.Parent のキーを読み出し、それを参照する子行を c.Table へ挿入する時間を計る。
+func benchChild[K any](ctx context.Context, db *sql.DB, cfg Config, kind string, c fkChild) (Result, error) {
+	log := slog.With("db", kind, "table", c.Table, "parent", c.Parent, "fk", c.FK)
+	keys, err := selectKeys[K](ctx, db, c.Parent)
+	if err != nil {
+		return Result{}, err
+	}
+	if len(keys) == 0 {
+		return Result{}, fmt.Errorf("parent table %s is empty; run the foreign-key benchmark together with that strategy", c.Parent)
+	}
+	gen := payloadFor(cfg)
+	insertStmt, err := prepare(ctx, db, cfg, insertSQL(kind, c.Table, []string{"parent_id", "payload"}, gen.Columns()))
+	if err != nil {
+		return Result{}, err
+	}
+	defer insertStmt.Close()
+
+	r := rand.New(rand.NewPCG(fkSeed, fkSeed))
+	inserted, insertSec, err := insertLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		// 子の payload は親と区別できるよう PayloadGenerator の値の代わりに "c-<行番号>" を入れる。
+		args := insertArgs(gen, i, keys[r.IntN(len(keys))])
+		args[1] = fmt.Sprintf("c-%d", i)
+		_, err := insertStmt.ExecContext(ctx, args...)
+		return err
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{
+		DB:            kind,
+		Table:         c.Table,
+		InsertRows:    inserted,
+		InsertSeconds: insertSec,
+	}, nil
+}
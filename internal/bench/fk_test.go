@@ -0,0 +1,49 @@
+package bench
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFKCreateStmts(t *testing.T) {
+	t.Run("MySQL_制約ありの子テーブルだけFOREIGN_KEYを持つ", func(t *testing.T) {
+		stmts := fkCreateStmts("mysql", "")
+		if len(stmts) != 4 {
+			t.Fatalf("stmts = %d, want 4", len(stmts))
+		}
+		for i, c := range fkChildren("mysql") {
+			hasFK := strings.Contains(stmts[i], "FOREIGN KEY (parent_id) REFERENCES "+c.Parent+" (id)")
+			if hasFK != c.FK || !strings.Contains(stmts[i], "KEY idx_"+c.Table+"_parent (parent_id)") {
+				t.Fatalf("%s: fk = %v, stmt = %s", c.Table, c.FK, stmts[i])
+			}
+		}
+		if !strings.Contains(stmts[2], "parent_id BINARY(16) NOT NULL") || !strings.Contains(stmts[2], "REFERENCES bench_uuid_bin (id)") {
+			t.Fatalf("uuid child = %s", stmts[2])
+		}
+	})
+
+	t.Run("PostgreSQL_参照元のインデックスを別に作る", func(t *testing.T) {
+		stmts := fkCreateStmts("postgres", "")
+		if len(stmts) != 8 {
+			t.Fatalf("stmts = %d, want 8", len(stmts))
+		}
+		if !strings.Contains(stmts[4], "parent_id UUID NOT NULL REFERENCES bench_uuid (id)") {
+			t.Fatalf("uuid child = %s", stmts[4])
+		}
+		if stmts[5] != "CREATE INDEX idx_bench_child_uuid_parent ON bench_child_uuid (parent_id)" {
+			t.Fatalf("index = %s", stmts[5])
+		}
+		if strings.Contains(stmts[6], "REFERENCES") {
+			t.Fatalf("nofk child must not reference the parent: %s", stmts[6])
+		}
+	})
+
+	t.Run("スコアカード_子テーブルは制約の有無が同じ連番参照を基準にする", func(t *testing.T) {
+		if got := scorecardBaselineFor("bench_child_uuid"); got != "bench_child_auto" {
+			t.Fatalf("baseline = %s", got)
+		}
+		if got := scorecardBaselineFor("bench_child_uuid_nofk"); got != "bench_child_auto_nofk" {
+			t.Fatalf("baseline = %s", got)
+		}
+	})
+}
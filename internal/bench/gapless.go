@@ -0,0 +1,262 @@
+package bench
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// gaplessCounterDDL は -gapless の方式が番号を採るカウンタ表を作る文を返す。
+// 方式（テーブル名）ごとに 1 行を持ち、value に最後に採った番号を入れる。
+func gaplessCounterDDL(kind string) string {
+	if kind == "mysql" {
+		return `CREATE TABLE bench_gapless_counter (
+			name VARCHAR(64) NOT NULL PRIMARY KEY,
+			value BIGINT NOT NULL
+		) ENGINE=InnoDB`
+	}
+	return `CREATE TABLE bench_gapless_counter (
+			name TEXT PRIMARY KEY,
+			value BIGINT NOT NULL
+		)`
+}
+
+// gaplessCounterSeed は tables の番号を 0 から始めるカウンタ行を入れる文を返す。
+func gaplessCounterSeed(tables ...string) string {
+	rows := ""
+	for i, table := range tables {
+		if i > 0 {
+			rows += ", "
+		}
+		rows += fmt.Sprintf("('%s', 0)", table)
+	}
+	return "INSERT INTO bench_gapless_counter (name, value) VALUES " + rows
+}
+
+// gaplessTables は -gapless で欠番なしの連番を使うテーブル。カウンタ表にはこの名前で行を作る。
+func gaplessTables(cfg Config) []string {
+	if cfg.ConcurrentWorkers > 0 {
+		return []string{"bench_gapless", "bench_gapless_concurrent"}
+	}
+	return []string{"bench_gapless"}
+}
+
+// gaplessSQL はカウンタ行をロックして最後の番号を読む文と、番号を進める文を返す。
+func gaplessSQL(kind string) (lock, advance string) {
+	if kind == "mysql" {
+		return "SELECT value FROM bench_gapless_counter WHERE name = ? FOR UPDATE",
+			"UPDATE bench_gapless_counter SET value = ? WHERE name = ?"
+	}
+	return "SELECT value FROM bench_gapless_counter WHERE name = $1 FOR UPDATE",
+		"UPDATE bench_gapless_counter SET value = $1 WHERE name = $2"
+}
+
+// gaplessSeq は会計帳票の伝票番号のような「欠番のない連番」を、カウンタ表の行ロックで採る。
+// 1 行の挿入ごとにトランザクションを開き、カウンタ行を SELECT ... FOR UPDATE でロックして番号を 1 進め、
+// 同じトランザクションで行を挿入してコミットする。挿入が失敗すれば番号ごとロールバックされるため欠番が出ないが、
+// カウンタ行のロックはコミットまで続くため、同じ表への挿入はすべて直列になる。
+type gaplessSeq struct {
+	db      *sql.DB
+	cfg     Config
+	gen     PayloadGenerator
+	name    string
+	lock    stmt
+	advance stmt
+	insert  stmt
+}
+
+// newGaplessSeq は kind の table へ欠番なしの連番で挿入する gaplessSeq を作る。
+// カウンタ行の名前は table にする。使い終わったら Close する。
+func newGaplessSeq(ctx context.Context, db *sql.DB, cfg Config, kind, table string) (*gaplessSeq, error) {
+	lockSQL, advanceSQL := gaplessSQL(kind)
+	s := &gaplessSeq{db: db, cfg: cfg, gen: payloadFor(cfg), name: table}
+	var err error
+	if s.lock, err = prepare(ctx, db, cfg, lockSQL); err != nil {
+		return nil, err
+	}
+	if s.advance, err = prepare(ctx, db, cfg, advanceSQL); err != nil {
+		s.Close()
+		return nil, err
+	}
+	if s.insert, err = prepare(ctx, db, cfg, insertSQL(kind, table, []string{"id", "payload"}, s.gen.Columns())); err != nil {
+		s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Close は準備した文を閉じる。
+func (s *gaplessSeq) Close() error {
+	var errs []error
+	for _, st := range []stmt{s.lock, s.advance, s.insert} {
+		if st != nil {
+			errs = append(errs, st.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// next は番号を 1 つ採り、i 行目の値で挿入してコミットする。採った番号を返す。
+// -simulated-rtt の遅延は呼び出し側のループが INSERT の 1 往復ぶんを入れるため、ここでは
+// BEGIN・ロック・番号の更新・COMMIT の残り 4 往復ぶんを入れる。ロックを持ったまま待つ時間も含まれる。
+func (s *gaplessSeq) next(ctx context.Context, i int) (int64, error) {
+	if err := simulateRTT(ctx, s.cfg); err != nil {
+		return 0, err
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	// コミット後の Rollback は sql.ErrTxDone を返すだけなので無視する。
+	defer tx.Rollback()
+	if err := simulateRTT(ctx, s.cfg); err != nil {
+		return 0, err
+	}
+	var last int64
+	if err := txStmt(ctx, tx, s.lock).QueryRowContext(ctx, s.name).Scan(&last); err != nil {
+		return 0, fmt.Errorf("gapless counter %s lock failed: %w", s.name, err)
+	}
+	if err := simulateRTT(ctx, s.cfg); err != nil {
+		return 0, err
+	}
+	id := last + 1
+	if _, err := txStmt(ctx, tx, s.advance).ExecContext(ctx, id, s.name); err != nil {
+		return 0, fmt.Errorf("gapless counter %s advance failed: %w", s.name, err)
+	}
+	if _, err := txStmt(ctx, tx, s.insert).ExecContext(ctx, insertArgs(s.gen, i, id)...); err != nil {
+		return 0, err
+	}
+	if err := simulateRTT(ctx, s.cfg); err != nil {
+		return 0, err
+	}
+	return id, tx.Commit()
+}
+
+// txStmt は s を tx の中で使う文にする。プリペアドステートメントは tx の接続で準備し直したものを、
+// adhocStmt は tx へ送るものを返す。
+func txStmt(ctx context.Context, tx *sql.Tx, s stmt) stmt {
+	switch s := s.(type) {
+	case *sql.Stmt:
+		return tx.StmtContext(ctx, s)
+	case adhocStmt:
+		return adhocStmt{db: tx, query: s.query}
+	}
+	return s
+}
+
+// benchMySQLGapless は MySQL のカウンタ表で採る欠番なしの BIGINT 主キー (bench_gapless) を計測する。
+func benchMySQLGapless(ctx context.Context, db *sql.DB, cfg Config) (Result, error) {
+	return benchGapless(ctx, db, cfg, "mysql")
+}
+
+// benchPGGapless は PostgreSQL のカウンタ表で採る欠番なしの BIGINT 主キー (bench_gapless) を計測する。
+func benchPGGapless(ctx context.Context, db *sql.DB, cfg Config) (Result, error) {
+	return benchGapless(ctx, db, cfg, "postgres")
+}
+
+// benchGapless は gaplessSeq で番号を採って bench_gapless へ挿入し、bench_auto と同じ読み取りフェーズを計る。
+// 挿入位置は連番と同じく末尾に並ぶため、bench_auto との Insert の差が 1 行ごとのトランザクションと
+// カウンタ行のロックのコストになる。
+func benchGapless(ctx context.Context, db *sql.DB, cfg Config, kind string) (Result, error) {
+	const table = "bench_gapless"
+	log := slog.With("db", kind, "table", table)
+	seq, err := newGaplessSeq(ctx, db, cfg, kind, table)
+	if err != nil {
+		return Result{}, err
+	}
+	defer seq.Close()
+
+	// 採った番号を挿入順に保持する。
+	ids := make([]int64, 0, cfg.Rows)
+	inserted, insertSec, err := insertLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		id, err := seq.next(ctx, i)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+		return nil
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	if err := analyzeTable(ctx, db, cfg, log, kind, table); err != nil {
+		return Result{}, err
+	}
+
+	sample := ids
+	if len(sample) > cfg.Lookups {
+		sample = sample[:cfg.Lookups]
+	}
+	selectStmt, err := prepare(ctx, db, cfg, "SELECT payload FROM "+table+" WHERE id = "+placeholders(kind, 1))
+	if err != nil {
+		return Result{}, err
+	}
+	defer selectStmt.Close()
+
+	// Point Lookup 計測: 主キー完全一致検索の反復時間。
+	gen := payloadFor(cfg)
+	point, err := pointLoop(ctx, cfg, log, len(sample), func(ctx context.Context, i int) error {
+		return scanPayload(cfg, gen, i, sample[i], selectStmt.QueryRowContext(ctx, sample[i]))
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	// 範囲検索の下限/上限は採った番号の 25%〜75% 点から決める。
+	var rangeSec float64
+	var rangeUsedIndex *bool
+	if len(ids) > 0 {
+		lo, hi := ids[len(ids)/4], ids[len(ids)*3/4]
+		p1, p2 := "?", "?"
+		if kind == "postgres" {
+			p1, p2 = "$1", "$2"
+		}
+		rangeSQL := "SELECT COUNT(*) FROM " + table + " WHERE id BETWEEN " + p1 + " AND " + p2
+		log.Debug("range scan start")
+		_, endRange := startSpan(ctx, "range")
+		rctx, cancel := queryContext(ctx, cfg)
+		defer cancel()
+		start := time.Now()
+		var c int64
+		if err := db.QueryRowContext(rctx, rangeSQL, lo, hi).Scan(&c); err != nil {
+			return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
+		}
+		rangeSec = time.Since(start).Seconds()
+		log.Info("range scan done", "sec", rangeSec)
+		endRange(nil)
+		if rangeUsedIndex, err = explainRange(ctx, db, cfg, log, kind, rangeSQL, lo, hi); err != nil {
+			return Result{}, err
+		}
+	}
+
+	// Insert→Readback 計測: 番号はカウンタ表から採るまで分からないため、採った番号で読み戻す。
+	readbackSec, err := readbackLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		id, err := seq.next(ctx, inserted+i)
+		if err != nil {
+			return err
+		}
+		var payload sql.NullString
+		return selectStmt.QueryRowContext(ctx, id).Scan(&payload)
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		DB:                    kind,
+		Table:                 table,
+		InsertRows:            inserted,
+		InsertSeconds:         insertSec,
+		PointLookupCount:      len(sample),
+		PointSeconds:          point.Seconds,
+		PointRounds:           point.Rounds,
+		PointWarmSeconds:      point.Warm,
+		PointSteadySeconds:    point.Steady,
+		RangeSeconds:          rangeSec,
+		RangeUsedIndex:        rangeUsedIndex,
+		InsertReadbackSeconds: readbackSec,
+	}, nil
+}
@@ -0,0 +1,64 @@
+package bench
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestGaplessSetup(t *testing.T) {
+	t.Run("欠番なし_並列挿入なしなら1表", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Gapless = true
+		if got := gaplessTables(cfg); !slices.Equal(got, []string{"bench_gapless"}) {
+			t.Fatalf("gaplessTables = %v", got)
+		}
+	})
+
+	t.Run("欠番なし_カウンタ行は表ごとに0から", func(t *testing.T) {
+		got := gaplessCounterSeed("bench_gapless", "bench_gapless_concurrent")
+		want := "INSERT INTO bench_gapless_counter (name, value) VALUES ('bench_gapless', 0), ('bench_gapless_concurrent', 0)"
+		if got != want {
+			t.Fatalf("gaplessCounterSeed = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("欠番なし_カウンタ表は対象の表の後に作って埋める", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Gapless = true
+		cfg.ConcurrentWorkers = 2
+		for _, kind := range []string{"mysql", "postgres"} {
+			stmts := mysqlSetupStmts(cfg)
+			if kind == "postgres" {
+				stmts = pgSetupStmts(cfg)
+			}
+			idx := func(prefix string) int {
+				return slices.IndexFunc(stmts, func(s string) bool { return strings.HasPrefix(s, prefix) })
+			}
+			table, concurrent := idx("CREATE TABLE bench_gapless ("), idx("CREATE TABLE bench_gapless_concurrent (")
+			counter, seed := idx("CREATE TABLE bench_gapless_counter ("), idx("INSERT INTO bench_gapless_counter ")
+			if table < 0 || concurrent < 0 || counter < table || seed < counter {
+				t.Fatalf("%s: table=%d concurrent=%d counter=%d seed=%d", kind, table, concurrent, counter, seed)
+			}
+		}
+	})
+
+	t.Run("欠番なし_行ロックで読む", func(t *testing.T) {
+		lock, advance := gaplessSQL("postgres")
+		if !strings.HasSuffix(lock, "WHERE name = $1 FOR UPDATE") || !strings.Contains(advance, "SET value = $1 WHERE name = $2") {
+			t.Fatalf("gaplessSQL = %q, %q", lock, advance)
+		}
+	})
+}
+
+func TestConcurrentTables(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ConcurrentWorkers = 2
+	if got := concurrentTables(cfg); len(got) != 2 {
+		t.Fatalf("concurrentTables = %v, want 2 tables", got)
+	}
+	cfg.Gapless = true
+	if got := concurrentTables(cfg); got[len(got)-1] != "bench_gapless_concurrent" {
+		t.Fatalf("concurrentTables = %v, want bench_gapless_concurrent last", got)
+	}
+}
@@ -0,0 +1,59 @@
+package bench
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"math/rand/v2"
+)
+
+// hotColdSeed は HotColdSplit の既定シード。実行間で同じ検索順になるよう固定する。
+const hotColdSeed = 20260412
+
+// HotColdSplit は挿入順に並んだ n 件を、直近 hotFraction ぶんの hot 範囲とそれより古い cold 範囲に分け、
+// それぞれから k 件ずつ検索対象の添字を選んでシード固定で並べ替えて返す。
+// 範囲が k 件より狭い場合は同じ行を繰り返し選ぶ。cold 範囲が空なら cold は nil。
+func HotColdSplit(n, k int, hotFraction float64) (hot, cold []int) {
+	if n <= 0 || k <= 0 {
+		return nil, nil
+	}
+	h := min(max(int(math.Ceil(float64(n)*hotFraction)), 1), n)
+	r := rand.New(rand.NewPCG(hotColdSeed, hotColdSeed))
+	pick := func(off, size int) []int {
+		if size <= 0 {
+			return nil
+		}
+		idx := make([]int, k)
+		for i := range idx {
+			idx[i] = off + int(int64(i)*int64(size)/int64(k))
+		}
+		r.Shuffle(len(idx), func(i, j int) { idx[i], idx[j] = idx[j], idx[i] })
+		return idx
+	}
+	return pick(n-h, h), pick(0, n-h)
+}
+
+// hotColdLoop は cfg.HotFraction が正なら、挿入順の keys を HotColdSplit で分けて
+// 直近の行と古い行それぞれ cfg.Lookups 件の点検索時間を返す。0 なら何もせず 0 を返す。
+// 連番は直近の行がインデックス末尾の同じページに集まるのに対し、UUID では散らばる差を見る。
+func hotColdLoop[K any](ctx context.Context, cfg Config, log *slog.Logger, keys []K, lookup func(ctx context.Context, key K) error) (float64, float64, error) {
+	if cfg.HotFraction <= 0 {
+		return 0, 0, nil
+	}
+	hot, cold := HotColdSplit(len(keys), cfg.Lookups, cfg.HotFraction)
+	// -latency-dump には通常の点検索だけを書き出す。
+	ctx = withoutLatencyDump(ctx)
+	hotT, err := pointLoop(ctx, cfg, log.With("phase", "hot"), len(hot), func(ctx context.Context, i int) error {
+		return lookup(ctx, keys[hot[i]])
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	coldT, err := pointLoop(ctx, cfg, log.With("phase", "cold"), len(cold), func(ctx context.Context, i int) error {
+		return lookup(ctx, keys[cold[i]])
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return hotT.Seconds, coldT.Seconds, nil
+}
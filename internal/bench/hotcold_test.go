@@ -0,0 +1,76 @@
+package bench
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"testing"
+)
+
+func TestHotColdSplit(t *testing.T) {
+	t.Run("HotCold_直近の行と古い行に分ける", func(t *testing.T) {
+		hot, cold := HotColdSplit(100, 10, 0.1)
+		if len(hot) != 10 || len(cold) != 10 {
+			t.Fatalf("len(hot) = %d, len(cold) = %d, want 10 each", len(hot), len(cold))
+		}
+		for _, i := range hot {
+			if i < 90 || i >= 100 {
+				t.Fatalf("hot index %d outside the newest 10 rows", i)
+			}
+		}
+		for _, i := range cold {
+			if i >= 90 {
+				t.Fatalf("cold index %d inside the hot rows", i)
+			}
+		}
+		sorted := slices.Sorted(slices.Values(cold))
+		if sorted[0] != 0 || sorted[9] != 81 {
+			t.Fatalf("cold sample is not spread over old rows: %v", sorted)
+		}
+	})
+
+	t.Run("HotCold_範囲が狭ければ同じ行を繰り返す", func(t *testing.T) {
+		hot, _ := HotColdSplit(100, 6, 0.02)
+		for _, i := range hot {
+			if i != 98 && i != 99 {
+				t.Fatalf("hot index %d outside the newest 2 rows", i)
+			}
+		}
+	})
+
+	t.Run("HotCold_全件がhotならcoldは空", func(t *testing.T) {
+		if _, cold := HotColdSplit(5, 3, 1); cold != nil {
+			t.Fatalf("cold = %v, want nil", cold)
+		}
+	})
+}
+
+func TestHotColdLoop(t *testing.T) {
+	t.Run("HotCold_0なら検索しない", func(t *testing.T) {
+		calls := 0
+		hot, cold, err := hotColdLoop(context.Background(), DefaultConfig(), slog.Default(), []int{1, 2, 3}, func(context.Context, int) error {
+			calls++
+			return nil
+		})
+		if err != nil || hot != 0 || cold != 0 || calls != 0 {
+			t.Fatalf("hot = %v, cold = %v, calls = %d, err = %v", hot, cold, calls, err)
+		}
+	})
+
+	t.Run("HotCold_直近と古いキーをそれぞれ検索する", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Lookups = 4
+		cfg.HotFraction = 0.5
+		var got []int
+		_, _, err := hotColdLoop(context.Background(), cfg, slog.Default(), []int{10, 11, 12, 13, 14, 15, 16, 17}, func(_ context.Context, k int) error {
+			got = append(got, k)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 8 || slices.Min(got[:4]) < 14 || slices.Max(got[4:]) > 13 {
+			t.Fatalf("looked up %v", got)
+		}
+	})
+}
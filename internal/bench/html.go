@@ -0,0 +1,130 @@
+package bench
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+// htmlBarMaxWidth は最大値の棒の幅 (px)。他の棒はこれに対する比で描く。
+const htmlBarMaxWidth = 380
+
+// htmlBar は SVG 棒グラフの 1 本を表す。
+type htmlBar struct {
+	Label string
+	Value string
+	Y     int
+	Width float64
+}
+
+// htmlChart は 1 指標ぶんの棒グラフを表す。
+type htmlChart struct {
+	Title  string
+	Height int
+	Bars   []htmlBar
+}
+
+// htmlGroup は 1 DB ぶんのグラフ群を表す。
+type htmlGroup struct {
+	DB     string
+	Charts []htmlChart
+}
+
+// htmlMetric は HTML レポートへ描く指標の定義。
+type htmlMetric struct {
+	Title string
+	Value func(Result) float64
+}
+
+var htmlMetrics = []htmlMetric{
+	{"Insert (sec, lower is better)", func(r Result) float64 { return r.InsertSeconds }},
+	{"Point Lookup (sec, lower is better)", func(r Result) float64 { return r.PointSeconds }},
+	{"Range / ORDER BY (sec, lower is better)", func(r Result) float64 { return r.RangeSeconds }},
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="ja">
+<head>
+<meta charset="utf-8">
+<title>UUID vs AUTO_INCREMENT Benchmark</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+h2 { border-bottom: 1px solid #ccc; padding-bottom: .2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: .3em .6em; text-align: right; }
+th:first-child, td:first-child { text-align: left; }
+svg text { font-size: 12px; }
+</style>
+</head>
+<body>
+<h1>UUID vs AUTO_INCREMENT Benchmark</h1>
+<table>
+<tr>{{range .Header}}<th>{{.}}</th>{{end}}</tr>
+{{range .Rows}}<tr>{{range $i, $f := .}}<td>{{$f}}</td>{{end}}</tr>
+{{end}}</table>
+{{range .Groups}}<h2>{{.DB}}</h2>
+{{range .Charts}}<h3>{{.Title}}</h3>
+<svg width="680" height="{{.Height}}" xmlns="http://www.w3.org/2000/svg">
+{{range .Bars}}<text x="0" y="{{.Y}}" dy="15">{{.Label}}</text>
+<rect x="180" y="{{.Y}}" width="{{printf "%.1f" .Width}}" height="20" fill="#4a7fb5"></rect>
+<text x="{{printf "%.1f" .Width}}" dx="186" y="{{.Y}}" dy="15">{{.Value}}</text>
+{{end}}</svg>
+{{end}}{{end}}</body>
+</html>
+`))
+
+// FormatResultsHTML は計測結果を単体で開ける HTML レポートに整形する。
+// DB ごとに指標別のインライン SVG 棒グラフを描き、外部リソースには依存しない。
+func FormatResultsHTML(results []Result) string {
+	var dbs []string
+	byDB := make(map[string][]Result)
+	for _, r := range results {
+		if _, ok := byDB[r.DB]; !ok {
+			dbs = append(dbs, r.DB)
+		}
+		byDB[r.DB] = append(byDB[r.DB], r)
+	}
+
+	groups := make([]htmlGroup, 0, len(dbs))
+	for _, db := range dbs {
+		g := htmlGroup{DB: db}
+		for _, m := range htmlMetrics {
+			g.Charts = append(g.Charts, htmlBarChart(m, byDB[db]))
+		}
+		groups = append(groups, g)
+	}
+	cols := columnsFor(results)
+	rows := make([][]string, 0, len(results))
+	for _, r := range results {
+		rows = append(rows, columnValues(cols, r, DefaultPrecision))
+	}
+
+	var out strings.Builder
+	if err := htmlReportTemplate.Execute(&out, struct {
+		Header []string
+		Rows   [][]string
+		Groups []htmlGroup
+	}{columnNames(cols), rows, groups}); err != nil {
+		// テンプレートは固定でデータも単純なため、失敗はプログラムの誤り。
+		panic(fmt.Sprintf("html report template: %v", err))
+	}
+	return out.String()
+}
+
+// htmlBarChart は results の指標 m を最大値基準の棒グラフへ変換する。
+func htmlBarChart(m htmlMetric, results []Result) htmlChart {
+	maxV := 0.0
+	for _, r := range results {
+		maxV = max(maxV, m.Value(r))
+	}
+	c := htmlChart{Title: m.Title, Height: len(results)*28 + 8}
+	for i, r := range results {
+		v := m.Value(r)
+		w := 0.0
+		if maxV > 0 {
+			w = v / maxV * htmlBarMaxWidth
+		}
+		c.Bars = append(c.Bars, htmlBar{Label: r.Table, Value: fmt.Sprintf("%.6f", v), Y: i*28 + 4, Width: w})
+	}
+	return c
+}
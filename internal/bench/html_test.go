@@ -0,0 +1,33 @@
+package bench
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatResultsHTML(t *testing.T) {
+	t.Run("HTMLレポート_DBごとにグラフを出力する", func(t *testing.T) {
+		out := FormatResultsHTML([]Result{
+			{DB: "mysql", Table: "bench_auto", InsertSeconds: 2, PointSeconds: 1, RangeSeconds: 0.1},
+			{DB: "mysql", Table: "bench_uuid_bin", InsertSeconds: 4, PointSeconds: 1, RangeSeconds: 0.1},
+			{DB: "postgres", Table: "bench_uuid", InsertSeconds: 1, PointSeconds: 1, RangeSeconds: 0.1},
+		})
+		if !strings.HasPrefix(out, "<!DOCTYPE html>") {
+			t.Fatalf("missing doctype")
+		}
+		if strings.Count(out, "<svg") != 6 {
+			t.Fatalf("svg count = %d, want 6 (2 DBs x 3 metrics)", strings.Count(out, "<svg"))
+		}
+		// 最大値の棒は最大幅、半分の値は半分の幅で描く。
+		if !strings.Contains(out, `width="380.0"`) || !strings.Contains(out, `width="190.0"`) {
+			t.Fatalf("bar widths are not scaled to the max value")
+		}
+	})
+
+	t.Run("HTMLレポート_テーブル名をエスケープする", func(t *testing.T) {
+		out := FormatResultsHTML([]Result{{DB: "mysql", Table: "<script>"}})
+		if strings.Contains(out, "<script>") {
+			t.Fatalf("table name is not escaped")
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package bench
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// uuidCharKey は CHAR(36) 方式の i 行目のキー（ハイフン付き 36 文字）を返す。
+func uuidCharKey(cfg Config, i int) string {
+	return newUUID(cfg, i).String()
+}
+
+// uuidHexKey は CHAR(32) 方式の i 行目のキー（ハイフンなし 32 文字）を返す。
+func uuidHexKey(cfg Config, i int) string {
+	return EncodeUUIDHex(newUUID(cfg, i))
+}
+
+// uuidBinKey は BINARY(16) 方式の i 行目のキー（16 バイト）を返す。
+func uuidBinKey(cfg Config, i int) []byte {
+	return UUIDToBytes(newUUID(cfg, i))
+}
+
+// uuidBase64Key は Base64url 方式の i 行目のキー（22 文字）を返す。
+func uuidBase64Key(cfg Config, i int) string {
+	return EncodeUUIDBase64(newUUID(cfg, i))
+}
+
+// idGenerators は GenerateIDs で選べる方式と、その i 行目のキーの作り方。
+// キーをクライアント側で決める単一列主キーの方式だけを持つ。
+var idGenerators = map[string]func(cfg Config, i int) any{
+	"bench_uuid_char":        func(cfg Config, i int) any { return uuidCharKey(cfg, i) },
+	"bench_uuid_char32":      func(cfg Config, i int) any { return uuidHexKey(cfg, i) },
+	"bench_uuid_bin":         func(cfg Config, i int) any { return uuidBinKey(cfg, i) },
+	"bench_uuid_bin_swapped": func(cfg Config, i int) any { return UUIDToSwappedBytes(newUUID(cfg, i)) },
+	"bench_uuid_b64":         func(cfg Config, i int) any { return uuidBase64Key(cfg, i) },
+	"bench_uuid_v1":          func(cfg Config, i int) any { return UUIDToBytes(uuidV1Key(cfg, i)) },
+	"bench_uuid_v1_swapped":  func(cfg Config, i int) any { return UUIDToSwappedBytes(uuidV1Key(cfg, i)) },
+	"bench_uuid_bytea":       func(cfg Config, i int) any { return uuidBinKey(cfg, i) },
+	"bench_uuid":             func(cfg Config, i int) any { return newUUID(cfg, i) },
+}
+
+// IDStrategies は GenerateIDs が受け付ける方式名を名前順で返す。
+func IDStrategies() []string {
+	names := slices.Collect(maps.Keys(idGenerators))
+	names = append(names, "bench_int_shuffled")
+	slices.Sort(names)
+	return names
+}
+
+// GenerateIDs は strategy（テーブル名）の方式で n 個のキーを、DB へそのまま渡せる型で返す。
+// bench_uuid_char / bench_uuid_char32 / bench_uuid_b64 は string、bench_uuid_bin / bench_uuid_bin_swapped / bench_uuid_v1 /
+// bench_uuid_v1_swapped / bench_uuid_bytea は []byte、
+// bench_uuid は uuid.UUID（乱数の v4）、bench_int_shuffled はシード固定でシャッフルした 1..n の int64。
+// 連番（bench_auto など）のようにサーバが採番する方式はエラーを返す。
+// ベンチマークを使わずに、自前の負荷試験やシード投入でキー生成だけを再利用するための関数。
+func GenerateIDs(strategy string, n int) ([]any, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("n must be >= 0, got %d", n)
+	}
+	if strategy == "bench_int_shuffled" {
+		ids := make([]any, n)
+		for i, id := range ShuffledIDs(n, shuffleSeed) {
+			ids[i] = id
+		}
+		return ids, nil
+	}
+	gen, ok := idGenerators[strategy]
+	if !ok {
+		return nil, fmt.Errorf("strategy %q has no client-generated keys; choose one of %s", strategy, strings.Join(IDStrategies(), ", "))
+	}
+	cfg := DefaultConfig()
+	ids := make([]any, n)
+	for i := range ids {
+		ids[i] = gen(cfg, i)
+	}
+	return ids, nil
+}
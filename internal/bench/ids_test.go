@@ -0,0 +1,78 @@
+package bench
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestGenerateIDs(t *testing.T) {
+	t.Run("キー生成_方式ごとの型と長さ", func(t *testing.T) {
+		tests := []struct {
+			strategy string
+			check    func(any) bool
+		}{
+			{"bench_uuid_char", func(v any) bool { s, ok := v.(string); return ok && len(s) == 36 }},
+			{"bench_uuid_char32", func(v any) bool { s, ok := v.(string); return ok && len(s) == 32 }},
+			{"bench_uuid_b64", func(v any) bool { s, ok := v.(string); return ok && len(s) == 22 }},
+			{"bench_uuid_bin", func(v any) bool { b, ok := v.([]byte); return ok && len(b) == 16 }},
+			{"bench_uuid_bin_swapped", func(v any) bool { b, ok := v.([]byte); return ok && len(b) == 16 }},
+			{"bench_uuid_bytea", func(v any) bool { b, ok := v.([]byte); return ok && len(b) == 16 }},
+			{"bench_uuid", func(v any) bool { u, ok := v.(uuid.UUID); return ok && u.Version() == 4 }},
+		}
+		for _, tt := range tests {
+			ids, err := GenerateIDs(tt.strategy, 50)
+			if err != nil {
+				t.Fatalf("%s: %v", tt.strategy, err)
+			}
+			if len(ids) != 50 {
+				t.Fatalf("%s: len = %d, want 50", tt.strategy, len(ids))
+			}
+			seen := make(map[string]bool)
+			for _, id := range ids {
+				if !tt.check(id) {
+					t.Fatalf("%s: unexpected key %#v", tt.strategy, id)
+				}
+				seen[fmt.Sprint(id)] = true
+			}
+			if len(seen) != len(ids) {
+				t.Fatalf("%s: duplicate keys", tt.strategy)
+			}
+		}
+	})
+
+	t.Run("キー生成_シャッフル連番は1からnの並べ替え", func(t *testing.T) {
+		ids, err := GenerateIDs("bench_int_shuffled", 100)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := make([]int64, len(ids))
+		for i, id := range ids {
+			got[i] = id.(int64)
+		}
+		slices.Sort(got)
+		for i, v := range got {
+			if v != int64(i+1) {
+				t.Fatalf("sorted ids[%d] = %d, want %d", i, v, i+1)
+			}
+		}
+	})
+
+	t.Run("キー生成_サーバ採番の方式と負の件数はエラー", func(t *testing.T) {
+		if _, err := GenerateIDs("bench_auto", 1); err == nil {
+			t.Fatal("GenerateIDs(bench_auto) error = nil, want error")
+		}
+		if _, err := GenerateIDs("bench_uuid_char", -1); err == nil {
+			t.Fatal("GenerateIDs(n=-1) error = nil, want error")
+		}
+	})
+
+	t.Run("キー生成_方式一覧は名前順", func(t *testing.T) {
+		names := IDStrategies()
+		if !slices.IsSorted(names) || !slices.Contains(names, "bench_int_shuffled") || !slices.Contains(names, "bench_uuid_bin") {
+			t.Fatalf("IDStrategies = %v", names)
+		}
+	})
+}
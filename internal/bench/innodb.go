@@ -0,0 +1,92 @@
+package bench
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// innodbMetricNames は方式ごとに前後差分を取る INNODB_METRICS の項目。
+// index_* はページ分割/結合の累計、buffer_pool_pages_* はその時点のページ数。
+var innodbMetricNames = []string{
+	"index_page_splits",
+	"index_page_merge_successful",
+	"buffer_pool_pages_data",
+	"buffer_pool_pages_dirty",
+}
+
+// enableInnoDBMetrics は既定で無効な index モジュールのカウンタを有効にする。
+// 権限不足などで失敗しても計測は続け、取得できない項目は結果で空欄にする。
+func enableInnoDBMetrics(ctx context.Context, db *sql.DB) {
+	if _, err := db.ExecContext(ctx, "SET GLOBAL innodb_monitor_enable = 'module_index'"); err != nil {
+		slog.Warn("could not enable InnoDB index metrics; page_splits/page_merges will be empty unless already enabled", "err", err)
+	}
+}
+
+// innodbMetrics は innodbMetricNames のうち有効な項目の現在値を返す。
+func innodbMetrics(ctx context.Context, db *sql.DB) (map[string]int64, error) {
+	query := "SELECT NAME, `COUNT` FROM information_schema.INNODB_METRICS WHERE STATUS = 'enabled' AND NAME IN (?" + strings.Repeat(", ?", len(innodbMetricNames)-1) + ")"
+	args := make([]any, len(innodbMetricNames))
+	for i, name := range innodbMetricNames {
+		args[i] = name
+	}
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("mysql innodb metrics query failed: %w", err)
+	}
+	defer rows.Close()
+	m := make(map[string]int64, len(innodbMetricNames))
+	for rows.Next() {
+		var name string
+		var count int64
+		if err := rows.Scan(&name, &count); err != nil {
+			return nil, err
+		}
+		m[name] = count
+	}
+	return m, rows.Err()
+}
+
+// metricDelta は after[name] - before[name] を返す。どちらかに無い項目は nil。
+func metricDelta(before, after map[string]int64, name string) *int64 {
+	b, ok := before[name]
+	if !ok {
+		return nil
+	}
+	a, ok := after[name]
+	if !ok {
+		return nil
+	}
+	d := a - b
+	return &d
+}
+
+// withInnoDBMetrics は cfg.InnoDBMetrics なら bench の前後で INNODB_METRICS を読み、
+// ページ分割・結合数とバッファプールのページ数の差分を結果へ記録する。
+// ページ分割は UUID の Insert が遅くなる直接の原因で、所要時間だけでなく仕組みの側から差を示す。
+// 差分はサーバ全体のカウンタなので、他の負荷がない環境で使う。
+func withInnoDBMetrics(ctx context.Context, db *sql.DB, cfg Config, bench func() (Result, error)) (Result, error) {
+	if !cfg.InnoDBMetrics {
+		return bench()
+	}
+	before, err := innodbMetrics(ctx, db)
+	if err != nil {
+		return Result{}, err
+	}
+	r, err := bench()
+	if err != nil {
+		return r, err
+	}
+	after, err := innodbMetrics(ctx, db)
+	if err != nil {
+		return r, err
+	}
+	r.PageSplits = metricDelta(before, after, "index_page_splits")
+	r.PageMerges = metricDelta(before, after, "index_page_merge_successful")
+	r.BufferPoolPagesData = metricDelta(before, after, "buffer_pool_pages_data")
+	r.BufferPoolPagesDirty = metricDelta(before, after, "buffer_pool_pages_dirty")
+	slog.Info("innodb metrics", "table", r.Table, "page_splits", formatOptionalInt(r.PageSplits), "page_merges", formatOptionalInt(r.PageMerges))
+	return r, nil
+}
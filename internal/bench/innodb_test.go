@@ -0,0 +1,26 @@
+package bench
+
+import "testing"
+
+func TestMetricDelta(t *testing.T) {
+	before := map[string]int64{"index_page_splits": 10, "buffer_pool_pages_data": 500}
+	after := map[string]int64{"index_page_splits": 250, "buffer_pool_pages_data": 480}
+
+	t.Run("InnoDBメトリクス_前後の差分を返す", func(t *testing.T) {
+		if d := metricDelta(before, after, "index_page_splits"); d == nil || *d != 240 {
+			t.Fatalf("delta = %v, want 240", d)
+		}
+		if d := metricDelta(before, after, "buffer_pool_pages_data"); d == nil || *d != -20 {
+			t.Fatalf("delta = %v, want -20", d)
+		}
+	})
+
+	t.Run("InnoDBメトリクス_無効な項目はnil", func(t *testing.T) {
+		if d := metricDelta(before, after, "index_page_merge_successful"); d != nil {
+			t.Fatalf("delta = %d, want nil", *d)
+		}
+		if d := metricDelta(before, map[string]int64{}, "index_page_splits"); d != nil {
+			t.Fatalf("delta = %d, want nil", *d)
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package bench
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// latencyDumpKey / latencyServerKey は ctx に載せる latencyDump と接続先ラベルのキー。
+type (
+	latencyDumpKey   struct{}
+	latencyServerKey struct{}
+)
+
+// latencyFileUnsafe はファイル名に使えない文字。接続先ラベルの "#" などを置き換える。
+var latencyFileUnsafe = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// latencyDump は -latency-dump 指定時に、1 方式ぶんの挿入・点検索 1 件ごとの所要時間を
+// フェーズごとのファイルへ 1 行ずつ書き出す。サンプルはメモリに溜めずに書き出す。
+// 計測ループは 1 ゴルーチンから呼ぶ前提で、並行には使わない。
+type latencyDump struct {
+	dir                 string
+	kind, server, table string
+	files               map[string]*latencyFile
+	err                 error
+}
+
+// latencyFile はフェーズ 1 つぶんの出力先。
+type latencyFile struct {
+	f *os.File
+	w *bufio.Writer
+}
+
+// withServerLabel は ctx に接続先ラベルを載せる。-latency-dump のファイル名に使う。
+func withServerLabel(ctx context.Context, label string) context.Context {
+	return context.WithValue(ctx, latencyServerKey{}, label)
+}
+
+// latencyFileName は kind / server / table / phase から -latency-dump のファイル名を組み立てる。
+// 例: "mysql_bench_uuid_bin_insert.csv"、接続先ラベルがあれば "mysql_mysql-8.4.3_bench_uuid_bin_insert.csv"。
+func latencyFileName(kind, server, table, phase string) string {
+	parts := kind
+	if server != "" {
+		parts += "_" + latencyFileUnsafe.ReplaceAllString(server, "_")
+	}
+	return parts + "_" + table + "_" + phase + ".csv"
+}
+
+// withLatencyDump は cfg.LatencyDumpDir が指定されていれば、kind の table 用の latencyDump を載せた ctx を返す。
+// 未指定なら ctx をそのまま返し、latencyDump は nil（何も書かない）。
+func withLatencyDump(ctx context.Context, cfg Config, kind, table string) (context.Context, *latencyDump) {
+	if cfg.LatencyDumpDir == "" {
+		return ctx, nil
+	}
+	server, _ := ctx.Value(latencyServerKey{}).(string)
+	d := &latencyDump{dir: cfg.LatencyDumpDir, kind: kind, server: server, table: table, files: make(map[string]*latencyFile)}
+	return context.WithValue(ctx, latencyDumpKey{}, d), d
+}
+
+// withoutLatencyDump は ctx の latencyDump を外す。Hot/Cold などの追加の点検索を書き出さないために使う。
+func withoutLatencyDump(ctx context.Context) context.Context {
+	return context.WithValue(ctx, latencyDumpKey{}, (*latencyDump)(nil))
+}
+
+// latencyRecorder は ctx に latencyDump が載っていれば、phase のファイルへ 1 件の所要時間を書く関数を返す。
+// 載っていなければ nil を返す。書き込みの失敗は Close で返す。
+func latencyRecorder(ctx context.Context, phase string) func(time.Duration) {
+	d, _ := ctx.Value(latencyDumpKey{}).(*latencyDump)
+	if d == nil {
+		return nil
+	}
+	return func(took time.Duration) {
+		if d.err != nil {
+			return
+		}
+		lf, err := d.file(phase)
+		if err != nil {
+			d.err = err
+			return
+		}
+		_, d.err = lf.w.WriteString(strconv.FormatFloat(float64(took)/float64(time.Microsecond), 'f', 3, 64) + "\n")
+	}
+}
+
+// file は phase の出力ファイルを返す。初回はディレクトリとファイルを作り、見出し行を書く。
+func (d *latencyDump) file(phase string) (*latencyFile, error) {
+	if lf, ok := d.files[phase]; ok {
+		return lf, nil
+	}
+	if err := os.MkdirAll(d.dir, 0o755); err != nil {
+		return nil, fmt.Errorf("latency dump: %w", err)
+	}
+	f, err := os.Create(filepath.Join(d.dir, latencyFileName(d.kind, d.server, d.table, phase)))
+	if err != nil {
+		return nil, fmt.Errorf("latency dump: %w", err)
+	}
+	lf := &latencyFile{f: f, w: bufio.NewWriter(f)}
+	d.files[phase] = lf
+	_, err = lf.w.WriteString("latency_us\n")
+	return lf, err
+}
+
+// Close はすべての出力ファイルをフラッシュして閉じ、書き込み中に起きた最初のエラーを返す。nil でも呼べる。
+func (d *latencyDump) Close() error {
+	if d == nil {
+		return nil
+	}
+	errs := []error{d.err}
+	for _, lf := range d.files {
+		errs = append(errs, lf.w.Flush(), lf.f.Close())
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("latency dump: %w", err)
+	}
+	return nil
+}
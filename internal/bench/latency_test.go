@@ -0,0 +1,74 @@
+package bench
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLatencyFileName(t *testing.T) {
+	t.Run("接続先ラベルがなければ DB・テーブル・フェーズだけ", func(t *testing.T) {
+		if got := latencyFileName("mysql", "", "bench_uuid_bin", "insert"); got != "mysql_bench_uuid_bin_insert.csv" {
+			t.Fatalf("got %q", got)
+		}
+	})
+	t.Run("接続先ラベルのファイル名に使えない文字は置き換える", func(t *testing.T) {
+		if got := latencyFileName("postgres", "pg#2 16", "bench_uuid", "point"); got != "postgres_pg_2_16_bench_uuid_point.csv" {
+			t.Fatalf("got %q", got)
+		}
+	})
+}
+
+func TestLatencyDump(t *testing.T) {
+	t.Run("未指定なら記録しない", func(t *testing.T) {
+		ctx, d := withLatencyDump(context.Background(), DefaultConfig(), "mysql", "bench_auto")
+		if d != nil || latencyRecorder(ctx, "insert") != nil {
+			t.Fatal("expected no recorder")
+		}
+		if err := d.Close(); err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("挿入と点検索の 1 件ごとの時間をフェーズ別のファイルへ書く", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Rows = 3
+		cfg.LatencyDumpDir = filepath.Join(t.TempDir(), "lat")
+		ctx, d := withLatencyDump(withServerLabel(context.Background(), "a"), cfg, "mysql", "bench_auto")
+		slow := func(context.Context, int) error {
+			time.Sleep(time.Millisecond)
+			return nil
+		}
+		if _, _, err := insertLoop(ctx, cfg, slog.Default(), slow); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := pointLoop(ctx, cfg, slog.Default(), 2, slow); err != nil {
+			t.Fatal(err)
+		}
+		hc := cfg
+		hc.Lookups, hc.HotFraction = 2, 0.5
+		if _, _, err := hotColdLoop(ctx, hc, slog.Default(), []int{1, 2, 3, 4}, func(context.Context, int) error { return nil }); err != nil {
+			t.Fatal(err)
+		}
+		if err := d.Close(); err != nil {
+			t.Fatal(err)
+		}
+		for phase, want := range map[string]int{"insert": 3, "point": 2} {
+			b, err := os.ReadFile(filepath.Join(cfg.LatencyDumpDir, "mysql_a_bench_auto_"+phase+".csv"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			lines := strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+			if lines[0] != "latency_us" || len(lines) != want+1 {
+				t.Fatalf("%s: got %q", phase, lines)
+			}
+			if us, err := strconv.ParseFloat(lines[1], 64); err != nil || us < 1000 {
+				t.Fatalf("%s: latency %q is not in microseconds", phase, lines[1])
+			}
+		}
+	})
+}
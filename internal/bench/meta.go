@@ -0,0 +1,449 @@
+package bench
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Metadata は計測結果の解釈に必要な実行環境の情報を保持する。
+type Metadata struct {
+	RunID               string
+	Label               string
+	StartedAt           time.Time
+	MySQLVersion        string
+	MySQLVersionComment string
+	MySQLFlavor         string
+	PGVersion           string
+	PGFlavor            string
+	MySQLFlushLog       string
+	PGSyncCommit        string
+	MySQLIsolation      string
+	PGIsolation         string
+	Aggregate           string
+	StatementMode       string
+	Analyze             string
+	UUIDKeys            string
+	MySQLPageSize       string
+	MySQLKeysPerPage    string
+	PGBlockSize         string
+	PGKeysPerPage       string
+	PGUnlogged          bool
+	// PayloadNulls は -payload-nullable 指定時の NULL にした payload の割合。未指定なら空。
+	PayloadNulls string
+	// AutoScaledRows は -auto-scale-rows で方式ごとに選んだ行数（AutoScaledRows の形式）。未指定なら空。
+	AutoScaledRows string
+	// MySQLMemoryFit / PGMemoryFit は計測後に MemoryFit で求めた、表がキャッシュに収まったかの説明。
+	MySQLMemoryFit string
+	PGMemoryFit    string
+	// MySQLBufferPool / PGSharedBuffers はキャッシュの大きさ（バイト）。未取得なら 0。
+	MySQLBufferPool int64
+	PGSharedBuffers int64
+	// MySQLChangeBuffer / PGAutovacuum はサーバ全体の innodb_change_buffering と autovacuum。
+	// MySQLChangeBuffer は変数のないサーバでは空。
+	MySQLChangeBuffer string
+	PGAutovacuum      string
+	// PGTableAutovacuum は -pg-autovacuum でベンチテーブルに指定した autovacuum_enabled。未指定なら空。
+	PGTableAutovacuum string
+	// MySQLDriverOptions は -mysql-interpolate-params などで DSN に付けたドライバ設定（MySQLDriverOptions の形式）。未指定なら空。
+	MySQLDriverOptions string
+	// ClockBackward は計測中にシステム時刻が単調時計より遅れた量（WallClockBackward）。戻っていなければ空。
+	ClockBackward string
+	// SimulatedRTT は -simulated-rtt で往復ごとに入れた遅延。未指定なら空。
+	SimulatedRTT string
+}
+
+// managedFlavors は fsync/IO の前提が素の InnoDB / PostgreSQL と異なるエンジン。
+var managedFlavors = map[string]bool{
+	"aurora-mysql":    true,
+	"aurora-postgres": true,
+	"tidb":            true,
+	"cockroachdb":     true,
+	"yugabytedb":      true,
+}
+
+// MySQLFlavor は VERSION() と @@version_comment から MySQL 互換エンジンの種類を推定する。
+func MySQLFlavor(version, comment string) string {
+	s := strings.ToLower(version + " " + comment)
+	switch {
+	case strings.Contains(s, "aurora"):
+		return "aurora-mysql"
+	case strings.Contains(s, "tidb"):
+		return "tidb"
+	case strings.Contains(s, "mariadb"):
+		return "mariadb"
+	case strings.Contains(s, "percona"):
+		return "percona"
+	}
+	return "mysql"
+}
+
+// PGFlavor は version() の文字列から PostgreSQL 互換エンジンの種類を推定する。
+func PGFlavor(version string) string {
+	s := strings.ToLower(version)
+	switch {
+	case strings.Contains(s, "aurora"):
+		return "aurora-postgres"
+	case strings.Contains(s, "cockroachdb"):
+		return "cockroachdb"
+	case strings.Contains(s, "-yb-"):
+		return "yugabytedb"
+	}
+	return "postgres"
+}
+
+// IsManagedFlavor は flavor が素のエンジンと I/O 特性の異なるマネージド系かを返す。
+func IsManagedFlavor(flavor string) bool {
+	return managedFlavors[flavor]
+}
+
+// DetectServers は接続先のバージョンとエンジン種別を調べて Metadata を返す。
+// pgDB が nil（MySQL のみの実行）なら PostgreSQL の項目は空のままにする。
+// マネージド系エンジンを検出した場合は結果の比較に注意するよう警告を出す。
+func DetectServers(ctx context.Context, mysqlDB, pgDB *sql.DB) (Metadata, error) {
+	var md Metadata
+	if err := detectMySQL(ctx, mysqlDB, &md); err != nil {
+		return md, err
+	}
+	slog.Info("server detected", "db", "mysql", "version", md.MySQLVersion, "flavor", md.MySQLFlavor)
+	if pgDB != nil {
+		if err := detectPG(ctx, pgDB, &md); err != nil {
+			return md, err
+		}
+		slog.Info("server detected", "db", "postgres", "flavor", md.PGFlavor)
+	}
+	for _, f := range []string{md.MySQLFlavor, md.PGFlavor} {
+		if IsManagedFlavor(f) {
+			slog.Warn("managed engine detected: fsync/IO behavior differs from vanilla MySQL/PostgreSQL, label results accordingly", "flavor", f)
+		}
+	}
+	return md, nil
+}
+
+// detectMySQL は MySQL のバージョン、エンジン種別、耐久性設定、ページサイズを md へ記録する。
+func detectMySQL(ctx context.Context, db *sql.DB, md *Metadata) error {
+	if err := db.QueryRowContext(ctx, "SELECT VERSION(), @@version_comment").Scan(&md.MySQLVersion, &md.MySQLVersionComment); err != nil {
+		return fmt.Errorf("mysql version query failed: %w", err)
+	}
+	md.MySQLFlavor = MySQLFlavor(md.MySQLVersion, md.MySQLVersionComment)
+	// Aurora は version_comment に現れないことがあるため専用関数の有無でも判定する。
+	var aurora string
+	if err := db.QueryRowContext(ctx, "SELECT AURORA_VERSION()").Scan(&aurora); err == nil {
+		md.MySQLFlavor = "aurora-mysql"
+	}
+	// 耐久性設定は Insert 計測を大きく左右するため実効値を記録する。
+	if err := db.QueryRowContext(ctx, "SELECT @@GLOBAL.innodb_flush_log_at_trx_commit").Scan(&md.MySQLFlushLog); err != nil {
+		return fmt.Errorf("mysql durability query failed: %w", err)
+	}
+	// innodb_change_buffering は MySQL 以外の互換エンジンにはないことがあるため、読めなければ空のままにする。
+	if err := db.QueryRowContext(ctx, "SELECT @@GLOBAL.innodb_change_buffering").Scan(&md.MySQLChangeBuffer); err != nil {
+		slog.Debug("mysql innodb_change_buffering not available", "err", err)
+	}
+	// 分離レベルはロック待ちと MVCC の負荷を左右するため、-isolation の適用後のセッションの値を記録する。
+	// transaction_isolation は 5.7.20 より前のサーバや互換エンジンにはないことがあるため、読めなければ空のままにする。
+	if err := db.QueryRowContext(ctx, "SELECT @@SESSION.transaction_isolation").Scan(&md.MySQLIsolation); err != nil {
+		slog.Debug("mysql transaction_isolation not available", "err", err)
+	}
+	// ページサイズは 1 ページに入るキー数、つまりページ分割の頻度を決めるため記録する。
+	var page int
+	if err := db.QueryRowContext(ctx, "SELECT @@innodb_page_size").Scan(&page); err != nil {
+		return fmt.Errorf("mysql page size query failed: %w", err)
+	}
+	md.MySQLPageSize = strconv.Itoa(page)
+	md.MySQLKeysPerPage = formatKeysPerPage(page, mysqlKeyWidths, InnoDBKeysPerPage)
+	// バッファプールに全件が収まると、ランダムキーでもディスク I/O が発生しない。
+	if err := db.QueryRowContext(ctx, "SELECT @@innodb_buffer_pool_size").Scan(&md.MySQLBufferPool); err != nil {
+		return fmt.Errorf("mysql buffer pool size query failed: %w", err)
+	}
+	return nil
+}
+
+// detectPG は PostgreSQL のバージョン、エンジン種別、耐久性設定、ブロックサイズを md へ記録する。
+func detectPG(ctx context.Context, db *sql.DB, md *Metadata) error {
+	if err := db.QueryRowContext(ctx, "SELECT version()").Scan(&md.PGVersion); err != nil {
+		return fmt.Errorf("postgres version query failed: %w", err)
+	}
+	md.PGFlavor = PGFlavor(md.PGVersion)
+	if err := db.QueryRowContext(ctx, "SHOW synchronous_commit").Scan(&md.PGSyncCommit); err != nil {
+		return fmt.Errorf("postgres durability query failed: %w", err)
+	}
+	if err := db.QueryRowContext(ctx, "SHOW autovacuum").Scan(&md.PGAutovacuum); err != nil {
+		return fmt.Errorf("postgres autovacuum query failed: %w", err)
+	}
+	if err := db.QueryRowContext(ctx, "SHOW transaction_isolation").Scan(&md.PGIsolation); err != nil {
+		return fmt.Errorf("postgres isolation query failed: %w", err)
+	}
+	var aurora string
+	if err := db.QueryRowContext(ctx, "SELECT aurora_version()").Scan(&aurora); err == nil {
+		md.PGFlavor = "aurora-postgres"
+	}
+	var block int
+	if err := db.QueryRowContext(ctx, "SHOW block_size").Scan(&block); err != nil {
+		return fmt.Errorf("postgres block size query failed: %w", err)
+	}
+	md.PGBlockSize = strconv.Itoa(block)
+	md.PGKeysPerPage = formatKeysPerPage(block, pgKeyWidths, PGKeysPerPage)
+	if err := db.QueryRowContext(ctx, "SELECT pg_size_bytes(current_setting('shared_buffers'))").Scan(&md.PGSharedBuffers); err != nil {
+		return fmt.Errorf("postgres shared_buffers query failed: %w", err)
+	}
+	return nil
+}
+
+// keyWidth は主キー方式ごとのインデックスキー幅（バイト）。
+type keyWidth struct {
+	Name  string
+	Bytes int
+}
+
+// mysqlKeyWidths は InnoDB の各方式のキー幅。CHAR(36) は utf8mb4 では可変長扱いで長さ 1 バイトが付く。
+var mysqlKeyWidths = []keyWidth{{"bigint", 8}, {"binary16", 16}, {"char36", 37}}
+
+// pgKeyWidths は PostgreSQL の各方式のキー幅。
+var pgKeyWidths = []keyWidth{{"bigint", 8}, {"uuid", 16}}
+
+// InnoDBKeysPerPage は InnoDB の B+ 木の非リーフページ 1 枚に入るキー数を概算する。
+// ページ固定部 128 バイトを除き、レコードヘッダ 5 バイト + キー + 子ページ番号 4 バイト、
+// ページディレクトリ (約 4 レコードに 2 バイト) を 1 レコードあたりの大きさとし、
+// 挿入時に残す空き 1/16 を差し引く。
+func InnoDBKeysPerPage(pageSize, keyBytes int) int {
+	usable := float64(pageSize-128) * 15 / 16
+	return int(usable / (5 + float64(keyBytes) + 4 + 0.5))
+}
+
+// PGKeysPerPage は PostgreSQL の B-tree リーフページ 1 枚に入るキー数を概算する。
+// ページヘッダ 24 バイトと B-tree 特殊領域 16 バイトを除き、
+// 行ポインタ 4 バイト + タプルヘッダ 8 バイト + 8 バイト境界に揃えたキー、
+// リーフの既定 fillfactor 90% で計算する。
+func PGKeysPerPage(blockSize, keyBytes int) int {
+	usable := float64(blockSize-24-16) * 0.9
+	aligned := (keyBytes + 7) / 8 * 8
+	return int(usable / float64(4+8+aligned))
+}
+
+// formatKeysPerPage は方式ごとの推定キー数を "bigint:N,uuid:M" 形式で返す。
+func formatKeysPerPage(pageSize int, widths []keyWidth, estimate func(pageSize, keyBytes int) int) string {
+	parts := make([]string, len(widths))
+	for i, w := range widths {
+		parts[i] = fmt.Sprintf("%s:%d", w.Name, estimate(pageSize, w.Bytes))
+	}
+	return strings.Join(parts, ",")
+}
+
+// columnBytes は追加カラム 1 つが行に占めるおおよそのバイト数。
+func columnBytes(c ColumnSpec) int {
+	switch c.Type {
+	case "int":
+		return 4
+	case "bool":
+		return 1
+	case "text":
+		return textColumnLen + 2
+	case "varchar":
+		return c.Size + 2
+	}
+	return 8
+}
+
+// EstimatedTableBytes は kind ("mysql" / "postgres") で最も大きくなる UUID 主キー表
+// （MySQL は CHAR(36)、PostgreSQL は UUID のヒープ + 主キーインデックス）に rows 行を入れたときの
+// おおよそのサイズを返す。ページの空きは含めないので実際より小さめの下限として扱う。
+func EstimatedTableBytes(kind string, rows int, cols []ColumnSpec) int64 {
+	row := len(fmt.Sprintf("p-%d", rows)) + 1
+	for _, c := range cols {
+		row += columnBytes(c)
+	}
+	if kind == "postgres" {
+		// タプルヘッダ 24 + 行ポインタ 4 + UUID 16、インデックスはタプルヘッダ 8 + 行ポインタ 4 + UUID 16。
+		row += 24 + 4 + 16 + 8 + 4 + 16
+	} else {
+		// レコードヘッダ 5 + トランザクション ID 6 + ロールバックポインタ 7 + CHAR(36) 37。
+		row += 5 + 6 + 7 + 37
+	}
+	return int64(row) * int64(rows)
+}
+
+// CacheWarnings は計測対象の表が DB のキャッシュに丸ごと収まりそうな場合の警告文を返す。
+// 全件がメモリに乗るとランダムキーでも読み書きがディスクへ届かず、
+// UUID の不利が現れない「メモリ内だけの比較」になる。
+func CacheWarnings(md Metadata, cfg Config) []string {
+	var warnings []string
+	check := func(kind, setting string, cache int64) {
+		if cache <= 0 {
+			return
+		}
+		if est := EstimatedTableBytes(kind, cfg.Rows, payloadFor(cfg).Columns()); est < cache {
+			warnings = append(warnings, fmt.Sprintf("%s: estimated largest table (~%d bytes for %d rows) fits in %s (%d bytes); results reflect an in-memory dataset, raise -rows or shrink %s to exercise disk I/O", kind, est, cfg.Rows, setting, cache, setting))
+		}
+	}
+	check("mysql", "innodb_buffer_pool_size", md.MySQLBufferPool)
+	check("postgres", "shared_buffers", md.PGSharedBuffers)
+	return warnings
+}
+
+// MemoryFit は kind の最も大きい表が DB のキャッシュ cache（バイト）に収まったかを 1 行で返す。
+// 表の大きさは -mysql-table-sizes / -pg-vacuum で測ったデータ長 + インデックス長を使い、
+// 測っていなければ EstimatedTableBytes の見積もりを使う。cache が 0（未取得）なら空文字を返す。
+// 例: "in-memory: largest table bench_uuid_char 12.0MiB (measured) fits in 128.0MiB, headroom 116.0MiB (91%)"
+func MemoryFit(kind string, cache int64, results []Result, cfg Config) string {
+	if cache <= 0 {
+		return ""
+	}
+	var table string
+	var size int64
+	for _, r := range results {
+		if r.DB == kind && r.Err == "" && r.DataBytes+r.IndexBytes > size {
+			table, size = r.Table, r.DataBytes+r.IndexBytes
+		}
+	}
+	source := "measured"
+	if size == 0 {
+		source, size = "estimated", EstimatedTableBytes(kind, cfg.Rows, payloadFor(cfg).Columns())
+	}
+	what := "largest table"
+	if table != "" {
+		what += " " + table
+	}
+	mib := func(n int64) string { return fmt.Sprintf("%.1fMiB", float64(n)/(1<<20)) }
+	headroom := cache - size
+	if headroom > 0 {
+		return fmt.Sprintf("in-memory: %s %s (%s) fits in %s, headroom %s (%.0f%%); disk I/O hardly shows in these numbers",
+			what, mib(size), source, mib(cache), mib(headroom), float64(headroom)/float64(cache)*100)
+	}
+	return fmt.Sprintf("io-bound: %s %s (%s) exceeds %s by %s (%.0f%% of the cache); random keys pay for disk reads here",
+		what, mib(size), source, mib(cache), mib(-headroom), float64(size)/float64(cache)*100)
+}
+
+// versionNumber は "8.4.3-log" や "PostgreSQL 16.4 (Debian ...)" から
+// 先頭の数値バージョン部分 ("8.4.3", "16.4") を取り出す。
+func versionNumber(version string) string {
+	for _, f := range strings.Fields(version) {
+		if f == "" || f[0] < '0' || f[0] > '9' {
+			continue
+		}
+		end := strings.IndexFunc(f, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+		if end < 0 {
+			return f
+		}
+		return f[:end]
+	}
+	return version
+}
+
+// VersionAtLeast は version（VERSION() の値など）の数字部分が min 以上かを返す。
+// "8.0.36-log" と "8.0" のように桁数が違えば、足りない桁を 0 とみなす。
+func VersionAtLeast(version, min string) bool {
+	have, want := strings.Split(versionNumber(version), "."), strings.Split(min, ".")
+	for i := 0; i < max(len(have), len(want)); i++ {
+		h, w := 0, 0
+		if i < len(have) {
+			h, _ = strconv.Atoi(have[i])
+		}
+		if i < len(want) {
+			w, _ = strconv.Atoi(want[i])
+		}
+		if h != w {
+			return h > w
+		}
+	}
+	return true
+}
+
+// ServerLabel は kind ("mysql" / "postgres") とバージョン文字列から
+// 結果の Server 列に入れるラベル ("mysql-8.4.3", "postgres-16.4" など) を作る。
+func ServerLabel(kind, version string) string {
+	flavor := PGFlavor(version)
+	if kind == "mysql" {
+		flavor = MySQLFlavor(version, "")
+	}
+	return flavor + "-" + versionNumber(version)
+}
+
+// ServerVersion は kind ("mysql" / "postgres") に応じたクエリでバージョン文字列を取得する。
+func ServerVersion(ctx context.Context, db *sql.DB, kind string) (string, error) {
+	query := "SELECT VERSION()"
+	if kind == "postgres" {
+		query = "SELECT version()"
+	}
+	var v string
+	if err := db.QueryRowContext(ctx, query).Scan(&v); err != nil {
+		return "", fmt.Errorf("%s version query failed: %w", kind, err)
+	}
+	return v, nil
+}
+
+// FormatMetadata は Metadata を key=value 行の見出し付きブロックに整形する。
+// 値が空の項目は出力しない。
+func FormatMetadata(md Metadata) string {
+	var out strings.Builder
+	out.WriteString("=== Run Metadata ===\n")
+	for _, kv := range metadataPairs(md) {
+		fmt.Fprintf(&out, "%s=%s\n", kv[0], kv[1])
+	}
+	return strings.TrimSuffix(out.String(), "\n")
+}
+
+// metadataPairs は md のうち値のある項目を、FormatMetadata の表示順でキーと値の組にして返す。
+// キーは -format json の metadata と共通。
+func metadataPairs(md Metadata) [][2]string {
+	started := ""
+	if !md.StartedAt.IsZero() {
+		started = md.StartedAt.Format(time.RFC3339)
+	}
+	unlogged := ""
+	if md.PGUnlogged {
+		unlogged = "true"
+	}
+	var pairs [][2]string
+	for _, kv := range [][2]string{
+		{"run_id", md.RunID},
+		{"label", md.Label},
+		{"started_at", started},
+		{"mysql_version", md.MySQLVersion},
+		{"mysql_version_comment", md.MySQLVersionComment},
+		{"mysql_flavor", md.MySQLFlavor},
+		{"pg_version", md.PGVersion},
+		{"pg_flavor", md.PGFlavor},
+		{"mysql_innodb_flush_log_at_trx_commit", md.MySQLFlushLog},
+		{"pg_synchronous_commit", md.PGSyncCommit},
+		{"mysql_transaction_isolation", md.MySQLIsolation},
+		{"pg_transaction_isolation", md.PGIsolation},
+		{"mysql_innodb_change_buffering", md.MySQLChangeBuffer},
+		{"pg_autovacuum", md.PGAutovacuum},
+		{"pg_bench_tables_autovacuum", md.PGTableAutovacuum},
+		{"pg_unlogged", unlogged},
+		{"mysql_driver_options", md.MySQLDriverOptions},
+		{"clock_moved_backward", md.ClockBackward},
+		{"aggregate", md.Aggregate},
+		{"statement_mode", md.StatementMode},
+		{"simulated_rtt", md.SimulatedRTT},
+		{"analyze", md.Analyze},
+		{"uuid_keys", md.UUIDKeys},
+		{"payload_null_fraction", md.PayloadNulls},
+		{"auto_scaled_rows", md.AutoScaledRows},
+		{"mysql_innodb_page_size", md.MySQLPageSize},
+		{"mysql_est_keys_per_page", md.MySQLKeysPerPage},
+		{"pg_block_size", md.PGBlockSize},
+		{"pg_est_keys_per_page", md.PGKeysPerPage},
+		{"mysql_innodb_buffer_pool_size", formatBytes(md.MySQLBufferPool)},
+		{"pg_shared_buffers", formatBytes(md.PGSharedBuffers)},
+		{"mysql_memory_fit", md.MySQLMemoryFit},
+		{"pg_memory_fit", md.PGMemoryFit},
+	} {
+		if kv[1] != "" {
+			pairs = append(pairs, kv)
+		}
+	}
+	return pairs
+}
+
+// formatBytes は 0 なら空文字を、それ以外は 10 進数のバイト数を返す。
+func formatBytes(n int64) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.FormatInt(n, 10)
+}
@@ -0,0 +1,174 @@
+package bench
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMySQLFlavor(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		comment string
+		want    string
+	}{
+		{"素のMySQL", "8.4.3", "MySQL Community Server - GPL", "mysql"},
+		{"Aurora", "8.0.32", "Source distribution (Aurora)", "aurora-mysql"},
+		{"MariaDB", "11.4.2-MariaDB", "mariadb.org binary distribution", "mariadb"},
+		{"TiDB", "8.0.11-TiDB-v7.5.0", "", "tidb"},
+	}
+	for _, tt := range tests {
+		t.Run("MySQL種別判定_"+tt.name, func(t *testing.T) {
+			if got := MySQLFlavor(tt.version, tt.comment); got != tt.want {
+				t.Fatalf("MySQLFlavor = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPGFlavor(t *testing.T) {
+	t.Run("PostgreSQL種別判定_素のPostgreSQL", func(t *testing.T) {
+		if got := PGFlavor("PostgreSQL 16.4 (Debian 16.4-1.pgdg120+2) on x86_64-pc-linux-gnu"); got != "postgres" {
+			t.Fatalf("PGFlavor = %q, want postgres", got)
+		}
+	})
+	t.Run("PostgreSQL種別判定_マネージド系", func(t *testing.T) {
+		got := PGFlavor("PostgreSQL 11.2-YB-2.20.1.0-b0 on x86_64-pc-linux-gnu")
+		if got != "yugabytedb" || !IsManagedFlavor(got) {
+			t.Fatalf("PGFlavor = %q, want managed yugabytedb", got)
+		}
+	})
+}
+
+func TestFormatMetadata(t *testing.T) {
+	t.Run("メタデータ整形_空の項目は出力しない", func(t *testing.T) {
+		out := FormatMetadata(Metadata{MySQLVersion: "8.4.3", MySQLFlavor: "mysql"})
+		if !strings.Contains(out, "mysql_version=8.4.3") || !strings.Contains(out, "mysql_flavor=mysql") {
+			t.Fatalf("missing metadata lines: %s", out)
+		}
+		if strings.Contains(out, "pg_version=") {
+			t.Fatalf("empty field should be omitted: %s", out)
+		}
+	})
+}
+
+func TestKeysPerPage(t *testing.T) {
+	t.Run("ページ当たりキー数_キー幅が広いほど少ない", func(t *testing.T) {
+		got := formatKeysPerPage(16384, mysqlKeyWidths, InnoDBKeysPerPage)
+		if got != "bigint:870,binary16:597,char36:327" {
+			t.Fatalf("formatKeysPerPage(innodb) = %s", got)
+		}
+		if got := formatKeysPerPage(8192, pgKeyWidths, PGKeysPerPage); got != "bigint:366,uuid:262" {
+			t.Fatalf("formatKeysPerPage(postgres) = %s", got)
+		}
+	})
+}
+
+func TestServerLabel(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    string
+		version string
+		want    string
+	}{
+		{"MySQL8", "mysql", "8.4.3", "mysql-8.4.3"},
+		{"MySQL5.7接尾辞付き", "mysql", "5.7.44-log", "mysql-5.7.44"},
+		{"MariaDB", "mysql", "11.4.2-MariaDB-ubu2404", "mariadb-11.4.2"},
+		{"PostgreSQL", "postgres", "PostgreSQL 16.4 (Debian 16.4-1.pgdg120+2) on x86_64-pc-linux-gnu", "postgres-16.4"},
+	}
+	for _, tt := range tests {
+		t.Run("サーバラベル_"+tt.name, func(t *testing.T) {
+			if got := ServerLabel(tt.kind, tt.version); got != tt.want {
+				t.Fatalf("ServerLabel = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCacheWarnings(t *testing.T) {
+	t.Run("キャッシュ警告_全件が収まるなら警告する", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Rows = 1000
+		md := Metadata{MySQLBufferPool: 128 << 20, PGSharedBuffers: 128 << 20}
+		got := CacheWarnings(md, cfg)
+		if len(got) != 2 || !strings.Contains(got[0], "innodb_buffer_pool_size") || !strings.Contains(got[1], "shared_buffers") {
+			t.Fatalf("warnings = %q", got)
+		}
+	})
+
+	t.Run("キャッシュ警告_収まらない件数や未取得なら警告しない", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Rows = 10_000_000
+		if got := CacheWarnings(Metadata{MySQLBufferPool: 128 << 20}, cfg); len(got) != 0 {
+			t.Fatalf("warnings = %q", got)
+		}
+		if got := CacheWarnings(Metadata{}, DefaultConfig()); len(got) != 0 {
+			t.Fatalf("warnings = %q", got)
+		}
+	})
+
+	t.Run("キャッシュ警告_追加カラムの幅を見積もりに含める", func(t *testing.T) {
+		base := EstimatedTableBytes("mysql", 1000, nil)
+		wide := EstimatedTableBytes("mysql", 1000, []ColumnSpec{{Name: "note", Type: "varchar", Size: 200}})
+		if wide-base != 202*1000 {
+			t.Fatalf("wide - base = %d, want %d", wide-base, 202*1000)
+		}
+	})
+}
+
+func TestVersionAtLeast(t *testing.T) {
+	for _, tc := range []struct {
+		version, min string
+		want         bool
+	}{
+		{"8.0.36", "8.0", true},
+		{"8.0.36-log", "8.0.37", false},
+		{"5.7.44", "8.0", false},
+		{"8.4.3", "8.0.13", true},
+		{"8.0", "8.0.0", true},
+		{"8.0.mysql_aurora.3.05.2", "8.0", true},
+	} {
+		t.Run("バージョン比較_"+tc.version+"_"+tc.min, func(t *testing.T) {
+			if got := VersionAtLeast(tc.version, tc.min); got != tc.want {
+				t.Fatalf("VersionAtLeast(%q, %q) = %v, want %v", tc.version, tc.min, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMemoryFit(t *testing.T) {
+	cfg := DefaultConfig()
+	results := []Result{
+		{DB: "mysql", Table: "bench_auto", DataBytes: 8 << 20, IndexBytes: 0},
+		{DB: "mysql", Table: "bench_uuid_char", DataBytes: 12 << 20, IndexBytes: 4 << 20},
+		{DB: "mysql", Table: "bench_uuid_bin", Err: "boom", DataBytes: 1 << 30},
+	}
+
+	t.Run("実測_最大の表が収まれば余裕を示す", func(t *testing.T) {
+		got := MemoryFit("mysql", 128<<20, results, cfg)
+		want := "in-memory: largest table bench_uuid_char 16.0MiB (measured) fits in 128.0MiB, headroom 112.0MiB (88%); disk I/O hardly shows in these numbers"
+		if got != want {
+			t.Fatalf("got  %q\nwant %q", got, want)
+		}
+	})
+
+	t.Run("実測_収まらなければ超過分を示す", func(t *testing.T) {
+		got := MemoryFit("mysql", 8<<20, results, cfg)
+		if !strings.HasPrefix(got, "io-bound: largest table bench_uuid_char 16.0MiB (measured) exceeds 8.0MiB by 8.0MiB (200% of the cache)") {
+			t.Fatalf("got %q", got)
+		}
+	})
+
+	t.Run("未計測_見積もりを使う", func(t *testing.T) {
+		got := MemoryFit("postgres", 1<<40, results, cfg)
+		if !strings.HasPrefix(got, "in-memory: largest table ") || !strings.Contains(got, "(estimated)") {
+			t.Fatalf("got %q", got)
+		}
+	})
+
+	t.Run("キャッシュ未取得_空", func(t *testing.T) {
+		if got := MemoryFit("mysql", 0, results, cfg); got != "" {
+			t.Fatalf("got %q", got)
+		}
+	})
+}
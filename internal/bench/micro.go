@@ -0,0 +1,73 @@
+package bench
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MicroResult は DB を介さない UUID 生成/変換 1 方式ぶんの計測結果を表す。
+type MicroResult struct {
+	Name    string
+	N       int
+	NsPerOp float64
+}
+
+// microCase は -micro で計測する表現形式 1 つぶん。
+// encode は生成から DB へ渡す値までを行い、結果の長さを返す（最適化で消されないよう集計する）。
+type microCase struct {
+	name   string
+	encode func() int
+}
+
+// microCases は DB 計測の各方式が INSERT 前に行う処理に対応させている。
+var microCases = []microCase{
+	{"uuid_new", func() int { u := uuid.New(); return len(u) }},
+	{"char36_string", func() int { return len(uuid.NewString()) }},
+	{"char32_hex", func() int { return len(EncodeUUIDHex(uuid.New())) }},
+	{"binary16_bytes", func() int { return len(UUIDToBytes(uuid.New())) }},
+	{"binary16_swapped", func() int { return len(UUIDToSwappedBytes(uuid.New())) }},
+	{"base64_22", func() int { return len(EncodeUUIDBase64(uuid.New())) }},
+}
+
+// microSink は encode の結果を受け取り、計測ループがコンパイラに消されないようにする。
+var microSink int
+
+// RunMicro は各表現形式で n 個の UUID を生成・変換し、1 件あたりのナノ秒を返す。
+// DB 計測に含まれるクライアント側の変換コストだけを切り出して見るために使う。
+func RunMicro(n int) []MicroResult {
+	results := make([]MicroResult, 0, len(microCases))
+	for _, c := range microCases {
+		start := time.Now()
+		for i := 0; i < n; i++ {
+			microSink += c.encode()
+		}
+		elapsed := time.Since(start)
+		results = append(results, MicroResult{Name: c.name, N: n, NsPerOp: float64(elapsed.Nanoseconds()) / float64(n)})
+	}
+	return results
+}
+
+// UUIDToSwappedBytes は MySQL の UUID_TO_BIN(uuid, 1) と同じ並びの 16 バイトを返す。
+// time_hi / time_mid を先頭へ移し、UUIDv1 の時刻順に近い並びにする。
+func UUIDToSwappedBytes(u uuid.UUID) []byte {
+	b := make([]byte, 16)
+	copy(b[0:2], u[6:8])
+	copy(b[2:4], u[4:6])
+	copy(b[4:8], u[0:4])
+	copy(b[8:], u[8:])
+	return b
+}
+
+// FormatMicro は RunMicro の結果を見出し付き CSV 文字列に整形する。
+func FormatMicro(results []MicroResult) string {
+	var out bytes.Buffer
+	out.WriteString("=== UUID Encoding Micro Benchmark ===\n")
+	out.WriteString("representation,n,ns_per_op\n")
+	for _, r := range results {
+		fmt.Fprintf(&out, "%s,%d,%.2f\n", r.Name, r.N, r.NsPerOp)
+	}
+	return out.String()
+}
@@ -0,0 +1,41 @@
+package bench
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestUUIDToSwappedBytes(t *testing.T) {
+	t.Run("スワップ変換_UUID_TO_BINのswap指定と同じ並びになる", func(t *testing.T) {
+		// MySQL: HEX(UUID_TO_BIN('6ccd780c-baba-1026-9564-5b8c656024db', 1)) = '1026BABA6CCD780C95645B8C656024DB'
+		u := uuid.MustParse("6ccd780c-baba-1026-9564-5b8c656024db")
+		got := UUIDToSwappedBytes(u)
+		want := []byte{0x10, 0x26, 0xba, 0xba, 0x6c, 0xcd, 0x78, 0x0c, 0x95, 0x64, 0x5b, 0x8c, 0x65, 0x60, 0x24, 0xdb}
+		if string(got) != string(want) {
+			t.Fatalf("UUIDToSwappedBytes = %x, want %x", got, want)
+		}
+	})
+}
+
+func TestRunMicro(t *testing.T) {
+	t.Run("マイクロベンチ_全表現形式を計測して整形する", func(t *testing.T) {
+		results := RunMicro(100)
+		if len(results) != len(microCases) {
+			t.Fatalf("len = %d, want %d", len(results), len(microCases))
+		}
+		out := FormatMicro(results)
+		if !strings.Contains(out, "representation,n,ns_per_op\n") {
+			t.Fatalf("missing header: %s", out)
+		}
+		for _, r := range results {
+			if r.N != 100 || r.NsPerOp <= 0 {
+				t.Fatalf("unexpected result: %+v", r)
+			}
+			if !strings.Contains(out, r.Name+",100,") {
+				t.Fatalf("missing row for %s: %s", r.Name, out)
+			}
+		}
+	})
+}
@@ -0,0 +1,240 @@
+package bench
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// mixedSeed は混合負荷で読み書きと検索キーを選ぶ乱数の既定シード。ワーカー番号と組み合わせて使う。
+const mixedSeed = 20260520
+
+// ParseReadWriteRatio は "9:1" 形式の読み取り:書き込み比を解析し、全操作に占める読み取りの割合を返す。
+func ParseReadWriteRatio(s string) (float64, error) {
+	r, w, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok {
+		return 0, fmt.Errorf("mixed-ratio %q must be READS:WRITES (e.g. 9:1)", s)
+	}
+	reads, errR := strconv.Atoi(strings.TrimSpace(r))
+	writes, errW := strconv.Atoi(strings.TrimSpace(w))
+	if errR != nil || errW != nil || reads < 0 || writes < 0 || reads+writes == 0 {
+		return 0, fmt.Errorf("mixed-ratio %q must be two non-negative integers that are not both 0", s)
+	}
+	return float64(reads) / float64(reads+writes), nil
+}
+
+// mixedOutcome は混合負荷フェーズ 1 回ぶんの集計。Latencies は成功した操作ごとの秒数。
+type mixedOutcome struct {
+	Reads     int
+	Writes    int
+	Seconds   float64
+	Latencies []float64
+}
+
+// apply は o の秒間操作数と遅延の百分位（ミリ秒）を r へ書き込む。
+func (o mixedOutcome) apply(r *Result) {
+	if o.Seconds > 0 {
+		r.MixedOpsPerSec = float64(o.Reads+o.Writes) / o.Seconds
+	}
+	r.MixedP50Ms = Percentile(o.Latencies, 50) * 1000
+	r.MixedP95Ms = Percentile(o.Latencies, 95) * 1000
+	r.MixedP99Ms = Percentile(o.Latencies, 99) * 1000
+}
+
+// mixedWorkload は cfg.MixedWorkers 個のゴルーチンで cfg.MixedDuration の間、
+// cfg.MixedReadFraction の割合で keys からランダムに選んだ点検索を、残りで挿入を発行する。
+// 挿入の行番号は cfg.Rows から順に振る。時間切れで中断された操作は数えない。
+// いずれかの操作が失敗したら残りを中断し、最初のエラーを返す。
+func mixedWorkload[K any](ctx context.Context, cfg Config, log *slog.Logger, keys []K, read func(ctx context.Context, key K) error, write func(ctx context.Context, i int) error) (mixedOutcome, error) {
+	if len(keys) == 0 {
+		return mixedOutcome{}, errors.New("mixed workload has no rows to read")
+	}
+	parent := ctx
+	ctx, cancel := context.WithTimeout(ctx, cfg.MixedDuration)
+	defer cancel()
+	log.Debug("mixed workload start", "workers", cfg.MixedWorkers, "duration", cfg.MixedDuration, "read_fraction", cfg.MixedReadFraction)
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+		next     atomic.Int64
+	)
+	perWorker := make([]mixedOutcome, cfg.MixedWorkers)
+	start := time.Now()
+	for w := range perWorker {
+		wg.Add(1)
+		go func(o *mixedOutcome, w int) {
+			defer wg.Done()
+			r := rand.New(rand.NewPCG(mixedSeed, uint64(w)))
+			for ctx.Err() == nil {
+				isRead := r.Float64() < cfg.MixedReadFraction
+				opStart := time.Now()
+				var err error
+				if isRead {
+					key := keys[r.IntN(len(keys))]
+					err = withQueryTimeout(ctx, cfg, 0, func(ctx context.Context, _ int) error { return read(ctx, key) })
+				} else {
+					err = withQueryTimeout(ctx, cfg, cfg.Rows+int(next.Add(1))-1, write)
+				}
+				if err != nil {
+					// 計測時間の終了で打ち切られた操作は失敗扱いにしない。
+					if ctx.Err() != nil && parent.Err() == nil {
+						return
+					}
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					return
+				}
+				o.Latencies = append(o.Latencies, time.Since(opStart).Seconds())
+				if isRead {
+					o.Reads++
+				} else {
+					o.Writes++
+				}
+			}
+		}(&perWorker[w], w)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return mixedOutcome{}, firstErr
+	}
+	if err := parent.Err(); err != nil {
+		return mixedOutcome{}, err
+	}
+	out := mixedOutcome{Seconds: time.Since(start).Seconds()}
+	for _, o := range perWorker {
+		out.Reads += o.Reads
+		out.Writes += o.Writes
+		out.Latencies = append(out.Latencies, o.Latencies...)
+	}
+	return out, nil
+}
+
+// benchMixed は table へ cfg.Rows 行を cfg.MixedWorkers 個のワーカーで投入してから混合負荷をかける。
+// 検索対象は投入後に keys で取得したキーで、負荷中に挿入した行は検索しない。
+func benchMixed[K any](ctx context.Context, cfg Config, kind, table string, seed func(ctx context.Context, i int) error, keys func(ctx context.Context) ([]K, error), read func(ctx context.Context, key K) error, write func(ctx context.Context, i int) error) (Result, error) {
+	log := slog.With("db", kind, "table", table)
+	seedCfg := cfg
+	seedCfg.ConcurrentWorkers = cfg.MixedWorkers
+	seedSec, err := concurrentInsert(ctx, seedCfg, log, seed)
+	if err != nil {
+		return Result{}, err
+	}
+	ks, err := keys(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+	out, err := mixedWorkload(ctx, cfg, log, ks, read, write)
+	if err != nil {
+		return Result{}, err
+	}
+	res := Result{
+		DB:            kind,
+		Table:         table,
+		InsertRows:    cfg.Rows,
+		InsertSeconds: seedSec,
+		Workers:       cfg.MixedWorkers,
+	}
+	out.apply(&res)
+	log.Info("mixed workload done", "reads", out.Reads, "writes", out.Writes, "ops_per_sec", res.MixedOpsPerSec, "p99_ms", res.MixedP99Ms)
+	return res, nil
+}
+
+// runMixed は kind の連番主キー (bench_auto_mixed) と UUID 主キー (bench_uuid_mixed) に混合負荷をかける。
+// MySQL の UUID は BINARY(16)、PostgreSQL は UUID 型で保存する。
+func runMixed(ctx context.Context, db *sql.DB, cfg Config, kind string) ([]Result, error) {
+
+	gen := payloadFor(cfg)
+	autoInsert, err := prepare(ctx, db, cfg, insertSQL(kind, "bench_auto_mixed", []string{"payload"}, gen.Columns()))
+	if err != nil {
+		return nil, err
+	}
+	defer autoInsert.Close()
+	autoSelect, err := prepare(ctx, db, cfg, "SELECT payload FROM bench_auto_mixed WHERE id = "+placeholders(kind, 1))
+	if err != nil {
+		return nil, err
+	}
+	defer autoSelect.Close()
+	insertAuto := func(ctx context.Context, i int) error {
+		_, err := autoInsert.ExecContext(ctx, insertArgs(gen, i)...)
+		return err
+	}
+	auto, err := benchMixed(ctx, cfg, kind, "bench_auto_mixed", insertAuto, func(ctx context.Context) ([]int64, error) {
+		return selectKeys[int64](ctx, db, "bench_auto_mixed")
+	}, func(ctx context.Context, id int64) error {
+		var p sql.NullString
+		return autoSelect.QueryRowContext(ctx, id).Scan(&p)
+	}, insertAuto)
+	if err != nil {
+		return nil, err
+	}
+
+	// MySQL は BINARY(16) のバイト列、PostgreSQL は UUID 型のまま渡す。
+	uuidArg := func(u uuid.UUID) any {
+		if kind == "mysql" {
+			return UUIDToBytes(u)
+		}
+		return u
+	}
+	uuidInsert, err := prepare(ctx, db, cfg, insertSQL(kind, "bench_uuid_mixed", []string{"id", "payload"}, gen.Columns()))
+	if err != nil {
+		return nil, err
+	}
+	defer uuidInsert.Close()
+	uuidSelect, err := prepare(ctx, db, cfg, "SELECT payload FROM bench_uuid_mixed WHERE id = "+placeholders(kind, 1))
+	if err != nil {
+		return nil, err
+	}
+	defer uuidSelect.Close()
+	seeded := make([]uuid.UUID, cfg.Rows)
+	for i := range seeded {
+		seeded[i] = newUUID(cfg, i)
+	}
+	uuidRes, err := benchMixed(ctx, cfg, kind, "bench_uuid_mixed", func(ctx context.Context, i int) error {
+		_, err := uuidInsert.ExecContext(ctx, insertArgs(gen, i, uuidArg(seeded[i]))...)
+		return err
+	}, func(context.Context) ([]uuid.UUID, error) {
+		return seeded, nil
+	}, func(ctx context.Context, id uuid.UUID) error {
+		var p sql.NullString
+		return uuidSelect.QueryRowContext(ctx, uuidArg(id)).Scan(&p)
+	}, func(ctx context.Context, i int) error {
+		_, err := uuidInsert.ExecContext(ctx, insertArgs(gen, i, uuidArg(newUUID(cfg, i)))...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []Result{auto, uuidRes}, nil
+}
+
+// selectKeys は table の主キー id を K 型で全件読み出す。
+func selectKeys[K any](ctx context.Context, db *sql.DB, table string) ([]K, error) {
+	rows, err := db.QueryContext(ctx, "SELECT id FROM "+table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ids []K
+	for rows.Next() {
+		var id K
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -0,0 +1,121 @@
+package bench
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseReadWriteRatio(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    float64
+		wantErr bool
+	}{
+		{"9:1", 0.9, false},
+		{" 1 : 1 ", 0.5, false},
+		{"0:1", 0, false},
+		{"1:0", 1, false},
+		{"0:0", 0, true},
+		{"9", 0, true},
+		{"-1:2", 0, true},
+		{"a:b", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run("読み書き比_"+tt.in, func(t *testing.T) {
+			got, err := ParseReadWriteRatio(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && math.Abs(got-tt.want) > 1e-12 {
+				t.Fatalf("ParseReadWriteRatio(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMixedOutcomeApply(t *testing.T) {
+	t.Run("混合負荷集計_秒間操作数とミリ秒の百分位", func(t *testing.T) {
+		lat := make([]float64, 100)
+		for i := range lat {
+			lat[i] = float64(i+1) / 1000
+		}
+		var r Result
+		mixedOutcome{Reads: 150, Writes: 50, Seconds: 2, Latencies: lat}.apply(&r)
+		if r.MixedOpsPerSec != 100 {
+			t.Fatalf("MixedOpsPerSec = %v, want 100", r.MixedOpsPerSec)
+		}
+		for _, c := range []struct {
+			name string
+			got  float64
+			want float64
+		}{{"p50", r.MixedP50Ms, 50}, {"p95", r.MixedP95Ms, 95}, {"p99", r.MixedP99Ms, 99}} {
+			if math.Abs(c.got-c.want) > 1e-9 {
+				t.Fatalf("%s = %v, want %v", c.name, c.got, c.want)
+			}
+		}
+	})
+}
+
+func TestMixedWorkload(t *testing.T) {
+	cfg := Config{Rows: 10, MixedDuration: 30 * time.Millisecond, MixedWorkers: 3, MixedReadFraction: 0.5}
+	log := slog.New(slog.DiscardHandler)
+
+	t.Run("混合負荷_読み書きを比率どおりに混ぜ挿入番号はRowsから振る", func(t *testing.T) {
+		var reads, writes atomic.Int64
+		var minWrite atomic.Int64
+		minWrite.Store(math.MaxInt64)
+		out, err := mixedWorkload(context.Background(), cfg, log, []int{1, 2, 3}, func(context.Context, int) error {
+			reads.Add(1)
+			time.Sleep(time.Millisecond)
+			return nil
+		}, func(_ context.Context, i int) error {
+			writes.Add(1)
+			for {
+				cur := minWrite.Load()
+				if int64(i) >= cur || minWrite.CompareAndSwap(cur, int64(i)) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if out.Reads == 0 || out.Writes == 0 {
+			t.Fatalf("reads = %d, writes = %d, want both > 0", out.Reads, out.Writes)
+		}
+		if len(out.Latencies) != out.Reads+out.Writes {
+			t.Fatalf("latencies = %d, want %d", len(out.Latencies), out.Reads+out.Writes)
+		}
+		if minWrite.Load() != int64(cfg.Rows) {
+			t.Fatalf("first write index = %d, want %d", minWrite.Load(), cfg.Rows)
+		}
+		if out.Seconds < cfg.MixedDuration.Seconds() {
+			t.Fatalf("seconds = %v, want >= %v", out.Seconds, cfg.MixedDuration.Seconds())
+		}
+	})
+
+	t.Run("混合負荷_操作の失敗を返す", func(t *testing.T) {
+		boom := errors.New("boom")
+		_, err := mixedWorkload(context.Background(), cfg, log, []int{1}, func(context.Context, int) error {
+			return boom
+		}, func(context.Context, int) error {
+			return boom
+		})
+		if !errors.Is(err, boom) {
+			t.Fatalf("err = %v, want boom", err)
+		}
+	})
+
+	t.Run("混合負荷_検索対象がなければエラー", func(t *testing.T) {
+		if _, err := mixedWorkload(context.Background(), cfg, log, []int{}, nil, nil); err == nil {
+			t.Fatal("expected error for empty keys")
+		}
+	})
+}
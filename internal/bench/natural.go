@@ -0,0 +1,130 @@
+package bench
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+)
+
+// naturalKeySeed は NaturalKey の既定シード。実行間で同じキー列になるよう固定する。
+const naturalKeySeed = 20260320
+
+// naturalCountries は自然キーの国コードに使う ISO 3166-1 alpha-2 の一覧。
+var naturalCountries = []string{"JP", "US", "GB", "DE", "FR", "KR", "CN", "IN", "BR", "CA", "AU", "SG"}
+
+// naturalRangeCountry は自然キー方式の範囲検索で対象にする国コード。
+var naturalRangeCountry = naturalCountries[0]
+
+// NaturalKey は i 行目の自然キー (country, email) を返す。
+// 業務キーらしく挿入順と無関係に散らばるよう、seed と i から決まる乱数で国とメールの接頭辞を選ぶ。
+// メールには i を含めるため、同じ seed なら i が異なる限りキーは重複しない。
+func NaturalKey(i int, seed uint64) (country, email string) {
+	r := rand.New(rand.NewPCG(seed, uint64(i)))
+	country = naturalCountries[r.IntN(len(naturalCountries))]
+	email = fmt.Sprintf("%08x.%d@example.com", r.Uint32(), i)
+	return country, email
+}
+
+// benchNatural は (country, email) 複合の自然キーを主キーにしたテーブルを計測する。
+// サロゲートキー（連番/UUID）方式と同じ手順で Insert・Point Lookup を計り、
+// 範囲検索は主キー先頭列の等値（1 か国ぶんの件数）で計測する。
+func benchNatural(ctx context.Context, db *sql.DB, cfg Config, kind string) (Result, error) {
+	log := slog.With("db", kind, "table", "bench_natural")
+	gen := payloadFor(cfg)
+	insertStmt, err := prepare(ctx, db, cfg, insertSQL(kind, "bench_natural", []string{"country", "email", "payload"}, gen.Columns()))
+	if err != nil {
+		return Result{}, err
+	}
+	defer insertStmt.Close()
+
+	type naturalKey struct{ country, email string }
+	keys := make([]naturalKey, 0, cfg.Rows)
+	inserted, insertSec, err := insertLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		country, email := NaturalKey(i, naturalKeySeed)
+		if _, err := insertStmt.ExecContext(ctx, insertArgs(gen, i, country, email)...); err != nil {
+			return err
+		}
+		keys = append(keys, naturalKey{country, email})
+		return nil
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	if err := analyzeTable(ctx, db, cfg, log, kind, "bench_natural"); err != nil {
+		return Result{}, err
+	}
+
+	// 点検索サンプルは挿入順の先頭から lookups 件を使う。
+	sample := keys
+	if len(sample) > cfg.Lookups {
+		sample = sample[:cfg.Lookups]
+	}
+	selectSQL := "SELECT payload FROM bench_natural WHERE country = ? AND email = ?"
+	rangeSQL := "SELECT COUNT(*) FROM bench_natural WHERE country = ?"
+	if kind == "postgres" {
+		selectSQL = "SELECT payload FROM bench_natural WHERE country = $1 AND email = $2"
+		rangeSQL = "SELECT COUNT(*) FROM bench_natural WHERE country = $1"
+	}
+	selectStmt, err := prepare(ctx, db, cfg, selectSQL)
+	if err != nil {
+		return Result{}, err
+	}
+	defer selectStmt.Close()
+
+	// Point Lookup 計測: 複合主キー完全一致検索の反復時間。
+	point, err := pointLoop(ctx, cfg, log, len(sample), func(ctx context.Context, i int) error {
+		var payload sql.NullString
+		return selectStmt.QueryRowContext(ctx, sample[i].country, sample[i].email).Scan(&payload)
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	log.Debug("range scan start", "country", naturalRangeCountry)
+	_, endRange := startSpan(ctx, "range")
+	rctx, cancel := queryContext(ctx, cfg)
+	defer cancel()
+	start := time.Now()
+	var c int64
+	if err := db.QueryRowContext(rctx, rangeSQL, naturalRangeCountry).Scan(&c); err != nil {
+		return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
+	}
+	rangeSec := time.Since(start).Seconds()
+	log.Info("range scan done", "sec", rangeSec, "rows", c)
+	endRange(nil)
+	rangeUsedIndex, err := explainRange(ctx, db, cfg, log, kind, rangeSQL, naturalRangeCountry)
+	if err != nil {
+		return Result{}, err
+	}
+
+	// Insert→Readback 計測: 自然キーは挿入前に手元で決まるため、挿入後すぐそのキーで読み戻せる。
+	readbackSec, err := readbackLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		country, email := NaturalKey(inserted+i, naturalKeySeed)
+		if _, err := insertStmt.ExecContext(ctx, insertArgs(gen, inserted+i, country, email)...); err != nil {
+			return err
+		}
+		var payload sql.NullString
+		return selectStmt.QueryRowContext(ctx, country, email).Scan(&payload)
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		DB:                    kind,
+		Table:                 "bench_natural",
+		InsertRows:            inserted,
+		InsertSeconds:         insertSec,
+		PointLookupCount:      len(sample),
+		PointSeconds:          point.Seconds,
+		PointRounds:           point.Rounds,
+		PointWarmSeconds:      point.Warm,
+		PointSteadySeconds:    point.Steady,
+		RangeSeconds:          rangeSec,
+		RangeUsedIndex:        rangeUsedIndex,
+		InsertReadbackSeconds: readbackSec,
+	}, nil
+}
@@ -0,0 +1,46 @@
+package bench
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNaturalKey(t *testing.T) {
+	t.Run("自然キー_同じ引数なら同じキー", func(t *testing.T) {
+		c1, e1 := NaturalKey(42, naturalKeySeed)
+		c2, e2 := NaturalKey(42, naturalKeySeed)
+		if c1 != c2 || e1 != e2 {
+			t.Fatalf("NaturalKey not deterministic: (%s, %s) vs (%s, %s)", c1, e1, c2, e2)
+		}
+	})
+
+	t.Run("自然キー_列の幅に収まり重複しない", func(t *testing.T) {
+		seen := make(map[string]bool)
+		for i := range 10000 {
+			country, email := NaturalKey(i, naturalKeySeed)
+			if len(country) != 2 || !slices.Contains(naturalCountries, country) {
+				t.Fatalf("row %d: unexpected country %q", i, country)
+			}
+			if len(email) > 100 || !strings.HasSuffix(email, "@example.com") {
+				t.Fatalf("row %d: unexpected email %q", i, email)
+			}
+			key := country + "/" + email
+			if seen[key] {
+				t.Fatalf("row %d: duplicate key %s", i, key)
+			}
+			seen[key] = true
+		}
+	})
+
+	t.Run("自然キー_挿入順とキー順が一致しない", func(t *testing.T) {
+		keys := make([]string, 100)
+		for i := range keys {
+			country, email := NaturalKey(i, naturalKeySeed)
+			keys[i] = country + "/" + email
+		}
+		if slices.IsSorted(keys) {
+			t.Fatal("natural keys should not arrive in key order")
+		}
+	})
+}
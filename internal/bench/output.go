@@ -0,0 +1,207 @@
+package bench
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// outputFormats は -format で選べる出力形式。all は -out-prefix のファイルへ全形式を書き、
+// jsonl は計測が終わった方式から 1 件ずつ書き出す。
+var outputFormats = []string{"csv", "html", "markdown", "json", "jsonl", "all"}
+
+// FormatResultsCSV は見出し行なしの CSV（ヘッダ + 1 結果 1 行）に整形する。
+// スプレッドシートへそのまま読み込めるよう末尾は改行で終える。
+func FormatResultsCSV(results []Result, prec int) string {
+	var out bytes.Buffer
+	cols := columnsFor(results)
+	out.WriteString(strings.Join(columnNames(cols), ",") + "\n")
+	for _, r := range results {
+		out.WriteString(strings.Join(columnValues(cols, r, prec), ",") + "\n")
+	}
+	return out.String()
+}
+
+// FormatResultsMarkdown は計測結果を Markdown の表に整形する。PR やレポートへの貼り付け用。
+func FormatResultsMarkdown(results []Result, prec int) string {
+	var out bytes.Buffer
+	cols := columnsFor(results)
+	names := columnNames(cols)
+	out.WriteString("| " + strings.Join(names, " | ") + " |\n")
+	out.WriteString("|" + strings.Repeat(" --- |", len(names)) + "\n")
+	for _, r := range results {
+		vals := columnValues(cols, r, prec)
+		for i, v := range vals {
+			// 値に | が含まれると列がずれるためエスケープする。
+			vals[i] = strings.ReplaceAll(v, "|", `\|`)
+		}
+		out.WriteString("| " + strings.Join(vals, " | ") + " |\n")
+	}
+	return out.String()
+}
+
+// OutputSchemaVersion は -format json の出力の形式の版。Result の json タグやメタデータのキーを
+// 追加・変更・削除したら上げる。
+const OutputSchemaVersion = 9
+
+// JSONOutput は -format json の出力全体。Metadata は FormatMetadata と同じキーで、値のある項目だけを
+// 文字列で持つ。Results の各要素のキーは Result の json タグに従う。
+type JSONOutput struct {
+	SchemaVersion int               `json:"schema_version"`
+	Metadata      map[string]string `json:"metadata"`
+	Results       []Result          `json:"results"`
+}
+
+// FormatResultsJSON は md と計測結果を、OutputSchemaVersion を付けた JSONOutput に整形する。
+func FormatResultsJSON(md Metadata, results []Result) (string, error) {
+	out := JSONOutput{SchemaVersion: OutputSchemaVersion, Metadata: make(map[string]string), Results: results}
+	if out.Results == nil {
+		out.Results = []Result{}
+	}
+	for _, kv := range metadataPairs(md) {
+		out.Metadata[kv[0]] = kv[1]
+	}
+	b, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b) + "\n", nil
+}
+
+// JSONLWriter は計測結果を 1 件 1 行の JSON として逐次書き出す。
+// Runner.OnResult から呼び、全結果を待たずにログ収集基盤などへ流す用途を想定する。
+type JSONLWriter struct {
+	enc *json.Encoder
+	err error
+}
+
+// NewJSONLWriter は w へ書き出す JSONLWriter を返す。
+func NewJSONLWriter(w io.Writer) *JSONLWriter {
+	return &JSONLWriter{enc: json.NewEncoder(w)}
+}
+
+// Write は r を 1 行の JSON として書く。書き込みに一度失敗したら以降は何もしない。
+func (j *JSONLWriter) Write(r Result) {
+	if j.err != nil {
+		return
+	}
+	j.err = j.enc.Encode(r)
+}
+
+// Err は最初に発生した書き込みエラーを返す。
+func (j *JSONLWriter) Err() error {
+	return j.err
+}
+
+// Report は WriteResults で書き出す 1 回の実行の内容。Rerun は -determinism-check の 2 回目の結果で、
+// 指定していなければ nil。
+type Report struct {
+	Metadata Metadata
+	Results  []Result
+	Rerun    []Result
+}
+
+// WriteResults は cfg.Format に従って report を w へ書き出す。コマンドは w に stdout を渡し、
+// テストや組み込み先は bytes.Buffer やファイルを渡して出力を受け取れる。
+// jsonl は計測中に JSONLWriter で書き出し済みのため何も書かない。all は csv と同じ内容を書き、
+// ファイルへの書き出しは WriteAllFormats に任せる。
+func WriteResults(w io.Writer, cfg Config, report Report) error {
+	var out strings.Builder
+	results := report.Results
+	switch cfg.Format {
+	case "html":
+		out.WriteString(FormatResultsHTML(results))
+	case "jsonl":
+	case "markdown":
+		out.WriteString(FormatResultsMarkdown(results, cfg.Precision))
+	case "json":
+		s, err := FormatResultsJSON(report.Metadata, results)
+		if err != nil {
+			return err
+		}
+		out.WriteString(s)
+	default:
+		// -compact-output は全体の表の代わりに、DB ごとの勝者を 1 行ずつ出す。
+		if cfg.CompactOutput {
+			out.WriteString(FormatCompact(Compact(results)))
+		} else {
+			out.WriteString(FormatMetadata(report.Metadata) + "\n")
+			out.WriteString(FormatResultsPrecision(results, cfg.Precision) + "\n")
+		}
+		// 要約の表は空行で区切って続ける。
+		section := func(s string) {
+			out.WriteString("\n" + s)
+		}
+		if cfg.Preset == PresetMySQLUUIDRepresentations {
+			section(FormatRepresentations(results, cfg.Precision))
+		}
+		if cfg.SecondaryLookups {
+			section(FormatSecondaryLookups(SecondaryLookups(results)))
+		}
+		if cfg.Scorecard {
+			section(FormatScorecard(Scorecard(results)))
+		}
+		if cfg.DeterminismCheck {
+			section(FormatDeterminism(CheckDeterminism(results, report.Rerun)))
+		}
+	}
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+// WriteAllFormats は prefix.csv / prefix.md / prefix.json / prefix.html へ全形式を書き出し、
+// 書いたファイル名を返す。高コストな計測を形式ごとに再実行しなくて済むようにする。
+// JSON には md も含める。
+// gz が真なら各ファイルを gzip で圧縮し、ファイル名の末尾に .gz を付ける。
+func WriteAllFormats(prefix string, md Metadata, results []Result, prec int, gz bool) ([]string, error) {
+	jsonOut, err := FormatResultsJSON(md, results)
+	if err != nil {
+		return nil, err
+	}
+	files := []struct {
+		ext  string
+		body string
+	}{
+		{".csv", FormatResultsCSV(results, prec)},
+		{".md", FormatResultsMarkdown(results, prec)},
+		{".json", jsonOut},
+		{".html", FormatResultsHTML(results)},
+	}
+	paths := make([]string, 0, len(files))
+	for _, f := range files {
+		path := prefix + f.ext
+		if gz {
+			path += ".gz"
+		}
+		if err := writeOutputFile(path, f.body, gz); err != nil {
+			return paths, fmt.Errorf("write %s: %w", path, err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// writeOutputFile は body を path へ書く。gz が真なら gzip.Writer を通して圧縮する。
+func writeOutputFile(path, body string, gz bool) error {
+	if !gz {
+		return os.WriteFile(path, []byte(body), 0o644)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(f)
+	if _, err := io.WriteString(zw, body); err != nil {
+		f.Close()
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,266 @@
+package bench
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+var outputTestResults = []Result{
+	{DB: "mysql", Table: "bench_auto", InsertRows: 10, InsertSeconds: 1.5, PointLookupCount: 5, PointSeconds: 0.25, RangeSeconds: 0.125},
+	{DB: "postgres", Table: "bench_uuid", InsertRows: 10, InsertSeconds: 2, PointLookupCount: 5, PointSeconds: 0.5, RangeSeconds: 0.25},
+}
+
+func TestFormatResultsCSV(t *testing.T) {
+	t.Run("CSV_見出し行なしでヘッダから始まる", func(t *testing.T) {
+		got := FormatResultsCSV(outputTestResults, 2)
+		lines := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+		if len(lines) != 3 || !strings.HasPrefix(lines[0], "db,table,") {
+			t.Fatalf("csv = %q", got)
+		}
+		if lines[1] != "mysql,bench_auto,10,1.50,5,0.25,0.12" && lines[1] != "mysql,bench_auto,10,1.50,5,0.25,0.13" {
+			t.Fatalf("row = %q", lines[1])
+		}
+	})
+
+	t.Run("CSV_COUNT計測の方式はrange_bytesを空欄にする", func(t *testing.T) {
+		rs := []Result{{DB: "mysql", Table: "bench_auto"}, {DB: "mysql", Table: "bench_uuid_bin", RangeBytes: 160000}}
+		lines := strings.Split(strings.TrimSuffix(FormatResultsCSV(rs, 2), "\n"), "\n")
+		col := slices.Index(strings.Split(lines[0], ","), "range_bytes")
+		if col < 0 {
+			t.Fatalf("header = %q, want range_bytes", lines[0])
+		}
+		if got := strings.Split(lines[1], ",")[col]; got != "" {
+			t.Fatalf("bench_auto range_bytes = %q, want empty", got)
+		}
+		if got := strings.Split(lines[2], ",")[col]; got != "160000" {
+			t.Fatalf("bench_uuid_bin range_bytes = %q, want 160000", got)
+		}
+	})
+}
+
+func TestFormatResultsMarkdown(t *testing.T) {
+	t.Run("Markdown_区切り行と結果行を出力する", func(t *testing.T) {
+		got := FormatResultsMarkdown(outputTestResults, 1)
+		lines := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+		if len(lines) != 4 {
+			t.Fatalf("lines = %d:\n%s", len(lines), got)
+		}
+		if strings.Count(lines[0], "|") != strings.Count(lines[1], "|") {
+			t.Fatalf("separator does not match header:\n%s", got)
+		}
+		if !strings.HasPrefix(lines[3], "| postgres | bench_uuid | 10 | 2.0 |") {
+			t.Fatalf("row = %q", lines[3])
+		}
+	})
+
+	t.Run("Markdown_値の縦棒をエスケープする", func(t *testing.T) {
+		got := FormatResultsMarkdown([]Result{{Label: "a|b", DB: "mysql"}}, 1)
+		if !strings.Contains(got, `a\|b`) {
+			t.Fatalf("pipe not escaped:\n%s", got)
+		}
+	})
+}
+
+func TestFormatResultsJSON(t *testing.T) {
+	t.Run("JSON_版とメタデータ付きで読み戻せる", func(t *testing.T) {
+		got, err := FormatResultsJSON(Metadata{RunID: "r1", Label: "ssd"}, outputTestResults)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var back JSONOutput
+		if err := json.Unmarshal([]byte(got), &back); err != nil {
+			t.Fatal(err)
+		}
+		if back.SchemaVersion != OutputSchemaVersion || back.Metadata["run_id"] != "r1" || back.Metadata["label"] != "ssd" {
+			t.Fatalf("envelope = %+v", back)
+		}
+		if len(back.Results) != 2 || back.Results[1].Table != "bench_uuid" || back.Results[1].InsertSeconds != 2 {
+			t.Fatalf("round trip = %+v", back.Results)
+		}
+	})
+
+	t.Run("JSON_結果なしは空配列", func(t *testing.T) {
+		got, err := FormatResultsJSON(Metadata{}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(got, `"results": []`) || !strings.Contains(got, `"metadata": {}`) {
+			t.Fatalf("json = %q", got)
+		}
+	})
+
+	t.Run("JSON_Resultのキーが変わったらOutputSchemaVersionを上げる", func(t *testing.T) {
+		// 版 9 のキー。Result の json タグを変えたらここを直し、OutputSchemaVersion と README の表も更新する。
+		want := []string{
+			"label", "db", "table", "insert_rows", "insert_sec", "point_lookups", "point_sec", "range_or_orderby_sec",
+			"range_used_index", "range_bytes", "point_rounds", "point_warm_sec", "point_steady_sec", "index_only_point_sec",
+			"reverse_lookups", "reverse_point_sec", "hot_point_sec", "cold_point_sec", "insert_readback_sec",
+			"insert_returning_sec", "insert_select_key_sec", "prepopulate_sec",
+			"insert_rows_per_sec", "large_insert_rows", "large_insert_sec", "range_delete_rows", "range_delete_sec", "insert_cpu_sec",
+			"point_cpu_sec", "range_cpu_sec", "repeats", "insert_sec_ci_low", "insert_sec_ci_high", "point_sec_ci_low",
+			"point_sec_ci_high", "range_sec_ci_low", "range_sec_ci_high",
+			"seq_correlation", "time_regressions", "workers", "lock_waits", "deadlocks", "mixed_ops_per_sec", "mixed_p50_ms", "mixed_p95_ms",
+			"mixed_p99_ms", "page_splits", "page_merges", "bp_pages_data_delta", "bp_pages_dirty_delta", "vacuum_sec",
+			"dead_tuples", "data_bytes", "index_bytes", "server", "error",
+		}
+		rt := reflect.TypeFor[Result]()
+		var got []string
+		for i := range rt.NumField() {
+			name, _, _ := strings.Cut(rt.Field(i).Tag.Get("json"), ",")
+			got = append(got, name)
+		}
+		if !slices.Equal(got, want) || OutputSchemaVersion != 9 {
+			t.Fatalf("json keys changed (schema_version %d):\n got %v\nwant %v", OutputSchemaVersion, got, want)
+		}
+	})
+}
+
+func TestJSONLWriter(t *testing.T) {
+	t.Run("JSONL_1行ずつ単独で読み戻せる", func(t *testing.T) {
+		var buf strings.Builder
+		w := NewJSONLWriter(&buf)
+		for _, r := range outputTestResults {
+			w.Write(r)
+		}
+		if err := w.Err(); err != nil {
+			t.Fatal(err)
+		}
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		if len(lines) != len(outputTestResults) {
+			t.Fatalf("lines = %d, want %d:\n%s", len(lines), len(outputTestResults), buf.String())
+		}
+		for i, line := range lines {
+			var r Result
+			if err := json.Unmarshal([]byte(line), &r); err != nil {
+				t.Fatalf("line %d is not standalone JSON: %v", i, err)
+			}
+			if r.Table != outputTestResults[i].Table {
+				t.Fatalf("line %d table = %q, want %q", i, r.Table, outputTestResults[i].Table)
+			}
+		}
+	})
+
+	t.Run("JSONL_書き込みエラーを保持する", func(t *testing.T) {
+		w := NewJSONLWriter(failingWriter{})
+		w.Write(outputTestResults[0])
+		w.Write(outputTestResults[1])
+		if w.Err() == nil {
+			t.Fatal("expected write error")
+		}
+	})
+}
+
+// failingWriter は常に書き込みに失敗する io.Writer。
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, os.ErrClosed }
+
+func TestWriteResults(t *testing.T) {
+	md := Metadata{RunID: "r1"}
+	report := Report{Metadata: md, Results: outputTestResults}
+	write := func(t *testing.T, cfg Config) string {
+		t.Helper()
+		var buf strings.Builder
+		if err := WriteResults(&buf, cfg, report); err != nil {
+			t.Fatal(err)
+		}
+		return buf.String()
+	}
+
+	t.Run("出力先_csvはメタデータと結果の表を書く", func(t *testing.T) {
+		cfg := DefaultConfig()
+		want := FormatMetadata(md) + "\n" + FormatResultsPrecision(outputTestResults, cfg.Precision) + "\n"
+		if got := write(t, cfg); got != want {
+			t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+		}
+	})
+
+	t.Run("出力先_形式ごとの整形をそのまま書く", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Format = "markdown"
+		if got := write(t, cfg); got != FormatResultsMarkdown(outputTestResults, cfg.Precision) {
+			t.Fatalf("markdown = %q", got)
+		}
+		cfg.Format = "json"
+		var out JSONOutput
+		if err := json.Unmarshal([]byte(write(t, cfg)), &out); err != nil || out.Metadata["run_id"] != "r1" || len(out.Results) != 2 {
+			t.Fatalf("json = %+v, %v", out, err)
+		}
+		cfg.Format = "jsonl"
+		if got := write(t, cfg); got != "" {
+			t.Fatalf("jsonl = %q, want nothing (streamed during the run)", got)
+		}
+	})
+
+	t.Run("出力先_要約の表は空行で区切って続ける", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Scorecard = true
+		want := "\n\n" + FormatScorecard(Scorecard(outputTestResults))
+		if got := write(t, cfg); !strings.HasSuffix(got, want) {
+			t.Fatalf("got:\n%s\nwant suffix:\n%s", got, want)
+		}
+	})
+
+	t.Run("出力先_書き込みエラーを返す", func(t *testing.T) {
+		if err := WriteResults(failingWriter{}, DefaultConfig(), report); err == nil {
+			t.Fatal("expected write error")
+		}
+	})
+}
+
+func TestWriteAllFormats(t *testing.T) {
+	t.Run("全形式_接頭辞ごとに4ファイルを書く", func(t *testing.T) {
+		prefix := filepath.Join(t.TempDir(), "run1")
+		paths, err := WriteAllFormats(prefix, Metadata{}, outputTestResults, DefaultPrecision, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []string{prefix + ".csv", prefix + ".md", prefix + ".json", prefix + ".html"}
+		if strings.Join(paths, ",") != strings.Join(want, ",") {
+			t.Fatalf("paths = %v, want %v", paths, want)
+		}
+		for _, p := range paths {
+			b, err := os.ReadFile(p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(b) == 0 {
+				t.Fatalf("%s is empty", p)
+			}
+		}
+	})
+
+	t.Run("gzip_拡張子に.gzを付けて圧縮する", func(t *testing.T) {
+		prefix := filepath.Join(t.TempDir(), "run1")
+		paths, err := WriteAllFormats(prefix, Metadata{}, outputTestResults, DefaultPrecision, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(paths) != 4 || paths[0] != prefix+".csv.gz" {
+			t.Fatalf("paths = %v", paths)
+		}
+		f, err := os.Open(paths[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := io.ReadAll(zr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != FormatResultsCSV(outputTestResults, DefaultPrecision) {
+			t.Fatalf("csv = %q", b)
+		}
+	})
+}
@@ -0,0 +1,261 @@
+package bench
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxPartitions は -partitions の上限。PostgreSQL ではパーティションごとに子テーブルを作るため控えめにする。
+const maxPartitions = 1024
+
+// partitionStep は連番テーブルの 1 パーティションあたりの id の幅。
+// -rows 行を partitions 個へほぼ均等に分け、超えた分は最後のパーティションに入る。
+func partitionStep(rows, partitions int) int64 {
+	return int64((rows + partitions - 1) / partitions)
+}
+
+// partitionDDL は kind の bench_auto_part（id の RANGE パーティション）と
+// bench_uuid_part（UUID の HASH パーティション。MySQL は KEY パーティション）を作る文を返す。
+// PostgreSQL はパーティションを PARTITION OF で 1 つずつ作る。
+func partitionDDL(kind string, cfg Config, extra string) []string {
+	n, step := cfg.Partitions, partitionStep(cfg.Rows, cfg.Partitions)
+	if kind == "postgres" {
+		stmts := []string{fmt.Sprintf(`CREATE TABLE bench_auto_part (
+			id BIGSERIAL,
+			payload TEXT NOT NULL%s,
+			PRIMARY KEY (id)
+		) PARTITION BY RANGE (id)`, extra)}
+		for k := range n {
+			from, to := fmt.Sprint(int64(k)*step+1), fmt.Sprint(int64(k+1)*step+1)
+			if k == 0 {
+				from = "MINVALUE"
+			}
+			if k == n-1 {
+				to = "MAXVALUE"
+			}
+			stmts = append(stmts, fmt.Sprintf("CREATE TABLE bench_auto_part_p%d PARTITION OF bench_auto_part FOR VALUES FROM (%s) TO (%s)", k, from, to))
+		}
+		stmts = append(stmts, fmt.Sprintf(`CREATE TABLE bench_uuid_part (
+			id UUID,
+			payload TEXT NOT NULL%s,
+			PRIMARY KEY (id)
+		) PARTITION BY HASH (id)`, extra))
+		for k := range n {
+			stmts = append(stmts, fmt.Sprintf("CREATE TABLE bench_uuid_part_p%d PARTITION OF bench_uuid_part FOR VALUES WITH (MODULUS %d, REMAINDER %d)", k, n, k))
+		}
+		return stmts
+	}
+	parts := make([]string, n)
+	for k := range parts {
+		bound := fmt.Sprint(int64(k+1)*step + 1)
+		if k == n-1 {
+			bound = "MAXVALUE"
+		}
+		parts[k] = fmt.Sprintf("PARTITION p%d VALUES LESS THAN (%s)", k, bound)
+	}
+	return []string{
+		fmt.Sprintf(`CREATE TABLE bench_auto_part (
+			id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+			payload VARCHAR(100) NOT NULL%s
+		) ENGINE=InnoDB PARTITION BY RANGE (id) (%s)`, extra, strings.Join(parts, ", ")),
+		fmt.Sprintf(`CREATE TABLE bench_uuid_part (
+			id BINARY(16) NOT NULL PRIMARY KEY,
+			payload VARCHAR(100) NOT NULL%s
+		) ENGINE=InnoDB PARTITION BY KEY (id) PARTITIONS %d`, extra, n),
+	}
+}
+
+// benchAutoPartitioned は id で RANGE パーティション分割した連番主キー (bench_auto_part) を計測する。
+// 範囲検索は 2 番目のパーティションの id 範囲ちょうどを COUNT し、1 パーティションだけを読む（プルーニング）。
+func benchAutoPartitioned(ctx context.Context, db *sql.DB, cfg Config, kind string) (Result, error) {
+	const table = "bench_auto_part"
+	log := slog.With("db", kind, "table", table)
+	gen := payloadFor(cfg)
+	insertStmt, err := prepare(ctx, db, cfg, insertSQL(kind, table, []string{"payload"}, gen.Columns()))
+	if err != nil {
+		return Result{}, err
+	}
+	defer insertStmt.Close()
+
+	inserted, insertSec, err := insertLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		_, err := insertStmt.ExecContext(ctx, insertArgs(gen, i)...)
+		return err
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	if err := analyzeTable(ctx, db, cfg, log, kind, table); err != nil {
+		return Result{}, err
+	}
+
+	ids, err := selectKeys[int64](ctx, db, table)
+	if err != nil {
+		return Result{}, err
+	}
+	sample := ids
+	if len(sample) > cfg.Lookups {
+		sample = sample[:cfg.Lookups]
+	}
+	selectStmt, err := prepare(ctx, db, cfg, "SELECT payload FROM "+table+" WHERE id = "+placeholders(kind, 1))
+	if err != nil {
+		return Result{}, err
+	}
+	defer selectStmt.Close()
+
+	// Point Lookup 計測: id の等値検索は該当する 1 パーティションだけを探す。
+	point, err := pointLoop(ctx, cfg, log, len(sample), func(ctx context.Context, i int) error {
+		var payload sql.NullString
+		return selectStmt.QueryRowContext(ctx, sample[i]).Scan(&payload)
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	step := partitionStep(cfg.Rows, cfg.Partitions)
+	lo, hi := step+1, 2*step
+	rangeSQL := "SELECT COUNT(*) FROM " + table + " WHERE id BETWEEN ? AND ?"
+	if kind == "postgres" {
+		rangeSQL = "SELECT COUNT(*) FROM " + table + " WHERE id BETWEEN $1 AND $2"
+	}
+	log.Debug("range scan start", "lo", lo, "hi", hi)
+	_, endRange := startSpan(ctx, "range")
+	rctx, cancel := queryContext(ctx, cfg)
+	defer cancel()
+	start := time.Now()
+	var c int64
+	if err := db.QueryRowContext(rctx, rangeSQL, lo, hi).Scan(&c); err != nil {
+		return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
+	}
+	rangeSec := time.Since(start).Seconds()
+	log.Info("range scan done", "sec", rangeSec, "rows", c)
+	endRange(nil)
+	rangeUsedIndex, err := explainRange(ctx, db, cfg, log, kind, rangeSQL, lo, hi)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		DB:                 kind,
+		Table:              table,
+		InsertRows:         inserted,
+		InsertSeconds:      insertSec,
+		PointLookupCount:   len(sample),
+		PointSeconds:       point.Seconds,
+		PointRounds:        point.Rounds,
+		PointWarmSeconds:   point.Warm,
+		PointSteadySeconds: point.Steady,
+		RangeSeconds:       rangeSec,
+		RangeUsedIndex:     rangeUsedIndex,
+	}, nil
+}
+
+// benchUUIDPartitioned は UUID のハッシュでパーティション分割した UUID 主キー (bench_uuid_part) を計測する。
+// MySQL は BINARY(16)、PostgreSQL は UUID 型で保存する。
+// 範囲検索は他の UUID 方式と同じ ORDER BY + LIMIT で、ハッシュ分割では全パーティションを読んで併合する。
+func benchUUIDPartitioned(ctx context.Context, db *sql.DB, cfg Config, kind string) (Result, error) {
+	const table = "bench_uuid_part"
+	log := slog.With("db", kind, "table", table)
+	key := func(i int) any {
+		if kind == "mysql" {
+			return uuidBinKey(cfg, i)
+		}
+		return newUUID(cfg, i)
+	}
+	gen := payloadFor(cfg)
+	insertStmt, err := prepare(ctx, db, cfg, insertSQL(kind, table, []string{"id", "payload"}, gen.Columns()))
+	if err != nil {
+		return Result{}, err
+	}
+	defer insertStmt.Close()
+
+	ids := make([]any, 0, cfg.Rows)
+	inserted, insertSec, err := insertLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		id := key(i)
+		ids = append(ids, id)
+		_, err := insertStmt.ExecContext(ctx, insertArgs(gen, i, id)...)
+		return err
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	if err := analyzeTable(ctx, db, cfg, log, kind, table); err != nil {
+		return Result{}, err
+	}
+
+	sample := ids
+	if len(sample) > cfg.Lookups {
+		sample = sample[:cfg.Lookups]
+	}
+	selectStmt, err := prepare(ctx, db, cfg, "SELECT payload FROM "+table+" WHERE id = "+placeholders(kind, 1))
+	if err != nil {
+		return Result{}, err
+	}
+	defer selectStmt.Close()
+
+	// Point Lookup 計測: UUID の等値検索はハッシュで 1 パーティションに絞られる。
+	point, err := pointLoop(ctx, cfg, log, len(sample), func(ctx context.Context, i int) error {
+		var payload sql.NullString
+		return selectStmt.QueryRowContext(ctx, sample[i]).Scan(&payload)
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	rangeSQL := "SELECT id FROM " + table + " ORDER BY id LIMIT 10000"
+	log.Debug("range scan start")
+	_, endRange := startSpan(ctx, "range")
+	var rangeBytes int64
+	rctx, cancel := queryContext(ctx, cfg)
+	defer cancel()
+	start := time.Now()
+	rowsRes, err := db.QueryContext(rctx, rangeSQL)
+	if err != nil {
+		return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
+	}
+	for rowsRes.Next() {
+		var b []byte
+		var u uuid.UUID
+		dest := any(&b)
+		if kind == "postgres" {
+			dest = &u
+		}
+		if err := rowsRes.Scan(dest); err != nil {
+			rowsRes.Close()
+			return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
+		}
+		if kind == "postgres" {
+			rangeBytes += valueBytes(u)
+		} else {
+			rangeBytes += valueBytes(b)
+		}
+	}
+	rowsRes.Close()
+	rangeSec := time.Since(start).Seconds()
+	log.Info("range scan done", "sec", rangeSec)
+	endRange(nil)
+	rangeUsedIndex, err := explainRange(ctx, db, cfg, log, kind, rangeSQL)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		DB:                 kind,
+		Table:              table,
+		InsertRows:         inserted,
+		InsertSeconds:      insertSec,
+		PointLookupCount:   len(sample),
+		PointSeconds:       point.Seconds,
+		PointRounds:        point.Rounds,
+		PointWarmSeconds:   point.Warm,
+		PointSteadySeconds: point.Steady,
+		RangeSeconds:       rangeSec,
+		RangeBytes:         rangeBytes,
+		RangeUsedIndex:     rangeUsedIndex,
+	}, nil
+}
@@ -0,0 +1,64 @@
+package bench
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPartitionStep(t *testing.T) {
+	t.Run("割り切れる", func(t *testing.T) {
+		if got := partitionStep(1000, 4); got != 250 {
+			t.Fatalf("step = %d, want 250", got)
+		}
+	})
+	t.Run("割り切れない分は切り上げる", func(t *testing.T) {
+		if got := partitionStep(10, 4); got != 3 {
+			t.Fatalf("step = %d, want 3", got)
+		}
+	})
+}
+
+func TestPartitionDDL(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Rows = 1000
+	cfg.Partitions = 4
+
+	t.Run("MySQL_RANGEとKEYで分割する", func(t *testing.T) {
+		stmts := partitionDDL("mysql", cfg, "")
+		if len(stmts) != 2 {
+			t.Fatalf("stmts = %d, want 2", len(stmts))
+		}
+		want := "PARTITION BY RANGE (id) (PARTITION p0 VALUES LESS THAN (251), PARTITION p1 VALUES LESS THAN (501), PARTITION p2 VALUES LESS THAN (751), PARTITION p3 VALUES LESS THAN (MAXVALUE))"
+		if !strings.HasSuffix(stmts[0], want) {
+			t.Fatalf("auto = %s", stmts[0])
+		}
+		if !strings.HasSuffix(stmts[1], "PARTITION BY KEY (id) PARTITIONS 4") || !strings.Contains(stmts[1], "id BINARY(16) NOT NULL PRIMARY KEY") {
+			t.Fatalf("uuid = %s", stmts[1])
+		}
+	})
+
+	t.Run("PostgreSQL_パーティションを個別に作る", func(t *testing.T) {
+		stmts := partitionDDL("postgres", cfg, "")
+		if len(stmts) != 10 {
+			t.Fatalf("stmts = %d, want 10", len(stmts))
+		}
+		if !strings.HasSuffix(stmts[0], "PARTITION BY RANGE (id)") {
+			t.Fatalf("auto = %s", stmts[0])
+		}
+		if stmts[1] != "CREATE TABLE bench_auto_part_p0 PARTITION OF bench_auto_part FOR VALUES FROM (MINVALUE) TO (251)" {
+			t.Fatalf("first = %s", stmts[1])
+		}
+		if stmts[2] != "CREATE TABLE bench_auto_part_p1 PARTITION OF bench_auto_part FOR VALUES FROM (251) TO (501)" {
+			t.Fatalf("second = %s", stmts[2])
+		}
+		if stmts[4] != "CREATE TABLE bench_auto_part_p3 PARTITION OF bench_auto_part FOR VALUES FROM (751) TO (MAXVALUE)" {
+			t.Fatalf("last = %s", stmts[4])
+		}
+		if !strings.HasSuffix(stmts[5], "PARTITION BY HASH (id)") {
+			t.Fatalf("uuid = %s", stmts[5])
+		}
+		if stmts[9] != "CREATE TABLE bench_uuid_part_p3 PARTITION OF bench_uuid_part FOR VALUES WITH (MODULUS 4, REMAINDER 3)" {
+			t.Fatalf("hash = %s", stmts[9])
+		}
+	})
+}
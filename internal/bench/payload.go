@@ -0,0 +1,111 @@
+package bench
+
+import (
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+)
+
+// PayloadGenerator は各方式の INSERT で主キーの後に入れる列、つまり payload 列と追加カラムの値を作る。
+// 同じ i には毎回同じ値を返し、実行間・方式間で同じ行が同じ内容になるようにする。
+// Config.Payload に設定すれば組み込みの生成器の代わりに使われ、Columns は CREATE TABLE にも反映される。
+type PayloadGenerator interface {
+	// Columns は payload 列の後に続ける追加カラムの定義。
+	Columns() []ColumnSpec
+	// Value は i 行目の値を payload 列、Columns() の順で返す。payload の nil は NULL として挿入する。
+	Value(i int) []any
+}
+
+// randomPayloadLen は -payload random の payload の長さ。payload 列 VARCHAR(100) いっぱいにする。
+const randomPayloadLen = 100
+
+// randomPayloadSeed は -payload random の文字を選ぶ乱数の既定シード。行番号と組み合わせて使う。
+const randomPayloadSeed = 20261016
+
+// randomPayloadChars は -payload random で使う文字。
+const randomPayloadChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// payloadTexts は -payload で選べる組み込みの payload の作り方。i 行目の文字列を返す。
+var payloadTexts = map[string]func(i int) string{
+	// seq は "p-<行番号>" の短い文字列。行幅は小さく、圧縮もよく効く。
+	"seq": func(i int) string { return fmt.Sprintf("p-%d", i) },
+	// random は行番号から決まる 100 文字の英数字。行幅を列の上限まで広げ、圧縮の効かない中身にする。
+	"random": func(i int) string {
+		r := rand.New(rand.NewPCG(randomPayloadSeed, uint64(i)))
+		b := make([]byte, randomPayloadLen)
+		for j := range b {
+			b[j] = randomPayloadChars[r.IntN(len(randomPayloadChars))]
+		}
+		return string(b)
+	},
+}
+
+// PayloadKinds は -payload で選べる組み込みの生成方法を名前順で返す。
+func PayloadKinds() []string {
+	return slices.Sorted(maps.Keys(payloadTexts))
+}
+
+// builtinPayload はフラグから組み立てる PayloadGenerator。
+// nullFraction が正なら、行番号から決まるおよそその割合の行で payload を nil にする。
+type builtinPayload struct {
+	text         func(i int) string
+	nullFraction float64
+	cols         []ColumnSpec
+}
+
+func (p builtinPayload) Columns() []ColumnSpec { return p.cols }
+
+func (p builtinPayload) Value(i int) []any {
+	vals := make([]any, 0, 1+len(p.cols))
+	vals = append(vals, p.payload(i))
+	for _, c := range p.cols {
+		vals = append(vals, c.Value(i))
+	}
+	return vals
+}
+
+// payload は i 行目の payload 列の値。NULL の行では nil。
+func (p builtinPayload) payload(i int) any {
+	if p.nullFraction > 0 && payloadIsNull(i, p.nullFraction) {
+		return nil
+	}
+	return p.text(i)
+}
+
+// NewPayloadGenerator は -payload、-payload-nullable / -payload-null-fraction、-columns の指定から
+// 組み込みの PayloadGenerator を作る。
+func NewPayloadGenerator(cfg Config) (PayloadGenerator, error) {
+	text, ok := payloadTexts[cfg.PayloadKind]
+	if !ok {
+		return nil, fmt.Errorf("payload %q is unknown (want one of %v)", cfg.PayloadKind, PayloadKinds())
+	}
+	p := builtinPayload{text: text, cols: cfg.ExtraColumns}
+	if cfg.PayloadNullable {
+		p.nullFraction = cfg.PayloadNullFraction
+	}
+	return p, nil
+}
+
+// payloadFor は cfg.Payload があればそれを、なければフラグから作った組み込みの生成器を返す。
+// 生成方法の名前は ValidateConfig で確かめてあるため、未知なら既定の seq を使う。
+func payloadFor(cfg Config) PayloadGenerator {
+	if cfg.Payload != nil {
+		return cfg.Payload
+	}
+	p, err := NewPayloadGenerator(cfg)
+	if err != nil {
+		cfg.PayloadKind = "seq"
+		p, _ = NewPayloadGenerator(cfg)
+	}
+	return p
+}
+
+// payloadValue は gen が作る i 行目の payload 列の値を返す。NULL の行では nil。
+// 組み込みの生成器では追加カラムの値を作らずに payload だけを求める。
+func payloadValue(gen PayloadGenerator, i int) any {
+	if p, ok := gen.(builtinPayload); ok {
+		return p.payload(i)
+	}
+	return gen.Value(i)[0]
+}
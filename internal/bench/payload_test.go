@@ -0,0 +1,91 @@
+package bench
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// fixedPayload は Config.Payload へ差し込む独自の PayloadGenerator のテスト用実装。
+type fixedPayload struct{}
+
+func (fixedPayload) Columns() []ColumnSpec { return []ColumnSpec{{Name: "note", Type: "int"}} }
+func (fixedPayload) Value(i int) []any     { return []any{"fixed", int32(i * 10)} }
+
+func TestPayloadGenerator(t *testing.T) {
+	t.Run("生成器_seqは行番号の短い文字列と追加カラム", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.ExtraColumns = []ColumnSpec{{Name: "n", Type: "bigint"}}
+		p, err := NewPayloadGenerator(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := p.Value(3); !slices.Equal(got, []any{"p-3", int64(3)}) {
+			t.Fatalf("Value(3) = %v", got)
+		}
+		if got := p.Columns(); len(got) != 1 || got[0].Name != "n" {
+			t.Fatalf("Columns = %v", got)
+		}
+	})
+
+	t.Run("生成器_randomは100文字で行ごとに決まる", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.PayloadKind = "random"
+		p, err := NewPayloadGenerator(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		a, b := p.Value(1)[0].(string), p.Value(2)[0].(string)
+		if len(a) != randomPayloadLen || strings.Trim(a, randomPayloadChars) != "" {
+			t.Fatalf("Value(1) = %q, want %d alphanumeric chars", a, randomPayloadLen)
+		}
+		if a == b || p.Value(1)[0] != a {
+			t.Fatalf("random payload must differ per row and repeat per row: %q, %q", a, b)
+		}
+	})
+
+	t.Run("生成器_nullableはpayloadだけをNULLにする", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.PayloadKind = "random"
+		cfg.PayloadNullable = true
+		cfg.PayloadNullFraction = 1
+		cfg.ExtraColumns = []ColumnSpec{{Name: "flag", Type: "bool"}}
+		if got := payloadFor(cfg).Value(4); got[0] != nil || got[1] != true {
+			t.Fatalf("Value(4) = %v, want [nil true]", got)
+		}
+	})
+
+	t.Run("生成器_未知の名前はエラー", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.PayloadKind = "lorem"
+		if _, err := NewPayloadGenerator(cfg); err == nil {
+			t.Fatal("NewPayloadGenerator error = nil, want error")
+		}
+		if err := ValidateConfig(cfg); err == nil {
+			t.Fatal("ValidateConfig error = nil, want error")
+		}
+	})
+
+	t.Run("生成器_Config.Payloadが挿入値とDDLに使われる", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Payload = fixedPayload{}
+		if got := insertArgs(payloadFor(cfg), 2, "key"); !slices.Equal(got, []any{"key", "fixed", int32(20)}) {
+			t.Fatalf("insertArgs = %v", got)
+		}
+		if got := payloadValue(payloadFor(cfg), 2); got != "fixed" {
+			t.Fatalf("payloadValue = %v, want fixed", got)
+		}
+		if ddl := strings.Join(mysqlSetupStmts(cfg), "\n"); !strings.Contains(ddl, "note INT NOT NULL") {
+			t.Fatalf("mysql DDL has no note column:\n%s", ddl)
+		}
+	})
+
+	t.Run("生成器_一括投入はseq以外と併用できない", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.PrepopulateFast = true
+		cfg.PayloadKind = "random"
+		if err := ValidateConfig(cfg); err == nil {
+			t.Fatal("ValidateConfig error = nil, want error")
+		}
+	})
+}
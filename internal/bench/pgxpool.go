@@ -0,0 +1,235 @@
+package bench
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// pgxpool 経路の点検索 SQL。主キー列 id を $1 で引く。
+const (
+	pgxAutoPointSQL = "SELECT payload FROM bench_auto_pgx WHERE id = $1"
+	pgxUUIDPointSQL = "SELECT payload FROM bench_uuid_pgx WHERE id = $1"
+)
+
+// RunPGXPool は database/sql を介さず pgxpool で PostgreSQL を計測する。
+// Insert は PGPipelineBatch 件ずつ pgx.Batch にまとめてパイプライン送信し、
+// database/sql 経由の 1 行 1 往復との差を見る。
+func RunPGXPool(ctx context.Context, pool *pgxpool.Pool, cfg Config) ([]Result, error) {
+	for _, stmt := range pgxSetupStmts(cfg) {
+		if _, err := pool.Exec(ctx, stmt); err != nil {
+			return nil, fmt.Errorf("pgxpool setup failed: %w", err)
+		}
+	}
+
+	results := make([]Result, 0, 2)
+	r, err := benchPGXAuto(ctx, pool, cfg)
+	if err != nil {
+		return nil, err
+	}
+	results = append(results, r)
+	r, err = benchPGXUUID(ctx, pool, cfg)
+	if err != nil {
+		return nil, err
+	}
+	results = append(results, r)
+	return results, nil
+}
+
+// pgxSetupStmts は pgxpool 経路のテーブルを作り直す文の一覧を返す。
+// -pg-unlogged・-pg-autovacuum・-payload-nullable は database/sql 経路の表と同じく反映する。
+func pgxSetupStmts(cfg Config) []string {
+	extra := columnsDDL("postgres", payloadFor(cfg).Columns())
+	stmts := []string{
+		"DROP TABLE IF EXISTS bench_auto_pgx",
+		"DROP TABLE IF EXISTS bench_uuid_pgx",
+		fmt.Sprintf(`CREATE TABLE bench_auto_pgx (
+			id BIGSERIAL PRIMARY KEY,
+			payload TEXT NOT NULL%s
+		)`, extra),
+		fmt.Sprintf(`CREATE TABLE bench_uuid_pgx (
+			id UUID PRIMARY KEY,
+			payload TEXT NOT NULL%s
+		)`, extra),
+	}
+	if cfg.PGUnlogged {
+		stmts = withUnlogged(stmts)
+	}
+	// database/sql の表と同じ状態で比べられるよう、autovacuum_enabled も合わせる。
+	if cfg.PGAutovacuum != "" {
+		stmts = withAutovacuum(stmts, cfg.PGAutovacuum)
+	}
+	if cfg.PayloadNullable {
+		stmts = withNullablePayload(stmts)
+	}
+	return stmts
+}
+
+// pipelineInsertLoop は queue(b, i) で 1 行ぶんをバッチへ積み、
+// PGPipelineBatch 件ごとに送信して挿入件数と所要秒数を返す。
+// cfg.InsertDuration が正ならバッチ単位で経過時間を見て打ち切る。
+func pipelineInsertLoop(ctx context.Context, pool *pgxpool.Pool, cfg Config, log *slog.Logger, queue func(b *pgx.Batch, i int)) (int, float64, error) {
+	log.Debug("insert start", "rows", cfg.Rows, "duration", cfg.InsertDuration, "batch", cfg.PGPipelineBatch)
+	start := time.Now()
+	n := 0
+	for cfg.InsertDuration > 0 || n < cfg.Rows {
+		if cfg.InsertDuration > 0 && time.Since(start) >= cfg.InsertDuration {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return n, time.Since(start).Seconds(), err
+		}
+		end := n + cfg.PGPipelineBatch
+		if cfg.InsertDuration == 0 && end > cfg.Rows {
+			end = cfg.Rows
+		}
+		b := &pgx.Batch{}
+		for i := n; i < end; i++ {
+			queue(b, i)
+		}
+		// Close は全結果を読み切り、最初のエラーを返す。-query-timeout はバッチ 1 回ぶんに掛ける。
+		bctx, cancel := queryContext(ctx, cfg)
+		err := pool.SendBatch(bctx, b).Close()
+		cancel()
+		if err != nil {
+			return n, time.Since(start).Seconds(), queryTimeoutError(ctx, bctx, cfg, err)
+		}
+		n = end
+		log.Debug("insert progress", "rows", n, "elapsed", time.Since(start))
+	}
+	sec := time.Since(start).Seconds()
+	log.Info("insert done", "rows", n, "sec", sec)
+	return n, sec, nil
+}
+
+// benchPGXAuto は pgxpool 経由で BIGSERIAL 主キーを計測する。
+func benchPGXAuto(ctx context.Context, pool *pgxpool.Pool, cfg Config) (Result, error) {
+	log := slog.With("db", "postgres", "table", "bench_auto_pgx")
+	gen := payloadFor(cfg)
+	query := insertSQL("postgres", "bench_auto_pgx", []string{"payload"}, gen.Columns())
+	inserted, insertSec, err := pipelineInsertLoop(ctx, pool, cfg, log, func(b *pgx.Batch, i int) {
+		b.Queue(query, insertArgs(gen, i)...)
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	// 参照用 ID 一覧を主キー順で収集する。
+	rows, err := pool.Query(ctx, "SELECT id FROM bench_auto_pgx ORDER BY id")
+	if err != nil {
+		return Result{}, err
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return Result{}, err
+	}
+
+	sample := ids
+	if len(sample) > cfg.Lookups {
+		sample = sample[:cfg.Lookups]
+	}
+	point, err := pointLoop(ctx, cfg, log, len(sample), func(ctx context.Context, i int) error {
+		var payload pgtype.Text
+		return pool.QueryRow(ctx, pgxAutoPointSQL, sample[i]).Scan(&payload)
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	lo, hi := int64(0), int64(0)
+	if len(ids) > 0 {
+		lo = ids[len(ids)/4]
+		hi = ids[(len(ids)*3)/4]
+	}
+	log.Debug("range scan start")
+	rctx, cancel := queryContext(ctx, cfg)
+	defer cancel()
+	start := time.Now()
+	var c int64
+	if err := pool.QueryRow(rctx, "SELECT COUNT(*) FROM bench_auto_pgx WHERE id BETWEEN $1 AND $2", lo, hi).Scan(&c); err != nil {
+		return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
+	}
+	rangeSec := time.Since(start).Seconds()
+	log.Info("range scan done", "sec", rangeSec)
+
+	return Result{
+		DB:                 "postgres",
+		Table:              "bench_auto_pgx",
+		InsertRows:         inserted,
+		InsertSeconds:      insertSec,
+		PointLookupCount:   len(sample),
+		PointSeconds:       point.Seconds,
+		PointRounds:        point.Rounds,
+		PointWarmSeconds:   point.Warm,
+		PointSteadySeconds: point.Steady,
+		RangeSeconds:       rangeSec,
+	}, nil
+}
+
+// benchPGXUUID は pgxpool 経由で UUID 主キーを計測する。
+func benchPGXUUID(ctx context.Context, pool *pgxpool.Pool, cfg Config) (Result, error) {
+	log := slog.With("db", "postgres", "table", "bench_uuid_pgx")
+	gen := payloadFor(cfg)
+	query := insertSQL("postgres", "bench_uuid_pgx", []string{"id", "payload"}, gen.Columns())
+	ids := make([]uuid.UUID, 0, cfg.Rows)
+	inserted, insertSec, err := pipelineInsertLoop(ctx, pool, cfg, log, func(b *pgx.Batch, i int) {
+		id := newUUID(cfg, i)
+		ids = append(ids, id)
+		b.Queue(query, insertArgs(gen, i, id)...)
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	sample := ids[:inserted]
+	if len(sample) > cfg.Lookups {
+		sample = sample[:cfg.Lookups]
+	}
+	point, err := pointLoop(ctx, cfg, log, len(sample), func(ctx context.Context, i int) error {
+		var payload pgtype.Text
+		return pool.QueryRow(ctx, pgxUUIDPointSQL, sample[i]).Scan(&payload)
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	// 範囲代替として ORDER BY + LIMIT の読み出し時間を計測する。
+	log.Debug("range scan start")
+	rctx, cancel := queryContext(ctx, cfg)
+	defer cancel()
+	start := time.Now()
+	rows, err := pool.Query(rctx, "SELECT id FROM bench_uuid_pgx ORDER BY id LIMIT 10000")
+	if err != nil {
+		return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
+	}
+	scanned, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
+	}
+	var rangeBytes int64
+	for _, id := range scanned {
+		rangeBytes += valueBytes(id)
+	}
+	rangeSec := time.Since(start).Seconds()
+	log.Info("range scan done", "sec", rangeSec)
+
+	return Result{
+		DB:                 "postgres",
+		Table:              "bench_uuid_pgx",
+		InsertRows:         inserted,
+		InsertSeconds:      insertSec,
+		PointLookupCount:   len(sample),
+		PointSeconds:       point.Seconds,
+		PointRounds:        point.Rounds,
+		PointWarmSeconds:   point.Warm,
+		PointSteadySeconds: point.Steady,
+		RangeSeconds:       rangeSec,
+		RangeBytes:         rangeBytes,
+	}, nil
+}
@@ -0,0 +1,29 @@
+package bench
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestPGXSetupStmts(t *testing.T) {
+	t.Run("pgxpoolのテーブル_autovacuumをdatabase/sqlの表と合わせる", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.PGAutovacuum = "off"
+		cfg.PGUnlogged = true
+		stmts := pgxSetupStmts(cfg)
+		for _, table := range []string{"bench_auto_pgx", "bench_uuid_pgx"} {
+			if !slices.Contains(stmts, "ALTER TABLE "+table+" SET (autovacuum_enabled = off)") {
+				t.Fatalf("stmts = %q, want autovacuum_enabled = off for %s", stmts, table)
+			}
+		}
+	})
+
+	t.Run("pgxpoolのテーブル_既定ではautovacuumを変えない", func(t *testing.T) {
+		for _, stmt := range pgxSetupStmts(DefaultConfig()) {
+			if strings.Contains(stmt, "autovacuum_enabled") {
+				t.Fatalf("stmt = %q, want no autovacuum_enabled", stmt)
+			}
+		}
+	})
+}
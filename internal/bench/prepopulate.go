@@ -0,0 +1,292 @@
+package bench
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// prepopulateSeed は SpreadSample の既定シード。実行間で同じ検索順になるよう固定する。
+const prepopulateSeed = 20260405
+
+// mysqlHexUUID は RANDOM_BYTES(16) を 8-4-4-4-12 形式の 36 文字へ整える式。
+// バージョンビットは立てないが、並びのランダムさは UUIDv4 と同じになる。
+const mysqlHexUUID = "LOWER(INSERT(INSERT(INSERT(INSERT(HEX(RANDOM_BYTES(16)), 9, 0, '-'), 14, 0, '-'), 19, 0, '-'), 24, 0, '-'))"
+
+// prepopulateMinMySQLVersion は mysqlPrepopulateSQL の WITH RECURSIVE と cte_max_recursion_depth に必要な MySQL のバージョン。
+const prepopulateMinMySQLVersion = "8.0"
+
+// mysqlPrepopulateSQL は MySQL 8 の再帰 CTE で 0..rows-1 を生成し、1 文で table を埋める INSERT を返す。
+// 再帰の深さが rows になるため、呼び出し側で cte_max_recursion_depth を引き上げておく。
+func mysqlPrepopulateSQL(table string, rows int) string {
+	cols, exprs := "payload", "CONCAT('p-', n)"
+	switch table {
+	case "bench_uuid_char":
+		cols, exprs = "id, payload", mysqlHexUUID+", CONCAT('p-', n)"
+	case "bench_uuid_bin":
+		cols, exprs = "id, payload", "RANDOM_BYTES(16), CONCAT('p-', n)"
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) WITH RECURSIVE seq (n) AS (SELECT 0 UNION ALL SELECT n + 1 FROM seq WHERE n + 1 < %d) SELECT %s FROM seq", table, cols, rows, exprs)
+}
+
+// pgPrepopulateSQL は generate_series で table を 1 文で埋める INSERT を返す。
+// gen_random_uuid は PostgreSQL 13 以降の組み込み関数。
+func pgPrepopulateSQL(table string, rows int) string {
+	if table == "bench_uuid" {
+		return fmt.Sprintf("INSERT INTO %s (id, payload) SELECT gen_random_uuid(), 'p-' || n FROM generate_series(0, %d) AS n", table, rows-1)
+	}
+	return fmt.Sprintf("INSERT INTO %s (payload) SELECT 'p-' || n FROM generate_series(0, %d) AS n", table, rows-1)
+}
+
+// largeInsertKey は -large-insert で table へ追加する行の主キーを作る関数を返す。
+// サーバが採番する連番のテーブルでは nil（id 列を渡さない）。
+func largeInsertKey(kind, table string) func() any {
+	switch {
+	case table == "bench_uuid_char":
+		return func() any { return uuid.New().String() }
+	case table == "bench_uuid_bin":
+		return func() any { return UUIDToBytes(uuid.New()) }
+	case kind == "postgres" && table == "bench_uuid":
+		return func() any { return uuid.New() }
+	}
+	return nil
+}
+
+// largeInsert は cfg.LargeInsertRows が正なら、cfg.Rows 行まで埋めた table へさらに cfg.LargeInsertRows 行を
+// 通常の Insert 計測と同じく 1 行ずつ挿入し、挿入できた行数と秒数を返す。
+// 空の表から作る計測では薄まる「すでに大きい表へ 1 行足すコスト」を切り出すためのもの。0 なら何もしない。
+func largeInsert(ctx context.Context, db queryer, cfg Config, log *slog.Logger, kind, table string) (int, float64, error) {
+	if cfg.LargeInsertRows <= 0 {
+		return 0, 0, nil
+	}
+	key := largeInsertKey(kind, table)
+	cols := []string{"payload"}
+	if key != nil {
+		cols = []string{"id", "payload"}
+	}
+	insertStmt, err := prepare(ctx, db, cfg, insertSQL(kind, table, cols, nil))
+	if err != nil {
+		return 0, 0, err
+	}
+	defer insertStmt.Close()
+	lcfg := cfg
+	lcfg.Rows = cfg.LargeInsertRows
+	gen := payloadFor(cfg)
+	return insertLoop(ctx, lcfg, log.With("phase", "large_insert"), func(ctx context.Context, i int) error {
+		// payload の番号は埋めた行の続きにする。
+		args := []any{payloadValue(gen, cfg.Rows+i)}
+		if key != nil {
+			args = append([]any{key()}, args...)
+		}
+		_, err := insertStmt.ExecContext(ctx, args...)
+		return err
+	})
+}
+
+// SpreadSample は 0..n-1 から k 個の添字を等間隔に選び、シード固定で並べ替えて返す。
+// 主キー順に読んだキー列から全域に散らばった検索対象を選ぶために使う。k >= n なら全件を返す。
+func SpreadSample(n, k int, seed uint64) []int {
+	if k > n {
+		k = n
+	}
+	idx := make([]int, k)
+	for i := range idx {
+		idx[i] = int(int64(i) * int64(n) / int64(k))
+	}
+	r := rand.New(rand.NewPCG(seed, seed))
+	r.Shuffle(len(idx), func(i, j int) { idx[i], idx[j] = idx[j], idx[i] })
+	return idx
+}
+
+// onSession は fn を 1 本の接続に固定して実行する。db が接続プールなら接続を 1 本取り出し、
+// トランザクションや取り出し済みの接続ならそのまま渡す。
+func onSession(ctx context.Context, db queryer, fn func(queryer) error) error {
+	pool, ok := db.(*sql.DB)
+	if !ok {
+		return fn(db)
+	}
+	conn, err := pool.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	return fn(conn)
+}
+
+// runMySQLPrepopulated は -prepopulate-fast 時の MySQL の計測。
+// 主要 3 方式のテーブルをサーバ側生成で埋め、読み取りフェーズだけを計測する。
+func runMySQLPrepopulated(ctx context.Context, db queryer, cfg Config, add func(...Result) error) error {
+	fill := func(table string) func(context.Context) error {
+		return func(ctx context.Context) error {
+			// cte_max_recursion_depth はセッション変数なので、同じ接続で続けて INSERT する。
+			return onSession(ctx, db, func(conn queryer) error {
+				if _, err := conn.ExecContext(ctx, fmt.Sprintf("SET SESSION cte_max_recursion_depth = %d", cfg.Rows+1)); err != nil {
+					return err
+				}
+				_, err := conn.ExecContext(ctx, mysqlPrepopulateSQL(table, cfg.Rows))
+				return err
+			})
+		}
+	}
+	steps := []struct {
+		table string
+		run   func() (Result, error)
+	}{
+		{"bench_auto", func() (Result, error) {
+			return benchPrepopulated[int64](ctx, db, cfg, "mysql", "bench_auto", fill("bench_auto"))
+		}},
+		{"bench_uuid_char", func() (Result, error) {
+			return benchPrepopulated[string](ctx, db, cfg, "mysql", "bench_uuid_char", fill("bench_uuid_char"))
+		}},
+		{"bench_uuid_bin", func() (Result, error) {
+			return benchPrepopulated[[]byte](ctx, db, cfg, "mysql", "bench_uuid_bin", fill("bench_uuid_bin"))
+		}},
+	}
+	for _, s := range steps {
+		if !strategySelected(cfg, s.table) {
+			continue
+		}
+		r, err := s.run()
+		if err != nil {
+			return err
+		}
+		if err := add(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runPGPrepopulated は -prepopulate-fast 時の PostgreSQL の計測。
+func runPGPrepopulated(ctx context.Context, db queryer, cfg Config, add func(...Result) error) error {
+	fill := func(table string) func(context.Context) error {
+		return func(ctx context.Context) error {
+			_, err := db.ExecContext(ctx, pgPrepopulateSQL(table, cfg.Rows))
+			return err
+		}
+	}
+	if strategySelected(cfg, "bench_auto") {
+		r, err := benchPrepopulated[int64](ctx, db, cfg, "postgres", "bench_auto", fill("bench_auto"))
+		if err != nil {
+			return err
+		}
+		if err := add(r); err != nil {
+			return err
+		}
+	}
+	if strategySelected(cfg, "bench_uuid") {
+		r, err := benchPrepopulated[uuid.UUID](ctx, db, cfg, "postgres", "bench_uuid", fill("bench_uuid"))
+		if err != nil {
+			return err
+		}
+		if err := add(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// benchPrepopulated は fill でサーバ側生成した table に対して読み取りフェーズだけを計測する。
+// 生成時間は prepopulate_sec として記録し、Insert 計測とは区別する。
+// -large-insert 指定時は読み取りの後で、埋めた表への追加の挿入も計測する。
+// 検索対象は主キー順に読んだ全キーから SpreadSample で選び、範囲検索は
+// その 25%〜75% 点のキーを上下限にした COUNT(*) で全方式共通に計測する。
+func benchPrepopulated[K any](ctx context.Context, db queryer, cfg Config, kind, table string, fill func(context.Context) error) (Result, error) {
+	log := slog.With("db", kind, "table", table)
+	log.Debug("prepopulate start", "rows", cfg.Rows)
+	start := time.Now()
+	if err := fill(ctx); err != nil {
+		return Result{}, fmt.Errorf("%s prepopulate %s failed: %w", kind, table, err)
+	}
+	fillSec := time.Since(start).Seconds()
+	log.Info("prepopulate done", "rows", cfg.Rows, "sec", fillSec)
+	if err := analyzeTable(ctx, db, cfg, log, kind, table); err != nil {
+		return Result{}, err
+	}
+
+	rows, err := db.QueryContext(ctx, "SELECT id FROM "+table+" ORDER BY id")
+	if err != nil {
+		return Result{}, err
+	}
+	keys := make([]K, 0, cfg.Rows)
+	for rows.Next() {
+		var k K
+		if err := rows.Scan(&k); err != nil {
+			rows.Close()
+			return Result{}, err
+		}
+		keys = append(keys, k)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return Result{}, err
+	}
+
+	p1, p2 := "?", "?"
+	if kind == "postgres" {
+		p1, p2 = "$1", "$2"
+	}
+	selectStmt, err := prepare(ctx, db, cfg, "SELECT payload FROM "+table+" WHERE id = "+p1)
+	if err != nil {
+		return Result{}, err
+	}
+	defer selectStmt.Close()
+
+	sample := SpreadSample(len(keys), cfg.Lookups, prepopulateSeed)
+	point, err := pointLoop(ctx, cfg, log, len(sample), func(ctx context.Context, i int) error {
+		var payload sql.NullString
+		return selectStmt.QueryRowContext(ctx, keys[sample[i]]).Scan(&payload)
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	var rangeSec float64
+	var rangeUsedIndex *bool
+	if len(keys) > 0 {
+		lo, hi := keys[len(keys)/4], keys[len(keys)*3/4]
+		log.Debug("range scan start")
+		_, endRange := startSpan(ctx, "range")
+		rctx, cancel := queryContext(ctx, cfg)
+		defer cancel()
+		start = time.Now()
+		var c int64
+		rangeSQL := "SELECT COUNT(*) FROM " + table + " WHERE id BETWEEN " + p1 + " AND " + p2
+		if err := db.QueryRowContext(rctx, rangeSQL, lo, hi).Scan(&c); err != nil {
+			return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
+		}
+		rangeSec = time.Since(start).Seconds()
+		log.Info("range scan done", "sec", rangeSec)
+		endRange(nil)
+		if rangeUsedIndex, err = explainRange(ctx, db, cfg, log, kind, rangeSQL, lo, hi); err != nil {
+			return Result{}, err
+		}
+	}
+
+	// 読み取りは埋めた直後の表で計り、追加の挿入はその後に行う。
+	largeRows, largeSec, err := largeInsert(ctx, db, cfg, log, kind, table)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		DB:                 kind,
+		Table:              table,
+		InsertRows:         len(keys),
+		PointLookupCount:   len(sample),
+		PointSeconds:       point.Seconds,
+		PointRounds:        point.Rounds,
+		PointWarmSeconds:   point.Warm,
+		PointSteadySeconds: point.Steady,
+		RangeSeconds:       rangeSec,
+		RangeUsedIndex:     rangeUsedIndex,
+		PrepopulateSeconds: fillSec,
+		LargeInsertRows:    largeRows,
+		LargeInsertSeconds: largeSec,
+	}, nil
+}
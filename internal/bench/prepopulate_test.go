@@ -0,0 +1,86 @@
+package bench
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestSpreadSample(t *testing.T) {
+	t.Run("検索対象_全域から等間隔に選ぶ", func(t *testing.T) {
+		got := SpreadSample(100, 4, prepopulateSeed)
+		slices.Sort(got)
+		if !slices.Equal(got, []int{0, 25, 50, 75}) {
+			t.Fatalf("SpreadSample = %v", got)
+		}
+	})
+
+	t.Run("検索対象_件数を超える指定は全件", func(t *testing.T) {
+		got := SpreadSample(3, 10, prepopulateSeed)
+		slices.Sort(got)
+		if !slices.Equal(got, []int{0, 1, 2}) {
+			t.Fatalf("SpreadSample = %v", got)
+		}
+	})
+
+	t.Run("検索対象_同じシードなら同じ順序", func(t *testing.T) {
+		if !slices.Equal(SpreadSample(1000, 50, 1), SpreadSample(1000, 50, 1)) {
+			t.Fatal("SpreadSample is not deterministic")
+		}
+	})
+}
+
+func TestPrepopulateSQL(t *testing.T) {
+	t.Run("事前投入_MySQLは再帰CTEで件数ぶん生成する", func(t *testing.T) {
+		got := mysqlPrepopulateSQL("bench_uuid_bin", 1000)
+		for _, want := range []string{"INSERT INTO bench_uuid_bin (id, payload)", "WITH RECURSIVE", "n + 1 < 1000", "RANDOM_BYTES(16)"} {
+			if !strings.Contains(got, want) {
+				t.Fatalf("sql %q does not contain %q", got, want)
+			}
+		}
+		if got := mysqlPrepopulateSQL("bench_auto", 10); !strings.HasPrefix(got, "INSERT INTO bench_auto (payload) ") {
+			t.Fatalf("sql = %q", got)
+		}
+	})
+
+	t.Run("事前投入_PostgreSQLはgenerate_seriesで生成する", func(t *testing.T) {
+		got := pgPrepopulateSQL("bench_uuid", 1000)
+		if !strings.Contains(got, "gen_random_uuid()") || !strings.Contains(got, "generate_series(0, 999)") {
+			t.Fatalf("sql = %q", got)
+		}
+	})
+}
+
+func TestLargeInsertKey(t *testing.T) {
+	t.Run("大きい表への追加_連番はidを渡さない", func(t *testing.T) {
+		if largeInsertKey("mysql", "bench_auto") != nil || largeInsertKey("postgres", "bench_auto") != nil {
+			t.Fatal("sequential tables must not get a client key")
+		}
+	})
+
+	t.Run("大きい表への追加_UUIDはテーブルの型で渡す", func(t *testing.T) {
+		if s, ok := largeInsertKey("mysql", "bench_uuid_char")().(string); !ok || len(s) != 36 {
+			t.Fatalf("char key = %v", s)
+		}
+		if b, ok := largeInsertKey("mysql", "bench_uuid_bin")().([]byte); !ok || len(b) != 16 {
+			t.Fatalf("bin key = %v", b)
+		}
+		if _, ok := largeInsertKey("postgres", "bench_uuid")().(uuid.UUID); !ok {
+			t.Fatal("postgres key must be uuid.UUID")
+		}
+	})
+
+	t.Run("大きい表への追加_prepopulate-fastが必要", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.LargeInsertRows = 1000
+		if err := ValidateConfig(cfg); err == nil {
+			t.Fatal("ValidateConfig error = nil, want error")
+		}
+		cfg.PrepopulateFast = true
+		if err := ValidateConfig(cfg); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
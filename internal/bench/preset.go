@@ -0,0 +1,98 @@
+package bench
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"slices"
+	"text/tabwriter"
+)
+
+// PresetMySQLUUIDRepresentations は MySQL の UUID 保存形式 3 種だけを比べるプリセット名。
+const PresetMySQLUUIDRepresentations = "mysql-uuid-representations"
+
+// presetRows はプリセットが -rows 未指定時に使う件数。形式間の差が出やすいよう既定より多くする。
+const presetRows = 500000
+
+// presets は -preset で選べる構成。explicit はコマンドラインで明示されたフラグ名で、
+// それらの値はプリセットで上書きしない。
+var presets = map[string]func(cfg *Config, explicit map[string]bool){
+	// CHAR(36) / BINARY(16) / BINARY(16) 並べ替え の 3 方式だけを MySQL で実行し、
+	// テーブルサイズも取得して保存形式の差を見る。
+	PresetMySQLUUIDRepresentations: func(cfg *Config, explicit map[string]bool) {
+		cfg.Strategies = []string{"bench_uuid_char", "bench_uuid_bin", "bench_uuid_bin_swapped"}
+		cfg.SwappedBinary = true
+		cfg.MySQLTableSizes = true
+		cfg.SkipPostgres = true
+		if !explicit["rows"] {
+			cfg.Rows = presetRows
+		}
+	},
+}
+
+// PresetNames は選べるプリセット名を名前順で返す。
+func PresetNames() []string {
+	return slices.Sorted(maps.Keys(presets))
+}
+
+// ApplyPreset は cfg.Preset が指定されていればその構成を cfg へ反映する。
+// explicit に含まれるフラグの値は利用者の指定を優先して残す。
+func ApplyPreset(cfg *Config, explicit map[string]bool) error {
+	if cfg.Preset == "" {
+		return nil
+	}
+	apply, ok := presets[cfg.Preset]
+	if !ok {
+		return fmt.Errorf("preset %q is unknown (want one of %v)", cfg.Preset, PresetNames())
+	}
+	apply(cfg, explicit)
+	return nil
+}
+
+// representationBaseline は保存形式比較で倍率の基準にするテーブル。
+const representationBaseline = "bench_uuid_bin"
+
+// FormatRepresentations は保存形式プリセットの結果を、BINARY(16) を基準にした
+// Insert 時間とテーブルサイズ（データ + インデックス）の倍率付きで整形する。
+// 基準の結果がない場合、倍率は空欄にする。
+func FormatRepresentations(results []Result, prec int) string {
+	var base *Result
+	for i := range results {
+		if results[i].DB == "mysql" && results[i].Table == representationBaseline {
+			base = &results[i]
+			break
+		}
+	}
+	ratio := func(v, b float64) string {
+		if base == nil || b == 0 {
+			return ""
+		}
+		return fmt.Sprintf("%.2fx", v/b)
+	}
+
+	var out bytes.Buffer
+	out.WriteString("=== MySQL UUID Representations ===\n")
+	w := tabwriter.NewWriter(&out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "table\tinsert_sec\tinsert_vs_bin\tpoint_sec\tdata_bytes\tindex_bytes\tsize_vs_bin")
+	for _, r := range results {
+		if r.DB != "mysql" {
+			continue
+		}
+		var baseInsert, baseSize float64
+		if base != nil {
+			baseInsert = base.InsertSeconds
+			baseSize = float64(base.DataBytes + base.IndexBytes)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%d\t%s\n",
+			r.Table,
+			formatFloat(r.InsertSeconds, prec),
+			ratio(r.InsertSeconds, baseInsert),
+			formatFloat(r.PointSeconds, prec),
+			r.DataBytes,
+			r.IndexBytes,
+			ratio(float64(r.DataBytes+r.IndexBytes), baseSize),
+		)
+	}
+	w.Flush()
+	return out.String()
+}
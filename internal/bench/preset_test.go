@@ -0,0 +1,80 @@
+package bench
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestApplyPreset(t *testing.T) {
+	t.Run("プリセット_未指定なら何も変えない", func(t *testing.T) {
+		cfg := DefaultConfig()
+		if err := ApplyPreset(&cfg, nil); err != nil {
+			t.Fatal(err)
+		}
+		if cfg.Strategies != nil || cfg.SkipPostgres || cfg.Rows != DefaultConfig().Rows {
+			t.Fatalf("cfg changed: %+v", cfg)
+		}
+	})
+
+	t.Run("プリセット_MySQLのUUID保存形式3種だけを選ぶ", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Preset = PresetMySQLUUIDRepresentations
+		if err := ApplyPreset(&cfg, map[string]bool{}); err != nil {
+			t.Fatal(err)
+		}
+		if !cfg.SkipPostgres || !cfg.SwappedBinary || !cfg.MySQLTableSizes || cfg.Rows != presetRows {
+			t.Fatalf("cfg = %+v", cfg)
+		}
+		if got := strings.Join(mysqlTables(cfg), ","); got != "bench_uuid_char,bench_uuid_bin,bench_uuid_bin_swapped" {
+			t.Fatalf("mysqlTables = %s", got)
+		}
+	})
+
+	t.Run("プリセット_明示したフラグは上書きしない", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Preset = PresetMySQLUUIDRepresentations
+		cfg.Rows = 1234
+		if err := ApplyPreset(&cfg, map[string]bool{"rows": true}); err != nil {
+			t.Fatal(err)
+		}
+		if cfg.Rows != 1234 {
+			t.Fatalf("rows = %d, want 1234", cfg.Rows)
+		}
+	})
+
+	t.Run("プリセット_未知の名前はエラー", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Preset = "nope"
+		if err := ApplyPreset(&cfg, nil); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func TestFormatRepresentations(t *testing.T) {
+	results := []Result{
+		{DB: "mysql", Table: "bench_uuid_char", InsertSeconds: 3, PointSeconds: 0.5, DataBytes: 3000, IndexBytes: 0},
+		{DB: "mysql", Table: "bench_uuid_bin", InsertSeconds: 2, PointSeconds: 0.4, DataBytes: 2000, IndexBytes: 0},
+	}
+
+	t.Run("保存形式比較_BINARY16基準の倍率を出す", func(t *testing.T) {
+		got := FormatRepresentations(results, 1)
+		lines := strings.Split(strings.TrimSpace(got), "\n")
+		if len(lines) != 4 {
+			t.Fatalf("lines = %d:\n%s", len(lines), got)
+		}
+		if f := strings.Fields(lines[2]); strings.Join(f, " ") != "bench_uuid_char 3.0 1.50x 0.5 3000 0 1.50x" {
+			t.Fatalf("char row = %q", lines[2])
+		}
+		if f := strings.Fields(lines[3]); f[2] != "1.00x" {
+			t.Fatalf("bin row = %q", lines[3])
+		}
+	})
+
+	t.Run("保存形式比較_基準がなければ倍率は空欄", func(t *testing.T) {
+		got := FormatRepresentations(results[:1], 1)
+		if strings.Contains(got, "x ") || strings.HasSuffix(strings.TrimSpace(got), "x") {
+			t.Fatalf("unexpected ratio:\n%s", got)
+		}
+	})
+}
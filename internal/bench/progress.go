@@ -0,0 +1,59 @@
+package bench
+
+import "context"
+
+// progressEvery は挿入・点検索の進捗を OnProgress へ渡す間隔（件数）。
+const progressEvery = 1000
+
+// Progress は計測中の方式のフェーズの進み具合。Total は予定件数で、
+// -insert-duration のように件数で終わらないフェーズでは 0。
+type Progress struct {
+	DB     string
+	Server string
+	Table  string
+	Phase  string
+	Done   int
+	Total  int
+}
+
+// progressKey は ctx に載せる progressSink のキー。
+type progressKey struct{}
+
+// progressSink は進捗の通知先と、通知に付ける DB・接続先・テーブル。
+type progressSink struct {
+	fn                func(Progress)
+	db, server, table string
+}
+
+// withProgress は fn が nil でなければ、kind / server の進捗を fn へ渡す ctx を返す。
+func withProgress(ctx context.Context, fn func(Progress), kind, server string) context.Context {
+	if fn == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, progressKey{}, progressSink{fn: fn, db: kind, server: server})
+}
+
+// withProgressTable は ctx の進捗の通知に table を付ける。通知先がなければ ctx をそのまま返す。
+func withProgressTable(ctx context.Context, table string) context.Context {
+	s, ok := ctx.Value(progressKey{}).(progressSink)
+	if !ok {
+		return ctx
+	}
+	s.table = table
+	return context.WithValue(ctx, progressKey{}, s)
+}
+
+// progressReporter は ctx に通知先があれば、phase の完了件数を渡す関数を返す。なければ nil。
+// 通知は progressEvery 件ごとと、最後（done == total）だけに間引く。
+func progressReporter(ctx context.Context, phase string, total int) func(done int) {
+	s, ok := ctx.Value(progressKey{}).(progressSink)
+	if !ok {
+		return nil
+	}
+	return func(done int) {
+		if done%progressEvery != 0 && done != total {
+			return
+		}
+		s.fn(Progress{DB: s.db, Server: s.server, Table: s.table, Phase: phase, Done: done, Total: total})
+	}
+}
@@ -0,0 +1,76 @@
+package bench
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strconv"
+	"time"
+)
+
+// rangeDeleteFraction は -range-delete で削除する行の割合。
+const rangeDeleteFraction = 0.25
+
+// rangeDeleteTextKeys は MySQL で id が文字列型の方式。境界値はドライバから []byte で返るため、
+// 文字列として渡し直し、列の照合順序のまま主キーの範囲で削除させる。
+var rangeDeleteTextKeys = map[string]bool{"bench_uuid_char": true, "bench_uuid_char32": true, "bench_uuid_b64": true}
+
+// rangeDeleteRows は rows 行のうち範囲削除で消す行数を返す。rows が 1 以上なら最低 1 行。
+func rangeDeleteRows(rows int) int {
+	if rows <= 0 {
+		return 0
+	}
+	return max(1, int(float64(rows)*rangeDeleteFraction))
+}
+
+// rangeDeleteTable は table が -range-delete の対象かを返す。組み込み方式のうち id 列を持つもの。
+func rangeDeleteTable(kind, table string) bool {
+	return builtinTable(kind, table) && table != "bench_natural"
+}
+
+// rangeDelete は cfg.RangeDelete が真なら、計測を終えた r のテーブルから id の小さい順に
+// 約 rangeDeleteFraction の行を WHERE id BETWEEN lo AND hi の 1 文で削除し、行数と秒数を r へ書き込む。
+// 連番では古い行が連続したページにまとまり、UUID では同じキー範囲の行が挿入順（PostgreSQL ではヒープ上）に散らばる。
+// 行を消すため、他のすべてのフェーズの後に呼ぶ。境界値の取得は計測に含めない。
+func rangeDelete(ctx context.Context, db *sql.DB, cfg Config, kind string, r *Result) error {
+	if !cfg.RangeDelete || !rangeDeleteTable(kind, r.Table) {
+		return nil
+	}
+	n := rangeDeleteRows(r.InsertRows)
+	if n == 0 {
+		return nil
+	}
+	log := slog.With("db", kind, "table", r.Table, "phase", "range_delete")
+	var lo, hi any
+	first := "SELECT id FROM " + r.Table + " ORDER BY id LIMIT 1"
+	if err := db.QueryRowContext(ctx, first).Scan(&lo); err != nil {
+		return err
+	}
+	if err := db.QueryRowContext(ctx, first+" OFFSET "+strconv.Itoa(n-1)).Scan(&hi); err != nil {
+		return err
+	}
+	if kind == "mysql" && rangeDeleteTextKeys[r.Table] {
+		lo, hi = string(lo.([]byte)), string(hi.([]byte))
+	}
+	query := "DELETE FROM " + r.Table + " WHERE id BETWEEN ? AND ?"
+	if kind == "postgres" {
+		query = "DELETE FROM " + r.Table + " WHERE id BETWEEN $1 AND $2"
+	}
+	_, endDelete := startSpan(ctx, "range_delete")
+	dctx, cancel := queryContext(ctx, cfg)
+	defer cancel()
+	start := time.Now()
+	res, err := db.ExecContext(dctx, query, lo, hi)
+	sec := time.Since(start).Seconds()
+	endDelete(err)
+	if err != nil {
+		return queryTimeoutError(ctx, dctx, cfg, err)
+	}
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	r.RangeDeleteRows, r.RangeDeleteSeconds = int(deleted), sec
+	log.Info("range delete done", "rows", deleted, "sec", sec)
+	return nil
+}
@@ -0,0 +1,33 @@
+package bench
+
+import "testing"
+
+func TestRangeDeleteRows(t *testing.T) {
+	tests := []struct {
+		name string
+		rows int
+		want int
+	}{
+		{"範囲削除_4分の1を消す", 1000, 250},
+		{"範囲削除_少なくとも1行", 3, 1},
+		{"範囲削除_空なら0", 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rangeDeleteRows(tt.rows); got != tt.want {
+				t.Fatalf("rangeDeleteRows(%d) = %d, want %d", tt.rows, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRangeDeleteTable(t *testing.T) {
+	t.Run("範囲削除_id列のある組み込み方式だけが対象", func(t *testing.T) {
+		if !rangeDeleteTable("mysql", "bench_uuid_bin") || !rangeDeleteTable("postgres", "bench_auto") {
+			t.Fatal("built-in id tables should be range-deleted")
+		}
+		if rangeDeleteTable("mysql", "bench_natural") || rangeDeleteTable("mysql", "custom_table") {
+			t.Fatal("bench_natural and unregistered tables should be skipped")
+		}
+	})
+}
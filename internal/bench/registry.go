@@ -0,0 +1,185 @@
+package bench
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"slices"
+)
+
+// Strategy は 1 つのテーブル（主キー方式）を計測する単位。
+// Name は結果の table 列・-strategies に指定する名前で、同じ DB の中で一意にする。
+// Setup は組み込みのスキーマ初期化の後に呼ばれ、自分のテーブルを作り直す（-no-setup では呼ばれない）。
+// Run は Setup 済みのテーブルを計測して 1 件の結果を返す。
+type Strategy interface {
+	Name() string
+	Setup(ctx context.Context, db *sql.DB) error
+	Run(ctx context.Context, db *sql.DB, cfg Config) (Result, error)
+}
+
+// VersionedStrategy は計測に必要なサーバの最小バージョンを持つ Strategy。
+// RegisterStrategy で登録する方式が実装していれば、-mysql-version-gate の判定に使う。
+type VersionedStrategy interface {
+	Strategy
+	MinVersion() string
+}
+
+// registeredStrategy はレジストリの 1 件。Enabled が nil なら常に計測し、
+// そうでなければ Enabled が真を返す設定のときだけ計測する（-uuid-comb などのオプション方式）。
+// MinVersion は必要なサーバの最小バージョン（"8.0" など）で、空なら制限しない。
+type registeredStrategy struct {
+	Strategy   Strategy
+	Enabled    func(Config) bool
+	MinVersion string
+}
+
+// enabled は cfg で s を計測するかどうかを返す。-strategies での絞り込みも含める。
+func (s registeredStrategy) enabled(cfg Config) bool {
+	return (s.Enabled == nil || s.Enabled(cfg)) && strategySelected(cfg, s.Strategy.Name())
+}
+
+// builtinStrategy は組み込み方式の Strategy。テーブルは setupMySQL / setupPostgres が
+// テーブルオプションなどを揃えてまとめて作るため、Setup では何もしない。
+type builtinStrategy struct {
+	name string
+	run  func(ctx context.Context, db *sql.DB, cfg Config) (Result, error)
+}
+
+func (s builtinStrategy) Name() string                                { return s.name }
+func (s builtinStrategy) Setup(ctx context.Context, db *sql.DB) error { return nil }
+func (s builtinStrategy) Run(ctx context.Context, db *sql.DB, cfg Config) (Result, error) {
+	return s.run(ctx, db, cfg)
+}
+
+// builtin は run で計測する組み込み方式を作る。
+func builtin(name string, run func(ctx context.Context, db *sql.DB, cfg Config) (Result, error)) Strategy {
+	return builtinStrategy{name: name, run: run}
+}
+
+// forKind は kind を固定して DB 共通の計測関数を呼ぶ関数を返す。
+func forKind(kind string, run func(ctx context.Context, db *sql.DB, cfg Config, kind string) (Result, error)) func(ctx context.Context, db *sql.DB, cfg Config) (Result, error) {
+	return func(ctx context.Context, db *sql.DB, cfg Config) (Result, error) {
+		return run(ctx, db, cfg, kind)
+	}
+}
+
+// strategyRegistry は DB 種別ごとに、単一テーブルの方式を計測順に並べたもの。
+// 複数テーブルをまとめて計測する外部キー・並列挿入・混合負荷は含めない。
+var strategyRegistry = map[string][]registeredStrategy{
+	"mysql": {
+		{Strategy: builtin("bench_auto", benchMySQLAuto)},
+		{Strategy: builtin("bench_uuid_char", benchMySQLUUIDChar)},
+		{Strategy: builtin("bench_uuid_char32", benchMySQLUUIDChar32), Enabled: func(cfg Config) bool { return cfg.UUIDChar32 }},
+		{Strategy: builtin("bench_uuid_bin", benchMySQLUUIDBin)},
+		{Strategy: builtin("bench_uuid_bin_swapped", benchMySQLUUIDBinSwapped), Enabled: func(cfg Config) bool { return cfg.SwappedBinary }},
+		{Strategy: builtin("bench_uuid_bin_native", benchMySQLUUIDNative), Enabled: func(cfg Config) bool { return cfg.NativeUUIDFunction }, MinVersion: nativeUUIDMinMySQLVersion},
+		{Strategy: builtin("bench_uuid_comb", benchMySQLUUIDComb), Enabled: func(cfg Config) bool { return cfg.UUIDComb }},
+		{Strategy: builtin("bench_uuid_v1", benchMySQLUUIDv1), Enabled: func(cfg Config) bool { return cfg.UUIDv1 }},
+		{Strategy: builtin("bench_uuid_v1_swapped", benchMySQLUUIDv1Swapped), Enabled: func(cfg Config) bool { return cfg.UUIDv1 }},
+		{Strategy: builtin("bench_uuid_tenant", benchMySQLUUIDTenant)},
+		{Strategy: builtin("bench_hybrid", benchMySQLHybrid)},
+		{Strategy: builtin("bench_uuid_seq", benchMySQLUUIDSeq), Enabled: func(cfg Config) bool { return cfg.SeqCorrelation }},
+		{Strategy: builtin("bench_uuid_rowid", benchMySQLUUIDRowID), Enabled: func(cfg Config) bool { return cfg.RowIDTable }},
+		{Strategy: builtin("bench_int_shuffled", benchMySQLIntShuffled), Enabled: func(cfg Config) bool { return cfg.ShuffleInsertOrder }},
+		{Strategy: builtin("bench_gapless", benchMySQLGapless), Enabled: func(cfg Config) bool { return cfg.Gapless }},
+		{Strategy: builtin("bench_natural", forKind("mysql", benchNatural)), Enabled: func(cfg Config) bool { return cfg.NaturalKey }},
+		{Strategy: builtin("bench_uuid_b64", forKind("mysql", benchUUIDBase64)), Enabled: func(cfg Config) bool { return cfg.UUIDBase64 }},
+		{Strategy: builtin("bench_auto_part", forKind("mysql", benchAutoPartitioned)), Enabled: func(cfg Config) bool { return cfg.Partitions > 0 }},
+		{Strategy: builtin("bench_uuid_part", forKind("mysql", benchUUIDPartitioned)), Enabled: func(cfg Config) bool { return cfg.Partitions > 0 }},
+	},
+	"postgres": {
+		{Strategy: builtin("bench_auto", benchPGAuto)},
+		{Strategy: builtin("bench_uuid", benchPGUUID)},
+		{Strategy: builtin("bench_uuid_comb", benchPGUUIDComb), Enabled: func(cfg Config) bool { return cfg.UUIDComb }},
+		{Strategy: builtin("bench_uuid_bytea", benchPGUUIDBytea), Enabled: func(cfg Config) bool { return cfg.PGUUIDBytea }},
+		{Strategy: builtin("bench_uuid_tenant", benchPGUUIDTenant)},
+		{Strategy: builtin("bench_hybrid", benchPGHybrid)},
+		{Strategy: builtin("bench_uuid_seq", benchPGUUIDSeq), Enabled: func(cfg Config) bool { return cfg.SeqCorrelation }},
+		{Strategy: builtin("bench_int_shuffled", benchPGIntShuffled), Enabled: func(cfg Config) bool { return cfg.ShuffleInsertOrder }},
+		{Strategy: builtin("bench_gapless", benchPGGapless), Enabled: func(cfg Config) bool { return cfg.Gapless }},
+		{Strategy: builtin("bench_natural", forKind("postgres", benchNatural)), Enabled: func(cfg Config) bool { return cfg.NaturalKey }},
+		{Strategy: builtin("bench_uuid_b64", forKind("postgres", benchUUIDBase64)), Enabled: func(cfg Config) bool { return cfg.UUIDBase64 }},
+		{Strategy: builtin("bench_auto_part", forKind("postgres", benchAutoPartitioned)), Enabled: func(cfg Config) bool { return cfg.Partitions > 0 }},
+		{Strategy: builtin("bench_uuid_part", forKind("postgres", benchUUIDPartitioned)), Enabled: func(cfg Config) bool { return cfg.Partitions > 0 }},
+	},
+}
+
+// RegisterStrategy は kind ("mysql" / "postgres") の方式の末尾に s を追加する。
+// 追加した方式は -strategies の絞り込みに従い、組み込み方式の後に計測される。
+// enabled が nil なら常に計測する。並行に呼ぶことは想定せず、init などの起動時に呼ぶ。
+func RegisterStrategy(kind string, s Strategy, enabled func(Config) bool) error {
+	registered, ok := strategyRegistry[kind]
+	if !ok {
+		return fmt.Errorf("unknown database kind %q (want mysql or postgres)", kind)
+	}
+	if slices.ContainsFunc(registered, func(r registeredStrategy) bool { return r.Strategy.Name() == s.Name() }) {
+		return fmt.Errorf("%s strategy %q is already registered", kind, s.Name())
+	}
+	r := registeredStrategy{Strategy: s, Enabled: enabled}
+	if v, ok := s.(VersionedStrategy); ok {
+		r.MinVersion = v.MinVersion()
+	}
+	strategyRegistry[kind] = append(registered, r)
+	return nil
+}
+
+// enabledStrategies は cfg で計測する kind の方式を計測順に返す。
+func enabledStrategies(cfg Config, kind string) []Strategy {
+	var out []Strategy
+	for _, s := range strategyRegistry[kind] {
+		if s.enabled(cfg) {
+			out = append(out, s.Strategy)
+		}
+	}
+	return out
+}
+
+// versionGated は MySQL の番号体系でバージョンを名乗るエンジンかを返す。
+// MariaDB (10.x) や TiDB (5.7 互換を名乗る) は番号と機能が対応しないため、ゲートの対象外にする。
+func versionGated(flavor string) bool {
+	return flavor != "mariadb" && flavor != "tidb"
+}
+
+// gateStrategies は cfg.MySQLVersionGate が真なら、MySQL サーバ version が最小バージョンに
+// 満たない方式を理由をログに残して除いた strategies を返す。
+func gateStrategies(cfg Config, version string, strategies []Strategy) []Strategy {
+	flavor := MySQLFlavor(version, "")
+	if !cfg.MySQLVersionGate || !versionGated(flavor) {
+		return strategies
+	}
+	var out []Strategy
+	for _, s := range strategies {
+		min := ""
+		for _, r := range strategyRegistry["mysql"] {
+			if r.Strategy.Name() == s.Name() {
+				min = r.MinVersion
+			}
+		}
+		if min != "" && !VersionAtLeast(version, min) {
+			slog.Warn("strategy skipped: server version too old", "db", "mysql", "table", s.Name(), "server_version", versionNumber(version), "min_version", min)
+			continue
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+// strategyNames は strategies の名前を返す。
+func strategyNames(strategies []Strategy) []string {
+	names := make([]string, len(strategies))
+	for i, s := range strategies {
+		names[i] = s.Name()
+	}
+	return names
+}
+
+// setupStrategies は strategies の Setup を順に呼ぶ。
+func setupStrategies(ctx context.Context, db *sql.DB, kind string, strategies []Strategy) error {
+	for _, s := range strategies {
+		if err := s.Setup(ctx, db); err != nil {
+			return fmt.Errorf("%s setup of %s failed: %w", kind, s.Name(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package bench
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"testing"
+)
+
+// fakeStrategy は Setup の呼び出しを記録するだけの Strategy。
+type fakeStrategy struct {
+	name  string
+	setup *int
+}
+
+func (s fakeStrategy) Name() string { return s.name }
+func (s fakeStrategy) Setup(ctx context.Context, db *sql.DB) error {
+	*s.setup++
+	return nil
+}
+func (s fakeStrategy) Run(ctx context.Context, db *sql.DB, cfg Config) (Result, error) {
+	return Result{Table: s.name}, nil
+}
+
+// versionedFake は最小バージョンを持つ fakeStrategy。
+type versionedFake struct {
+	fakeStrategy
+	min string
+}
+
+func (s versionedFake) MinVersion() string { return s.min }
+
+func TestStrategyRegistry(t *testing.T) {
+	saved, savedMySQL := slices.Clone(strategyRegistry["postgres"]), slices.Clone(strategyRegistry["mysql"])
+	defer func() { strategyRegistry["postgres"], strategyRegistry["mysql"] = saved, savedMySQL }()
+
+	t.Run("レジストリ_オプション方式は設定で有効なときだけ返す", func(t *testing.T) {
+		cfg := DefaultConfig()
+		if slices.Contains(strategyNames(enabledStrategies(cfg, "mysql")), "bench_uuid_comb") {
+			t.Fatal("bench_uuid_comb must be disabled by default")
+		}
+		cfg.UUIDComb = true
+		cfg.Strategies = []string{"bench_uuid_comb"}
+		if got := strategyNames(enabledStrategies(cfg, "mysql")); !slices.Equal(got, []string{"bench_uuid_comb"}) {
+			t.Fatalf("enabled = %v", got)
+		}
+	})
+
+	t.Run("レジストリ_登録した方式を末尾で計測しSetupを呼ぶ", func(t *testing.T) {
+		calls := 0
+		if err := RegisterStrategy("postgres", fakeStrategy{name: "bench_custom", setup: &calls}, nil); err != nil {
+			t.Fatal(err)
+		}
+		strategies := enabledStrategies(DefaultConfig(), "postgres")
+		if strategies[len(strategies)-1].Name() != "bench_custom" {
+			t.Fatalf("enabled = %v", strategyNames(strategies))
+		}
+		if err := setupStrategies(context.Background(), nil, "postgres", strategies); err != nil || calls != 1 {
+			t.Fatalf("setup calls = %d, err = %v", calls, err)
+		}
+		if !slices.ContainsFunc(Strategies(), func(i StrategyInfo) bool { return i.DB == "postgres" && i.Name == "bench_custom" }) {
+			t.Fatal("registered strategy missing from Strategies()")
+		}
+	})
+
+	t.Run("レジストリ_重複と未知のDBはエラー", func(t *testing.T) {
+		if err := RegisterStrategy("postgres", builtin("bench_uuid", benchPGUUID), nil); err == nil {
+			t.Fatal("want error for duplicate name")
+		}
+		if err := RegisterStrategy("sqlite", builtin("bench_x", benchPGUUID), nil); err == nil {
+			t.Fatal("want error for unknown kind")
+		}
+	})
+
+	t.Run("バージョンゲート_最小バージョンに満たない方式を外す", func(t *testing.T) {
+		calls := 0
+		if err := RegisterStrategy("mysql", versionedFake{fakeStrategy{name: "bench_new", setup: &calls}, "8.0.13"}, nil); err != nil {
+			t.Fatal(err)
+		}
+		cfg := DefaultConfig()
+		cfg.Strategies = []string{"bench_auto", "bench_new"}
+		strategies := enabledStrategies(cfg, "mysql")
+		for _, tc := range []struct {
+			version string
+			gate    bool
+			want    []string
+		}{
+			{"5.7.44-log", true, []string{"bench_auto"}},
+			{"8.0.12", true, []string{"bench_auto"}},
+			{"8.0.36", true, []string{"bench_auto", "bench_new"}},
+			{"5.7.44", false, []string{"bench_auto", "bench_new"}},
+			// MariaDB の番号は MySQL と対応しないため判定しない。
+			{"5.5.5-10.11.6-MariaDB", true, []string{"bench_auto", "bench_new"}},
+		} {
+			cfg.MySQLVersionGate = tc.gate
+			if got := strategyNames(gateStrategies(cfg, tc.version, strategies)); !slices.Equal(got, tc.want) {
+				t.Errorf("gate(%s, %v) = %v, want %v", tc.version, tc.gate, got, tc.want)
+			}
+		}
+	})
+	t.Run("バージョンゲート_UUID_TO_BINのサーバ生成は8.0未満で外す", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.NativeUUIDFunction = true
+		cfg.Strategies = []string{"bench_uuid_bin", "bench_uuid_bin_native"}
+		strategies := enabledStrategies(cfg, "mysql")
+		if got, want := strategyNames(gateStrategies(cfg, "5.7.44", strategies)), []string{"bench_uuid_bin"}; !slices.Equal(got, want) {
+			t.Fatalf("gate(5.7) = %v, want %v", got, want)
+		}
+		if got := strategyNames(gateStrategies(cfg, "8.0.36", strategies)); len(got) != 2 {
+			t.Fatalf("gate(8.0) = %v, want both", got)
+		}
+	})
+}
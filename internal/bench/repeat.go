@@ -0,0 +1,71 @@
+package bench
+
+import "math"
+
+// repeatMetrics は -repeat で平均と 95% 信頼区間を求める指標。sec は秒数、ci は区間の書き込み先。
+var repeatMetrics = []struct {
+	sec func(*Result) *float64
+	ci  func(*Result) (low, high *float64)
+}{
+	{
+		func(r *Result) *float64 { return &r.InsertSeconds },
+		func(r *Result) (*float64, *float64) { return &r.InsertSecondsCILow, &r.InsertSecondsCIHigh },
+	},
+	{
+		func(r *Result) *float64 { return &r.PointSeconds },
+		func(r *Result) (*float64, *float64) { return &r.PointSecondsCILow, &r.PointSecondsCIHigh },
+	},
+	{
+		func(r *Result) *float64 { return &r.RangeSeconds },
+		func(r *Result) (*float64, *float64) { return &r.RangeSecondsCILow, &r.RangeSecondsCIHigh },
+	},
+}
+
+// MergeRepeats は -repeat で同じ設定を繰り返した実行 runs を、1 回目の結果の並びにまとめる。
+// DB・接続先・テーブルが同じ結果のうち失敗していないものを標本とし、insert_sec / point_sec /
+// range_or_orderby_sec をその平均に置き換え、Repeats に標本数、*CILow / *CIHigh に 95% 信頼区間を入れる。
+// insert_rows も平均に置き換え、insert_rows_per_sec があれば平均の行数と秒数から求め直す
+// （-insert-duration では実行ごとに行数が変わるため）。
+// 標本が 2 未満の結果は区間を持たない。それ以外の列は 1 回目の値のまま。
+func MergeRepeats(runs [][]Result) []Result {
+	if len(runs) == 0 {
+		return nil
+	}
+	type key struct{ db, server, table string }
+	samples := make(map[key][]Result)
+	for _, run := range runs {
+		for _, r := range run {
+			if r.Err == "" {
+				k := key{r.DB, r.Server, r.Table}
+				samples[k] = append(samples[k], r)
+			}
+		}
+	}
+	out := make([]Result, len(runs[0]))
+	for i, r := range runs[0] {
+		out[i] = r
+		got := samples[key{r.DB, r.Server, r.Table}]
+		if r.Err != "" || len(got) < 2 {
+			continue
+		}
+		out[i].Repeats = len(got)
+		for _, m := range repeatMetrics {
+			xs := make([]float64, len(got))
+			for j := range got {
+				xs[j] = *m.sec(&got[j])
+			}
+			low, high := m.ci(&out[i])
+			*m.sec(&out[i]) = Mean(xs)
+			*low, *high = ConfidenceInterval95(xs)
+		}
+		var rows float64
+		for _, g := range got {
+			rows += float64(g.InsertRows)
+		}
+		out[i].InsertRows = int(math.Round(rows / float64(len(got))))
+		if out[i].InsertRowsPerSec > 0 && out[i].InsertSeconds > 0 {
+			out[i].InsertRowsPerSec = float64(out[i].InsertRows) / out[i].InsertSeconds
+		}
+	}
+	return out
+}
@@ -0,0 +1,77 @@
+package bench
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMergeRepeats(t *testing.T) {
+	run := func(insert, point float64) []Result {
+		return []Result{
+			{DB: "mysql", Server: "s1", Table: "bench_auto", InsertSeconds: insert, PointSeconds: point, RangeSeconds: 0.5},
+			{DB: "mysql", Server: "s1", Table: "bench_uuid_bin", Err: "boom"},
+		}
+	}
+
+	t.Run("繰り返し_平均と信頼区間に置き換える", func(t *testing.T) {
+		got := MergeRepeats([][]Result{run(1, 0.1), run(2, 0.2), run(3, 0.3)})
+		if len(got) != 2 {
+			t.Fatalf("len = %d, want 2", len(got))
+		}
+		r := got[0]
+		if r.Repeats != 3 || r.InsertSeconds != 2 || math.Abs(r.PointSeconds-0.2) > 1e-12 {
+			t.Fatalf("merged = %+v", r)
+		}
+		// 標本標準偏差 1、n=3 なので半幅は 4.303 / sqrt(3) ≈ 2.484。
+		if math.Abs(r.InsertSecondsCILow+0.484) > 1e-3 || math.Abs(r.InsertSecondsCIHigh-4.484) > 1e-3 {
+			t.Fatalf("insert ci = [%v, %v]", r.InsertSecondsCILow, r.InsertSecondsCIHigh)
+		}
+		if r.RangeSecondsCILow != 0.5 || r.RangeSecondsCIHigh != 0.5 {
+			t.Fatalf("range ci = [%v, %v], want [0.5, 0.5]", r.RangeSecondsCILow, r.RangeSecondsCIHigh)
+		}
+	})
+
+	t.Run("繰り返し_失敗した結果は区間を持たない", func(t *testing.T) {
+		got := MergeRepeats([][]Result{run(1, 0.1), run(2, 0.2)})
+		if got[1].Err != "boom" || got[1].Repeats != 0 || got[1].InsertSecondsCIHigh != 0 {
+			t.Fatalf("failed result = %+v", got[1])
+		}
+	})
+
+	t.Run("繰り返し_1回だけならそのまま", func(t *testing.T) {
+		got := MergeRepeats([][]Result{run(1, 0.1)})
+		if got[0].Repeats != 0 || got[0].InsertSeconds != 1 {
+			t.Fatalf("single run = %+v", got[0])
+		}
+	})
+
+	t.Run("繰り返し_行数を平均し毎秒行数を求め直す", func(t *testing.T) {
+		runs := [][]Result{
+			{{DB: "mysql", Table: "bench_auto", InsertRows: 100, InsertSeconds: 1, InsertRowsPerSec: 100}},
+			{{DB: "mysql", Table: "bench_auto", InsertRows: 300, InsertSeconds: 1, InsertRowsPerSec: 300}},
+		}
+		r := MergeRepeats(runs)[0]
+		if r.InsertRows != 200 || r.InsertRowsPerSec != 200 {
+			t.Fatalf("merged = %+v, want 200 rows at 200 rows/s", r)
+		}
+	})
+}
+
+func TestValidateConfigRepeat(t *testing.T) {
+	t.Run("繰り返し_0回は無効", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Repeat = 0
+		if err := ValidateConfig(cfg); err == nil {
+			t.Fatal("ValidateConfig error = nil, want error")
+		}
+	})
+
+	t.Run("繰り返し_no-setupとは併用できない", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Repeat = 3
+		cfg.NoSetup = true
+		if err := ValidateConfig(cfg); err == nil {
+			t.Fatal("ValidateConfig error = nil, want error")
+		}
+	})
+}
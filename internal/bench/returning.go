@@ -0,0 +1,87 @@
+package bench
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+)
+
+// insertReturning は cfg.InsertReturning が真なら、PostgreSQL の bench_auto と bench_uuid で
+// 「1 行挿入して、その行のキーを手元に得る」操作を cfg.Lookups 回繰り返した秒数を r へ書き込む。
+// bench_auto は採番されたキーを INSERT ... RETURNING id で 1 往復で受け取る場合（InsertReturningSeconds）と、
+// INSERT の後に SELECT lastval() で別に問い合わせる場合（InsertSelectKeySeconds）を計る。
+// bench_uuid はキーを挿入前にクライアントで決めるため RETURNING のない INSERT だけで済み、その秒数を
+// InsertReturningSeconds に入れて比べる。lastval() はセッション単位の値なので、1 本の接続に固定して計る。
+// 追加した行は消さないため、他のフェーズの後に呼ぶ。
+func insertReturning(ctx context.Context, db *sql.DB, cfg Config, kind string, r *Result) error {
+	if !cfg.InsertReturning || kind != "postgres" || (r.Table != "bench_auto" && r.Table != "bench_uuid") {
+		return nil
+	}
+	log := slog.With("db", kind, "table", r.Table, "phase", "insert_returning")
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	// 挿入計測と -insert-readback で使った行番号の後ろから振る。
+	base := r.InsertRows + cfg.Lookups
+	gen := payloadFor(cfg)
+	if r.Table == "bench_uuid" {
+		query := insertSQL(kind, r.Table, []string{"id", "payload"}, gen.Columns())
+		sec, err := insertKeyLoop(ctx, cfg, func(ctx context.Context, i int) error {
+			_, err := conn.ExecContext(ctx, query, insertArgs(gen, base+i, newUUID(cfg, base+i))...)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		r.InsertReturningSeconds = sec
+		log.Info("insert with client key done", "count", cfg.Lookups, "sec", sec)
+		return nil
+	}
+
+	query := insertSQL(kind, r.Table, []string{"payload"}, gen.Columns())
+	returningSec, err := insertKeyLoop(ctx, cfg, func(ctx context.Context, i int) error {
+		var id int64
+		return conn.QueryRowContext(ctx, query+" RETURNING id", insertArgs(gen, base+i)...).Scan(&id)
+	})
+	if err != nil {
+		return err
+	}
+	base += cfg.Lookups
+	selectSec, err := insertKeyLoop(ctx, cfg, func(ctx context.Context, i int) error {
+		if _, err := conn.ExecContext(ctx, query, insertArgs(gen, base+i)...); err != nil {
+			return err
+		}
+		// キーを得る問い合わせは INSERT とは別の往復になる。
+		if err := simulateRTT(ctx, cfg); err != nil {
+			return err
+		}
+		var id int64
+		return conn.QueryRowContext(ctx, "SELECT lastval()").Scan(&id)
+	})
+	if err != nil {
+		return err
+	}
+	r.InsertReturningSeconds, r.InsertSelectKeySeconds = returningSec, selectSec
+	log.Info("insert returning done", "count", cfg.Lookups, "returning_sec", returningSec, "select_key_sec", selectSec)
+	return nil
+}
+
+// insertKeyLoop は op を cfg.Lookups 回繰り返した合計秒数を返す。
+func insertKeyLoop(ctx context.Context, cfg Config, op func(ctx context.Context, i int) error) (float64, error) {
+	start := time.Now()
+	for i := 0; i < cfg.Lookups; i++ {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		if err := simulateRTT(ctx, cfg); err != nil {
+			return 0, err
+		}
+		if err := withQueryTimeout(ctx, cfg, i, op); err != nil {
+			return 0, err
+		}
+	}
+	return time.Since(start).Seconds(), nil
+}
@@ -0,0 +1,33 @@
+package bench
+
+import (
+	"context"
+	"testing"
+)
+
+func TestInsertReturning(t *testing.T) {
+	// 対象外の組み合わせでは DB に触れずに戻るため、nil の *sql.DB でも呼べる。
+	cfg := DefaultConfig()
+	cfg.InsertReturning = true
+	tests := []struct {
+		name string
+		cfg  Config
+		kind string
+		r    Result
+	}{
+		{"フラグなし", DefaultConfig(), "postgres", Result{Table: "bench_auto"}},
+		{"MySQLは対象外", cfg, "mysql", Result{Table: "bench_auto"}},
+		{"他の方式は対象外", cfg, "postgres", Result{Table: "bench_uuid_comb"}},
+	}
+	for _, tt := range tests {
+		t.Run("RETURNING計測_"+tt.name, func(t *testing.T) {
+			r := tt.r
+			if err := insertReturning(context.Background(), nil, tt.cfg, tt.kind, &r); err != nil {
+				t.Fatal(err)
+			}
+			if r.InsertReturningSeconds != 0 || r.InsertSelectKeySeconds != 0 {
+				t.Fatalf("result = %+v, want untouched", r)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package bench
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+)
+
+// reverseLookupIndex は -reverse-lookup で table の payload 列に張る二次インデックスの名前。
+func reverseLookupIndex(table string) string {
+	return "idx_" + table + "_payload"
+}
+
+// reverseLookupPayloads は挿入済みの rows 行の payload から、点検索に使う最大 n 件を行番号に均等な間隔で選ぶ。
+// NULL の行（-payload-nullable）は引けないため飛ばす。
+func reverseLookupPayloads(cfg Config, rows, n int) []any {
+	if n > rows {
+		n = rows
+	}
+	out := make([]any, 0, n)
+	gen := payloadFor(cfg)
+	for k := 0; k < n; k++ {
+		if p := payloadValue(gen, k*rows/n); p != nil {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// builtinTable は table が kind の組み込み方式かを返す。RegisterStrategy で追加した方式は
+// payload 列を持つとは限らないため、-reverse-lookup の対象にしない。
+func builtinTable(kind, table string) bool {
+	for _, r := range strategyRegistry[kind] {
+		if s, ok := r.Strategy.(builtinStrategy); ok && s.name == table {
+			return true
+		}
+	}
+	return false
+}
+
+// reverseLookups は cfg.ReverseLookup が真なら、計測を終えた r のテーブルの payload 列に二次インデックスを張り、
+// 挿入した payload の値で行を引く点検索の件数と秒数を r へ書き込む。
+// 主キーを通らない検索でも主キー方式の差がどれだけ残るかを見るためのもので、インデックスは
+// 他のフェーズに影響しないよう計測後に作り、終わったら削除する。組み込み方式以外は何もしない。
+func reverseLookups(ctx context.Context, db *sql.DB, cfg Config, kind string, r *Result) (err error) {
+	if !cfg.ReverseLookup || !builtinTable(kind, r.Table) {
+		return nil
+	}
+	sample := reverseLookupPayloads(cfg, r.InsertRows, cfg.Lookups)
+	if len(sample) == 0 {
+		return nil
+	}
+	log := slog.With("db", kind, "table", r.Table, "phase", "reverse")
+	index := reverseLookupIndex(r.Table)
+	if _, err := db.ExecContext(ctx, "CREATE INDEX "+index+" ON "+r.Table+" (payload)"); err != nil {
+		return err
+	}
+	defer func() {
+		drop := "DROP INDEX " + index
+		if kind == "mysql" {
+			drop += " ON " + r.Table
+		}
+		if _, dropErr := db.ExecContext(ctx, drop); err == nil {
+			err = dropErr
+		}
+	}()
+	if err := analyzeTable(ctx, db, cfg, log, kind, r.Table); err != nil {
+		return err
+	}
+	s, err := prepare(ctx, db, cfg, "SELECT * FROM "+r.Table+" WHERE payload = "+placeholders(kind, 1))
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+	// 行の中身は使わないが、主キー（ヒープ）から行本体を読むところまでを計る。
+	t, err := pointLoop(withoutLatencyDump(ctx), cfg, log, len(sample), func(ctx context.Context, i int) error {
+		rows, err := s.QueryContext(ctx, sample[i])
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return err
+	}
+	r.ReverseLookupCount, r.ReversePointSeconds = len(sample), t.Seconds
+	return nil
+}
@@ -0,0 +1,42 @@
+package bench
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestReverseLookupPayloads(t *testing.T) {
+	t.Run("行番号に均等な間隔で選ぶ", func(t *testing.T) {
+		got := reverseLookupPayloads(DefaultConfig(), 10, 5)
+		if want := []any{"p-0", "p-2", "p-4", "p-6", "p-8"}; !slices.Equal(got, want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("行数より多くは選ばない", func(t *testing.T) {
+		if got := reverseLookupPayloads(DefaultConfig(), 3, 10); len(got) != 3 {
+			t.Fatalf("got %v", got)
+		}
+	})
+
+	t.Run("NULL許容_NULLの行は飛ばす", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.PayloadNullable = true
+		cfg.PayloadNullFraction = 0.5
+		got := reverseLookupPayloads(cfg, 1000, 1000)
+		if len(got) == 0 || len(got) == 1000 || slices.Contains(got, nil) {
+			t.Fatalf("got %d payloads (nil included: %v)", len(got), slices.Contains(got, nil))
+		}
+	})
+}
+
+func TestBuiltinTable(t *testing.T) {
+	t.Run("組み込み方式だけを対象にする", func(t *testing.T) {
+		if !builtinTable("mysql", "bench_uuid_bin") || !builtinTable("postgres", "bench_uuid") {
+			t.Fatal("built-in strategies must be reverse-lookup targets")
+		}
+		if builtinTable("postgres", "bench_uuid_bin") || builtinTable("mysql", "bench_custom") {
+			t.Fatal("unknown tables must not be reverse-lookup targets")
+		}
+	})
+}
@@ -0,0 +1,94 @@
+package bench
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+)
+
+// prepopulateTables は -prepopulate-fast が埋めるテーブル。
+func prepopulateTables(kind string) []string {
+	if kind == "mysql" {
+		return []string{"bench_auto", "bench_uuid_char", "bench_uuid_bin"}
+	}
+	return []string{"bench_auto", "bench_uuid"}
+}
+
+// rollbackSetupStmts は stmts（mysqlSetupStmts / pgSetupStmts）から -prepopulate-fast のテーブルを作る
+// CREATE / ALTER 文だけを残した一覧を返す。DROP は含めず、既存のテーブルには触れない。
+// MySQL の CREATE TABLE は暗黙にコミットされてロールバックできないため、接続を閉じるか
+// DROP TEMPORARY TABLE で消える CREATE TEMPORARY TABLE に置き換える。
+// PostgreSQL の DDL はトランザクションの中で取り消せるため、そのまま使う。
+func rollbackSetupStmts(kind string, stmts []string) []string {
+	tables := prepopulateTables(kind)
+	var out []string
+	for _, stmt := range stmts {
+		rest, ok := strings.CutPrefix(stmt, "CREATE TABLE ")
+		if !ok {
+			rest, ok = strings.CutPrefix(stmt, "CREATE UNLOGGED TABLE ")
+		}
+		if !ok {
+			rest, ok = strings.CutPrefix(stmt, "ALTER TABLE ")
+		}
+		fields := strings.Fields(rest)
+		if !ok || len(fields) == 0 || !slices.Contains(tables, fields[0]) {
+			continue
+		}
+		if kind == "mysql" {
+			stmt = strings.Replace(stmt, "CREATE TABLE ", "CREATE TEMPORARY TABLE ", 1)
+		}
+		out = append(out, stmt)
+	}
+	return out
+}
+
+// withRollback は cfg.Rollback なら 1 つのトランザクションを開き、-prepopulate-fast のテーブルを
+// その中で作ってから run に渡し、結果にかかわらず最後にロールバックする。対象 DB に永続的な
+// テーブルや行を残さずに読み取りフェーズを計るためのもの。無効なら run(db) をそのまま呼ぶ。
+// MySQL は ANALYZE TABLE が暗黙にコミットするため、行を残さない保証は一時テーブルによる。
+func withRollback(ctx context.Context, db *sql.DB, cfg Config, kind string, run func(queryer) error) (err error) {
+	if !cfg.Rollback {
+		return run(db)
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s rollback begin failed: %w", kind, err)
+	}
+	defer func() {
+		// 一時テーブルは接続が閉じるまで残るため、接続プールへ戻す前に消す。
+		if kind == "mysql" {
+			for _, table := range prepopulateTables(kind) {
+				if _, derr := tx.ExecContext(ctx, "DROP TEMPORARY TABLE IF EXISTS "+table); derr != nil {
+					err = errors.Join(err, fmt.Errorf("mysql drop temporary table %s failed: %w", table, derr))
+				}
+			}
+		}
+		if rerr := tx.Rollback(); rerr != nil && !errors.Is(rerr, sql.ErrTxDone) {
+			err = errors.Join(err, fmt.Errorf("%s rollback failed: %w", kind, rerr))
+			return
+		}
+		slog.Info("rolled back", "db", kind)
+	}()
+
+	stmts := pgSetupStmts(cfg)
+	if kind == "mysql" {
+		stmts = mysqlSetupStmts(cfg)
+	}
+	for _, stmt := range rollbackSetupStmts(kind, stmts) {
+		slog.Debug(kind+" rollback setup", "stmt", stmt)
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("%s rollback setup failed: %w", kind, err)
+		}
+	}
+	// BINARY(16) の往復確認は、作ったばかりの一時テーブルで行う。
+	if kind == "mysql" && cfg.ValidateUUIDBytes {
+		if err := validateUUIDBytes(ctx, tx, cfg); err != nil {
+			return err
+		}
+	}
+	return run(tx)
+}
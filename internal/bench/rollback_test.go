@@ -0,0 +1,70 @@
+package bench
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRollbackSetupStmts(t *testing.T) {
+	t.Run("MySQL_一時テーブルだけを作る", func(t *testing.T) {
+		stmts := rollbackSetupStmts("mysql", mysqlSetupStmts(DefaultConfig()))
+		if len(stmts) != 3 {
+			t.Fatalf("len = %d, want 3: %q", len(stmts), stmts)
+		}
+		for i, table := range []string{"bench_auto", "bench_uuid_char", "bench_uuid_bin"} {
+			if !strings.HasPrefix(stmts[i], "CREATE TEMPORARY TABLE "+table+" ") {
+				t.Fatalf("stmts[%d] = %q, want CREATE TEMPORARY TABLE %s", i, stmts[i], table)
+			}
+		}
+	})
+
+	t.Run("PostgreSQL_DROPを含めずALTERは残す", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.PGUnlogged = true
+		cfg.PGAutovacuum = "off"
+		stmts := rollbackSetupStmts("postgres", pgSetupStmts(cfg))
+		want := []string{
+			"CREATE UNLOGGED TABLE bench_auto ",
+			"ALTER TABLE bench_auto SET (autovacuum_enabled = off)",
+			"CREATE UNLOGGED TABLE bench_uuid ",
+			"ALTER TABLE bench_uuid SET (autovacuum_enabled = off)",
+		}
+		if len(stmts) != len(want) {
+			t.Fatalf("len = %d, want %d: %q", len(stmts), len(want), stmts)
+		}
+		for i, w := range want {
+			if !strings.HasPrefix(stmts[i], w) {
+				t.Fatalf("stmts[%d] = %q, want prefix %q", i, stmts[i], w)
+			}
+		}
+	})
+}
+
+func TestValidateConfigRollback(t *testing.T) {
+	t.Run("ロールバック_prepopulate-fastと併用なら有効", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.PrepopulateFast = true
+		cfg.Rollback = true
+		if err := ValidateConfig(cfg); err != nil {
+			t.Fatalf("ValidateConfig = %v, want nil", err)
+		}
+	})
+
+	t.Run("ロールバック_prepopulate-fastなしはエラー", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Rollback = true
+		if err := ValidateConfig(cfg); err == nil {
+			t.Fatal("ValidateConfig error = nil, want error")
+		}
+	})
+
+	t.Run("ロールバック_pg-vacuumとは併用できない", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.PrepopulateFast = true
+		cfg.Rollback = true
+		cfg.PGVacuum = true
+		if err := ValidateConfig(cfg); err == nil {
+			t.Fatal("ValidateConfig error = nil, want error")
+		}
+	})
+}
@@ -0,0 +1,24 @@
+package bench
+
+import (
+	"context"
+	"time"
+)
+
+// simulateRTT は cfg.SimulatedRTT が正なら、その時間だけ待って DB との 1 往復ぶんのネットワーク遅延を模す。
+// DB が別リージョンにある構成では往復 1 回ごとに遅延がかかるため、行のキーを手元に得るまでに何往復
+// 必要かの違い（クライアントで決める UUID は INSERT の 1 往復、lastval() で問い合わせる連番や
+// カウンタ表の欠番なしの連番はそれ以上）が挿入時間へ積み上がる。0 なら何もしない。
+func simulateRTT(ctx context.Context, cfg Config) error {
+	if cfg.SimulatedRTT <= 0 {
+		return nil
+	}
+	t := time.NewTimer(cfg.SimulatedRTT)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,62 @@
+package bench
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSimulateRTT(t *testing.T) {
+	t.Run("遅延_0なら待たない", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if err := simulateRTT(ctx, DefaultConfig()); err != nil {
+			t.Fatalf("simulateRTT = %v, want nil", err)
+		}
+	})
+
+	t.Run("遅延_指定時間だけ待つ", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.SimulatedRTT = 5 * time.Millisecond
+		start := time.Now()
+		if err := simulateRTT(context.Background(), cfg); err != nil {
+			t.Fatalf("simulateRTT = %v, want nil", err)
+		}
+		if d := time.Since(start); d < cfg.SimulatedRTT {
+			t.Fatalf("slept %v, want >= %v", d, cfg.SimulatedRTT)
+		}
+	})
+
+	t.Run("遅延_キャンセルされたら止める", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.SimulatedRTT = time.Hour
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if err := simulateRTT(ctx, cfg); !errors.Is(err, context.Canceled) {
+			t.Fatalf("simulateRTT = %v, want context.Canceled", err)
+		}
+	})
+}
+
+func TestValidateConfigSimulatedRTT(t *testing.T) {
+	t.Run("遅延_負の値はエラー", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.SimulatedRTT = -time.Millisecond
+		if err := ValidateConfig(cfg); err == nil {
+			t.Fatal("ValidateConfig error = nil, want error")
+		}
+	})
+}
+
+func TestInsertThroughputSimulatedRTT(t *testing.T) {
+	t.Run("遅延_指定時は行数を選ばなくても書き込む", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.SimulatedRTT = 40 * time.Millisecond
+		r := Result{InsertRows: 100, InsertSeconds: 4}
+		insertThroughput(cfg, &r)
+		if r.InsertRowsPerSec != 25 {
+			t.Fatalf("InsertRowsPerSec = %v, want 25", r.InsertRowsPerSec)
+		}
+	})
+}
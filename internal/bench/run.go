@@ -3,487 +3,2899 @@ package bench
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 // MySQLDSN は Config から go-sql-driver/mysql 用 DSN を組み立てる。
+// DDL も 1 文ずつ実行しているため multiStatements は付けない
+// （マネージド MySQL では無効化されていることがあり、注入面も広がるため）。
 func MySQLDSN(cfg Config) string {
-	return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=true&multiStatements=true",
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=true",
 		cfg.MySQLUser,
 		cfg.MySQLPassword,
 		cfg.MySQLHost,
 		cfg.MySQLPort,
 		cfg.MySQLDB,
 	)
+	if cfg.NoPrepare {
+		dsn += "&interpolateParams=true"
+	}
+	if cfg.Strict {
+		dsn += "&sql_mode=" + url.QueryEscape(strictSQLMode)
+	}
+	if isolation := mysqlIsolationParam(cfg); isolation != "" {
+		dsn += "&transaction_isolation=" + url.QueryEscape(isolation)
+	}
+	for _, kv := range mysqlDriverParams(cfg) {
+		dsn += "&" + kv[0] + "=" + kv[1]
+	}
+	return dsn
 }
 
 // PGDSN は pgx stdlib 用の接続文字列を組み立てる。
+// セッション設定はランタイムパラメータとして末尾へ付ける。
 func PGDSN(cfg Config) string {
-	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable%s",
 		cfg.PGHost,
 		cfg.PGPort,
 		cfg.PGUser,
 		cfg.PGPassword,
 		cfg.PGDB,
+		formatPGParams(pgRuntimeParams(cfg)),
 	)
 }
 
+// Target は計測対象の接続 1 つぶんを表す。
+// Label が空でなければ、その接続で得た結果の Server 列に入れる。
+type Target struct {
+	DB    *sql.DB
+	Label string
+}
+
 // RunAll は各 DB/ID 方式のベンチマークを初期化込みで順に実行する。
 func RunAll(ctx context.Context, mysqlDB, pgDB *sql.DB, cfg Config) ([]Result, error) {
+	return RunTargets(ctx, []Target{{DB: mysqlDB}}, []Target{{DB: pgDB}}, cfg)
+}
+
+// RunTargets は MySQL / PostgreSQL の各接続先に対して全方式を順に実行する。
+// 複数バージョンのサーバを 1 回の実行で比較する用途を想定する。
+func RunTargets(ctx context.Context, mysqlTargets, pgTargets []Target, cfg Config) ([]Result, error) {
+	return Runner{Config: cfg}.Run(ctx, mysqlTargets, pgTargets)
+}
+
+// Runner は計測設定と、結果を逐次受け取るコールバックをまとめる。
+// OnResult を設定すると、各方式の計測が終わるたびに Server 付きの結果で呼ばれる。
+// 長時間の実行で進捗を表示したり、ダッシュボードへ流したりする用途を想定する。
+type Runner struct {
+	Config   Config
+	OnResult func(Result)
+	// OnProgress を設定すると、単一テーブルの方式の挿入・点検索の進み具合が一定件数ごとに渡される。
+	OnProgress func(Progress)
+}
+
+// Run は MySQL / PostgreSQL の各接続先に対して全方式を順に実行し、全結果を返す。
+func (rn Runner) Run(ctx context.Context, mysqlTargets, pgTargets []Target) ([]Result, error) {
+	var results []Result
+	for _, t := range mysqlTargets {
+		slog.Info("mysql target start", "server", t.Label)
+		tctx, endTarget := startSpan(ctx, "mysql", "db", "mysql", "server", t.Label)
+		tctx = withServerLabel(tctx, t.Label)
+		tctx = withProgress(tctx, rn.OnProgress, "mysql", t.Label)
+		rs, err := runMySQL(tctx, t.DB, rn.Config, rn.emitter(t.Label))
+		endTarget(err)
+		if err != nil {
+			return nil, labelError(t.Label, err)
+		}
+		results = append(results, tagServer(rs, t.Label)...)
+	}
+	for _, t := range pgTargets {
+		slog.Info("postgres target start", "server", t.Label)
+		tctx, endTarget := startSpan(ctx, "postgres", "db", "postgres", "server", t.Label)
+		tctx = withServerLabel(tctx, t.Label)
+		tctx = withProgress(tctx, rn.OnProgress, "postgres", t.Label)
+		rs, err := runPostgres(tctx, t.DB, rn.Config, rn.emitter(t.Label))
+		endTarget(err)
+		if err != nil {
+			return nil, labelError(t.Label, err)
+		}
+		results = append(results, tagServer(rs, t.Label)...)
+	}
+	return results, nil
+}
+
+// emitter は接続先ラベルを付けて OnResult へ渡す関数を返す。OnResult が nil なら nil。
+func (rn Runner) emitter(label string) func(Result) {
+	if rn.OnResult == nil {
+		return nil
+	}
+	return func(r Result) {
+		r.Server = label
+		rn.OnResult(r)
+	}
+}
+
+// labelError は接続先ラベルがあればエラーへ付け加える。
+func labelError(label string, err error) error {
+	if label == "" {
+		return err
+	}
+	return fmt.Errorf("%s: %w", label, err)
+}
+
+// tagServer は results の Server 列へ label を設定する。
+func tagServer(results []Result, label string) []Result {
+	for i := range results {
+		results[i].Server = label
+	}
+	return results
+}
+
+// SetupTargets は各接続先で計測前と同じスキーマ初期化（テーブルの作り直し）だけを行い、挿入も計測もしない。
+// -setup-only で共有環境のテーブルを先に用意し、後の実行は -no-setup で計測だけを行う用途を想定する。
+func SetupTargets(ctx context.Context, mysqlTargets, pgTargets []Target, cfg Config) error {
+	for _, t := range mysqlTargets {
+		strategies, err := mysqlStrategies(ctx, t.DB, cfg)
+		if err == nil {
+			err = setupMySQLSchema(ctx, t.DB, cfg, strategies)
+		}
+		if err != nil {
+			return labelError(t.Label, err)
+		}
+		slog.Info("mysql setup done", "server", t.Label, "tables", len(mysqlTables(cfg)))
+	}
+	for _, t := range pgTargets {
+		if err := setupPostgresSchema(ctx, t.DB, cfg); err != nil {
+			return labelError(t.Label, err)
+		}
+		slog.Info("postgres setup done", "server", t.Label, "tables", len(pgTables(cfg)))
+	}
+	return nil
+}
+
+// RunMySQL は MySQL の全方式をスキーマ初期化込みで順に実行する。
+func RunMySQL(ctx context.Context, mysqlDB *sql.DB, cfg Config) ([]Result, error) {
+	return runMySQL(ctx, mysqlDB, cfg, nil)
+}
+
+// runMySQL は RunMySQL に、計測が終わった方式の結果を onResult へ逐次渡す処理を加えたもの。
+func runMySQL(ctx context.Context, mysqlDB *sql.DB, cfg Config, onResult func(Result)) ([]Result, error) {
+	strategies, err := mysqlStrategies(ctx, mysqlDB, cfg)
+	if err != nil {
+		return nil, err
+	}
 	// 実行ごとにスキーマを作り直し、比較条件を揃える。
-	if err := setupMySQL(ctx, mysqlDB); err != nil {
+	// -no-setup 時は既存テーブルをそのまま使い、揃っているかだけ確認する。
+	// -rollback 時はテーブルを withRollback のトランザクションの中で作るため、ここでは何もしない。
+	switch {
+	case cfg.Rollback:
+	case cfg.NoSetup:
+		if err := checkTables(ctx, mysqlDB, "mysql", mysqlTables(cfg)); err != nil {
+			return nil, err
+		}
+	default:
+		if err := setupMySQLSchema(ctx, mysqlDB, cfg, strategies); err != nil {
+			return nil, err
+		}
+	}
+	// BINARY(16) の計測を始める前に、ドライバ経由の往復でバイト列が壊れないことを確かめる。
+	if cfg.ValidateUUIDBytes && !cfg.Rollback {
+		if err := validateUUIDBytes(ctx, mysqlDB, cfg); err != nil {
+			return nil, err
+		}
+	}
+	// 文字列の UUID キーが大小を区別しない照合順序になっていれば、結果を読む前に気付けるよう警告する。
+	// 一時テーブルは information_schema に現れないため、-rollback 時は確かめない。
+	if cfg.CollationWarn && !cfg.Rollback {
+		if err := checkCharCollation(ctx, mysqlDB); err != nil {
+			return nil, err
+		}
+	}
+
+	results := make([]Result, 0, 6)
+	// -mysql-table-sizes 時は方式ごとの計測直後にテーブルサイズを取得し、結果へ加えてから確定する。
+	add := func(rs ...Result) error {
+		for i := range rs {
+			var err error
+			if rs[i], err = mysqlTableSize(ctx, mysqlDB, cfg, rs[i]); err != nil {
+				return err
+			}
+		}
+		results = emit(results, onResult, rs...)
+		return nil
+	}
+	// fail は -fail-fast=false なら table の失敗を Err 付きの結果として残し、残りの方式を続ける。
+	fail := func(table string, err error) error {
+		r, err := isolateFailure(ctx, cfg, "mysql", table, err)
+		if err != nil {
+			return err
+		}
+		results = emit(results, onResult, r)
+		return nil
+	}
+	// run は table を計測して結果へ加える。
+	run := func(table string, bench func(context.Context, *sql.DB, Config) (Result, error)) error {
+		tctx, endTable := startSpan(withProgressTable(ctx, table), table, "table", table)
+		// -auto-scale-rows では方式ごとに較正した行数で計測する。
+		tcfg, err := autoScaleRows(tctx, mysqlDB, cfg, "mysql", table, bench)
+		if err != nil {
+			endTable(err)
+			return fail(table, err)
+		}
+		mctx, cpu := withCPUMeter(tctx, tcfg)
+		mctx, dump := withLatencyDump(mctx, tcfg, "mysql", table)
+		sampler := startMetricsSampler(tctx, mysqlDB, tcfg, "mysql", table)
+		r, err := withInnoDBMetrics(mctx, mysqlDB, cfg, func() (Result, error) {
+			return bench(mctx, mysqlDB, tcfg)
+		})
+		err = errors.Join(err, sampler.Stop(), dump.Close())
+		// 逆引きは CPU 時間・遅延ファイルの対象外にする。
+		if err == nil {
+			err = reverseLookups(tctx, mysqlDB, tcfg, "mysql", &r)
+		}
+		// 範囲削除は行を消すため最後に行う。
+		if err == nil {
+			err = rangeDelete(tctx, mysqlDB, tcfg, "mysql", &r)
+		}
+		endTable(err)
+		if err != nil {
+			return fail(table, err)
+		}
+		cpu.apply(&r)
+		insertThroughput(tcfg, &r)
+		if err := add(r); err != nil {
+			return fail(table, err)
+		}
+		return nil
+	}
+	if cfg.InnoDBMetrics {
+		enableInnoDBMetrics(ctx, mysqlDB)
+	}
+	// -prepopulate-fast はサーバ側生成でテーブルを埋め、読み取りフェーズだけを計測する。
+	if cfg.PrepopulateFast {
+		err := withRollback(ctx, mysqlDB, cfg, "mysql", func(db queryer) error {
+			return runMySQLPrepopulated(ctx, db, cfg, add)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return results, nil
+	}
+	// 単一テーブルの方式はレジストリの順に計測する。
+	for _, st := range strategies {
+		if err := run(st.Name(), st.Run); err != nil {
+			return nil, err
+		}
+	}
+	// MySQL: 外部キー制約あり/なしの子テーブルへの挿入
+	if cfg.ForeignKeys && strategySelected(cfg, fkTables("mysql")...) {
+		rs, err := runForeignKeys(ctx, mysqlDB, cfg, "mysql")
+		if err == nil {
+			err = add(rs...)
+		}
+		if err != nil {
+			if err := fail(strings.Join(fkTables("mysql"), "/"), err); err != nil {
+				return nil, err
+			}
+		}
+	}
+	// MySQL: 並列挿入時のロック待ち/デッドロック
+	if cfg.ConcurrentWorkers > 0 && strategySelected(cfg, concurrentTables(cfg)...) {
+		rs, err := runMySQLConcurrent(ctx, mysqlDB, cfg)
+		if err == nil {
+			err = add(rs...)
+		}
+		if err != nil {
+			if err := fail(strings.Join(concurrentTables(cfg), "/"), err); err != nil {
+				return nil, err
+			}
+		}
+	}
+	// MySQL: 点検索と挿入を混ぜた並列負荷
+	if cfg.MixedDuration > 0 && strategySelected(cfg, "bench_auto_mixed", "bench_uuid_mixed") {
+		rs, err := runMixed(ctx, mysqlDB, cfg, "mysql")
+		if err == nil {
+			err = add(rs...)
+		}
+		if err != nil {
+			if err := fail("bench_auto_mixed/bench_uuid_mixed", err); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return results, nil
+}
+
+// RunPostgres は PostgreSQL の全方式をスキーマ初期化込みで順に実行する。
+func RunPostgres(ctx context.Context, pgDB *sql.DB, cfg Config) ([]Result, error) {
+	return runPostgres(ctx, pgDB, cfg, nil)
+}
+
+// runPostgres は RunPostgres に、計測が終わった方式の結果を onResult へ逐次渡す処理を加えたもの。
+func runPostgres(ctx context.Context, pgDB *sql.DB, cfg Config, onResult func(Result)) ([]Result, error) {
+	switch {
+	case cfg.Rollback:
+	case cfg.NoSetup:
+		if err := checkTables(ctx, pgDB, "postgres", pgTables(cfg)); err != nil {
+			return nil, err
+		}
+	default:
+		if err := setupPostgresSchema(ctx, pgDB, cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	results := make([]Result, 0, 5)
+	// -pg-vacuum 時は方式ごとの計測直後に VACUUM を計測し、結果へ加えてから確定する。
+	add := func(rs ...Result) error {
+		for i := range rs {
+			var err error
+			if rs[i], err = vacuumPG(ctx, pgDB, cfg, rs[i]); err != nil {
+				return err
+			}
+		}
+		results = emit(results, onResult, rs...)
+		return nil
+	}
+	// fail は -fail-fast=false なら table の失敗を Err 付きの結果として残し、残りの方式を続ける。
+	fail := func(table string, err error) error {
+		r, err := isolateFailure(ctx, cfg, "postgres", table, err)
+		if err != nil {
+			return err
+		}
+		results = emit(results, onResult, r)
+		return nil
+	}
+	// run は table を計測して結果へ加える。
+	run := func(table string, bench func(context.Context, *sql.DB, Config) (Result, error)) error {
+		tctx, endTable := startSpan(withProgressTable(ctx, table), table, "table", table)
+		// -auto-scale-rows では方式ごとに較正した行数で計測する。
+		tcfg, err := autoScaleRows(tctx, pgDB, cfg, "postgres", table, bench)
+		if err != nil {
+			endTable(err)
+			return fail(table, err)
+		}
+		mctx, cpu := withCPUMeter(tctx, tcfg)
+		mctx, dump := withLatencyDump(mctx, tcfg, "postgres", table)
+		sampler := startMetricsSampler(tctx, pgDB, tcfg, "postgres", table)
+		r, err := bench(mctx, pgDB, tcfg)
+		err = errors.Join(err, sampler.Stop(), dump.Close())
+		// 逆引きは CPU 時間・遅延ファイルの対象外にする。
+		if err == nil {
+			err = reverseLookups(tctx, pgDB, tcfg, "postgres", &r)
+		}
+		if err == nil {
+			err = insertReturning(tctx, pgDB, tcfg, "postgres", &r)
+		}
+		// 範囲削除は行を消すため最後に行う。
+		if err == nil {
+			err = rangeDelete(tctx, pgDB, tcfg, "postgres", &r)
+		}
+		endTable(err)
+		if err != nil {
+			return fail(table, err)
+		}
+		cpu.apply(&r)
+		insertThroughput(tcfg, &r)
+		if err := add(r); err != nil {
+			return fail(table, err)
+		}
+		return nil
+	}
+	// -prepopulate-fast はサーバ側生成でテーブルを埋め、読み取りフェーズだけを計測する。
+	if cfg.PrepopulateFast {
+		err := withRollback(ctx, pgDB, cfg, "postgres", func(db queryer) error {
+			return runPGPrepopulated(ctx, db, cfg, add)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return results, nil
+	}
+	// 単一テーブルの方式はレジストリの順に計測する。
+	for _, st := range enabledStrategies(cfg, "postgres") {
+		if err := run(st.Name(), st.Run); err != nil {
+			return nil, err
+		}
+	}
+	// PostgreSQL: 外部キー制約あり/なしの子テーブルへの挿入
+	if cfg.ForeignKeys && strategySelected(cfg, fkTables("postgres")...) {
+		rs, err := runForeignKeys(ctx, pgDB, cfg, "postgres")
+		if err == nil {
+			err = add(rs...)
+		}
+		if err != nil {
+			if err := fail(strings.Join(fkTables("postgres"), "/"), err); err != nil {
+				return nil, err
+			}
+		}
+	}
+	// PostgreSQL: 並列挿入時のデッドロック
+	if cfg.ConcurrentWorkers > 0 && strategySelected(cfg, concurrentTables(cfg)...) {
+		rs, err := runPGConcurrent(ctx, pgDB, cfg)
+		if err == nil {
+			err = add(rs...)
+		}
+		if err != nil {
+			if err := fail(strings.Join(concurrentTables(cfg), "/"), err); err != nil {
+				return nil, err
+			}
+		}
+	}
+	// PostgreSQL: 点検索と挿入を混ぜた並列負荷
+	if cfg.MixedDuration > 0 && strategySelected(cfg, "bench_auto_mixed", "bench_uuid_mixed") {
+		rs, err := runMixed(ctx, pgDB, cfg, "postgres")
+		if err == nil {
+			err = add(rs...)
+		}
+		if err != nil {
+			if err := fail("bench_auto_mixed/bench_uuid_mixed", err); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return results, nil
+}
+
+// vacuumPG は cfg.PGVacuum なら r.Table へ VACUUM (ANALYZE) を実行して所要秒数を計り、
+// 実行前の不要タプル数と実行後のインデックスサイズを r へ記録する。
+// ランダムキーの挿入で散らばったインデックスの保守コストを、挿入時間とは別に見るために使う。
+func vacuumPG(ctx context.Context, db *sql.DB, cfg Config, r Result) (Result, error) {
+	if !cfg.PGVacuum {
+		return r, nil
+	}
+	log := slog.With("db", "postgres", "table", r.Table)
+	// 統計コレクタへの反映は遅れるため、直前の操作ぶんは数に入らないことがある。
+	var dead int64
+	if err := db.QueryRowContext(ctx, "SELECT COALESCE(SUM(n_dead_tup), 0) FROM pg_stat_user_tables WHERE relname = $1", r.Table).Scan(&dead); err != nil {
+		return r, fmt.Errorf("postgres dead tuple query failed: %w", err)
+	}
+	log.Debug("vacuum start", "dead_tuples", dead)
+	start := time.Now()
+	// テーブル名は固定の識別子のみなのでそのまま埋め込む。
+	if _, err := db.ExecContext(ctx, "VACUUM (ANALYZE) "+r.Table); err != nil {
+		return r, fmt.Errorf("postgres vacuum failed: %w", err)
+	}
+	sec := time.Since(start).Seconds()
+	var indexBytes int64
+	if err := db.QueryRowContext(ctx, "SELECT pg_indexes_size($1::regclass)", r.Table).Scan(&indexBytes); err != nil {
+		return r, fmt.Errorf("postgres index size query failed: %w", err)
+	}
+	log.Info("vacuum done", "sec", sec, "dead_tuples", dead, "index_bytes", indexBytes)
+	r.VacuumSeconds = sec
+	r.DeadTuples = &dead
+	r.IndexBytes = indexBytes
+	return r, nil
+}
+
+// mysqlTables は cfg で有効な MySQL の計測対象テーブル名を返す。
+func mysqlTables(cfg Config) []string {
+	tables := strategyNames(enabledStrategies(cfg, "mysql"))
+	if cfg.ForeignKeys {
+		tables = append(tables, fkTables("mysql")...)
+	}
+	if cfg.ConcurrentWorkers > 0 {
+		tables = append(tables, concurrentTables(cfg)...)
+	}
+	if cfg.MixedDuration > 0 {
+		tables = append(tables, "bench_auto_mixed", "bench_uuid_mixed")
+	}
+	return selectedTables(cfg, tables)
+}
+
+// pgTables は cfg で有効な PostgreSQL の計測対象テーブル名を返す。
+func pgTables(cfg Config) []string {
+	tables := strategyNames(enabledStrategies(cfg, "postgres"))
+	if cfg.ForeignKeys {
+		tables = append(tables, fkTables("postgres")...)
+	}
+	if cfg.ConcurrentWorkers > 0 {
+		tables = append(tables, concurrentTables(cfg)...)
+	}
+	if cfg.MixedDuration > 0 {
+		tables = append(tables, "bench_auto_mixed", "bench_uuid_mixed")
+	}
+	return selectedTables(cfg, tables)
+}
+
+// strategySelected は cfg.Strategies が空か、tables のいずれかを含むときに真を返す。
+func strategySelected(cfg Config, tables ...string) bool {
+	if len(cfg.Strategies) == 0 {
+		return true
+	}
+	for _, t := range tables {
+		if slices.Contains(cfg.Strategies, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// selectedTables は tables のうち cfg.Strategies で選択されたものを返す。
+func selectedTables(cfg Config, tables []string) []string {
+	return slices.DeleteFunc(tables, func(t string) bool { return !strategySelected(cfg, t) })
+}
+
+// mysqlTableSize は cfg.MySQLTableSizes なら ANALYZE TABLE で統計を更新してから
+// information_schema.TABLES のデータ長とインデックス長を r へ記録する。
+// InnoDB ではクラスタ化された主キーはデータ長に含まれ、インデックス長は二次インデックスのみ。
+func mysqlTableSize(ctx context.Context, db *sql.DB, cfg Config, r Result) (Result, error) {
+	if !cfg.MySQLTableSizes {
+		return r, nil
+	}
+	if err := mysqlAnalyze(ctx, db, r.Table); err != nil {
+		return r, err
+	}
+	if err := db.QueryRowContext(ctx, "SELECT data_length, index_length FROM information_schema.TABLES WHERE table_schema = DATABASE() AND table_name = ?", r.Table).Scan(&r.DataBytes, &r.IndexBytes); err != nil {
+		return r, fmt.Errorf("mysql table size query failed: %w", err)
+	}
+	slog.Info("table size", "db", "mysql", "table", r.Table, "data_bytes", r.DataBytes, "index_bytes", r.IndexBytes)
+	return r, nil
+}
+
+// mysqlAnalyze は table へ ANALYZE TABLE を実行する。
+func mysqlAnalyze(ctx context.Context, db queryer, table string) error {
+	// ANALYZE TABLE は結果行を返すため、読み捨ててから閉じる。テーブル名は固定の識別子のみ。
+	rows, err := db.QueryContext(ctx, "ANALYZE TABLE "+table)
+	if err != nil {
+		return fmt.Errorf("mysql analyze failed: %w", err)
+	}
+	for rows.Next() {
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("mysql analyze failed: %w", err)
+	}
+	return nil
+}
+
+// analyzeTable は cfg.Analyze なら挿入直後の table の統計を更新する（MySQL は ANALYZE TABLE、PostgreSQL は ANALYZE）。
+// 大量挿入の直後は統計が古く、オプティマイザが方式ごとに不利な計画を選びうるため、読み取りフェーズの前に揃える。
+func analyzeTable(ctx context.Context, db queryer, cfg Config, log *slog.Logger, kind, table string) error {
+	if !cfg.Analyze {
+		return nil
+	}
+	start := time.Now()
+	if kind == "mysql" {
+		if err := mysqlAnalyze(ctx, db, table); err != nil {
+			return err
+		}
+	} else if _, err := db.ExecContext(ctx, "ANALYZE "+table); err != nil {
+		return fmt.Errorf("postgres analyze failed: %w", err)
+	}
+	log.Debug("analyze done", "sec", time.Since(start).Seconds())
+	return nil
+}
+
+// checkTables は tables がすべて存在することを確認し、欠けていれば名前を挙げてエラーを返す。
+func checkTables(ctx context.Context, db *sql.DB, kind string, tables []string) error {
+	query := "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = ?"
+	if kind == "postgres" {
+		query = "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = $1"
+	}
+	var missing []string
+	for _, table := range tables {
+		var n int
+		if err := db.QueryRowContext(ctx, query, table).Scan(&n); err != nil {
+			return fmt.Errorf("%s table check failed: %w", kind, err)
+		}
+		if n == 0 {
+			missing = append(missing, table)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%s tables missing with -no-setup (run once without it to create them): %s", kind, strings.Join(missing, ", "))
+	}
+	slog.Info(kind+" tables present, setup skipped", "tables", len(tables))
+	return nil
+}
+
+// isolateFailure は方式 table の計測失敗 err の扱いを決める。
+// cfg.FailFast か ctx が中断されていれば err をそのまま返して実行全体を止め、
+// そうでなければ警告を記録し、Err に内容を入れた結果を返して残りの方式を続けさせる。
+func isolateFailure(ctx context.Context, cfg Config, kind, table string, err error) (Result, error) {
+	if cfg.FailFast || ctx.Err() != nil {
+		return Result{}, err
+	}
+	slog.Warn("strategy failed; continuing with the remaining strategies", "db", kind, "table", table, "err", err)
+	return Result{DB: kind, Table: table, Err: err.Error()}, nil
+}
+
+// emit は rs を results へ追加し、onResult があれば 1 件ずつ渡す。
+func emit(results []Result, onResult func(Result), rs ...Result) []Result {
+	for _, r := range rs {
+		if onResult != nil {
+			onResult(r)
+		}
+	}
+	return append(results, rs...)
+}
+
+// mysqlStrategies は MySQL で計測する単一テーブルの方式を返す。
+// -mysql-version-gate 時は、サーバのバージョンでは動かない方式をスキーマ初期化の前に外す。
+func mysqlStrategies(ctx context.Context, db *sql.DB, cfg Config) ([]Strategy, error) {
+	strategies := enabledStrategies(cfg, "mysql")
+	if !cfg.MySQLVersionGate {
+		return strategies, nil
+	}
+	version, err := ServerVersion(ctx, db, "mysql")
+	if err != nil {
 		return nil, err
 	}
-	if err := setupPostgres(ctx, pgDB); err != nil {
-		return nil, err
+	if cfg.PrepopulateFast && versionGated(MySQLFlavor(version, "")) && !VersionAtLeast(version, prepopulateMinMySQLVersion) {
+		return nil, fmt.Errorf("prepopulate-fast needs MySQL %s+ for WITH RECURSIVE, server is %s", prepopulateMinMySQLVersion, versionNumber(version))
+	}
+	return gateStrategies(cfg, version, strategies), nil
+}
+
+// setupMySQLSchema は MySQL の共通テーブルと strategies のテーブルを作り直す。
+func setupMySQLSchema(ctx context.Context, db *sql.DB, cfg Config, strategies []Strategy) error {
+	sctx, endSetup := startSpan(ctx, "setup")
+	err := setupMySQL(sctx, db, cfg)
+	if err == nil {
+		err = setupStrategies(sctx, db, "mysql", strategies)
+	}
+	endSetup(err)
+	return err
+}
+
+// setupPostgresSchema は PostgreSQL の共通テーブルと有効な方式のテーブルを作り直す。
+func setupPostgresSchema(ctx context.Context, db *sql.DB, cfg Config) error {
+	sctx, endSetup := startSpan(ctx, "setup")
+	err := setupPostgres(sctx, db, cfg)
+	if err == nil {
+		err = setupStrategies(sctx, db, "postgres", enabledStrategies(cfg, "postgres"))
+	}
+	endSetup(err)
+	return err
+}
+
+// setupMySQL はベンチ対象テーブルを作り直す。
+func setupMySQL(ctx context.Context, db *sql.DB, cfg Config) error {
+	stmts := mysqlSetupStmts(cfg)
+	for _, stmt := range stmts {
+		// 途中で失敗した場合は以降を実行せずエラーを返す。
+		slog.Debug("mysql setup", "stmt", stmt)
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("mysql setup failed: %w", err)
+		}
+	}
+	slog.Info("mysql setup done", "statements", len(stmts))
+	return nil
+}
+
+// mysqlSetupStmts は setupMySQL が実行する DROP / CREATE 文を実行順に返す。
+func mysqlSetupStmts(cfg Config) []string {
+	extra := columnsDDL("mysql", payloadFor(cfg).Columns())
+	// 子テーブルは外部キーで親を参照しうるため、親より先に消す。
+	stmts := fkDropStmts("mysql")
+	stmts = append(stmts,
+		"DROP TABLE IF EXISTS bench_auto",
+		"DROP TABLE IF EXISTS bench_uuid_char",
+		"DROP TABLE IF EXISTS bench_uuid_char32",
+		"DROP TABLE IF EXISTS bench_uuid_bin",
+		"DROP TABLE IF EXISTS bench_uuid_bin_swapped",
+		"DROP TABLE IF EXISTS bench_uuid_bin_native",
+		"DROP TABLE IF EXISTS bench_uuid_comb",
+		"DROP TABLE IF EXISTS bench_uuid_v1",
+		"DROP TABLE IF EXISTS bench_uuid_v1_swapped",
+		"DROP TABLE IF EXISTS bench_uuid_tenant",
+		"DROP TABLE IF EXISTS bench_hybrid",
+		"DROP TABLE IF EXISTS bench_int_shuffled",
+		"DROP TABLE IF EXISTS bench_gapless",
+		"DROP TABLE IF EXISTS bench_gapless_concurrent",
+		"DROP TABLE IF EXISTS bench_gapless_counter",
+		"DROP TABLE IF EXISTS bench_uuid_rowid",
+		"DROP TABLE IF EXISTS bench_uuid_seq",
+		"DROP TABLE IF EXISTS bench_auto_part",
+		"DROP TABLE IF EXISTS bench_uuid_part",
+		"DROP TABLE IF EXISTS bench_auto_concurrent",
+		"DROP TABLE IF EXISTS bench_uuid_concurrent",
+		"DROP TABLE IF EXISTS bench_auto_mixed",
+		"DROP TABLE IF EXISTS bench_uuid_mixed",
+		"DROP TABLE IF EXISTS bench_natural",
+		"DROP TABLE IF EXISTS bench_uuid_b64",
+		fmt.Sprintf(`CREATE TABLE bench_auto (
+			id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+			payload VARCHAR(100) NOT NULL%s
+		) ENGINE=InnoDB`, extra),
+		fmt.Sprintf(`CREATE TABLE bench_uuid_char (
+			id CHAR(36)%s NOT NULL PRIMARY KEY,
+			payload VARCHAR(100) NOT NULL%s
+		) ENGINE=InnoDB`, collationDDL(cfg.CharCollation), extra),
+		fmt.Sprintf(`CREATE TABLE bench_uuid_bin (
+			id BINARY(16) NOT NULL PRIMARY KEY,
+			payload VARCHAR(100) NOT NULL%s
+		) ENGINE=InnoDB`, extra),
+		fmt.Sprintf(`CREATE TABLE bench_uuid_tenant (
+			tenant_id BIGINT NOT NULL,
+			id BINARY(16) NOT NULL,
+			payload VARCHAR(100) NOT NULL%s,
+			PRIMARY KEY (tenant_id, id)
+		) ENGINE=InnoDB`, extra),
+		fmt.Sprintf(`CREATE TABLE bench_hybrid (
+			id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+			public_id BINARY(16) NOT NULL,
+			payload VARCHAR(100) NOT NULL%s,
+			UNIQUE KEY uk_bench_hybrid_public_id (public_id)
+		) ENGINE=InnoDB`, extra),
+	)
+	if cfg.SwappedBinary {
+		stmts = append(stmts, fmt.Sprintf(`CREATE TABLE bench_uuid_bin_swapped (
+			id BINARY(16) NOT NULL PRIMARY KEY,
+			payload VARCHAR(100) NOT NULL%s
+		) ENGINE=InnoDB`, extra))
+	}
+	if cfg.NativeUUIDFunction {
+		stmts = append(stmts, fmt.Sprintf(`CREATE TABLE bench_uuid_bin_native (
+			id BINARY(16) NOT NULL PRIMARY KEY,
+			payload VARCHAR(100) NOT NULL%s
+		) ENGINE=InnoDB`, extra))
+	}
+	if cfg.UUIDComb {
+		stmts = append(stmts, fmt.Sprintf(`CREATE TABLE bench_uuid_comb (
+			id BINARY(16) NOT NULL PRIMARY KEY,
+			payload VARCHAR(100) NOT NULL%s
+		) ENGINE=InnoDB`, extra))
+	}
+	if cfg.UUIDv1 {
+		for _, table := range []string{"bench_uuid_v1", "bench_uuid_v1_swapped"} {
+			stmts = append(stmts, fmt.Sprintf(`CREATE TABLE %s (
+			id BINARY(16) NOT NULL PRIMARY KEY,
+			payload VARCHAR(100) NOT NULL%s
+		) ENGINE=InnoDB`, table, extra))
+		}
+	}
+	if cfg.ShuffleInsertOrder {
+		// AUTO_INCREMENT を外し、クライアント採番の連番をシャッフル順で入れる。
+		stmts = append(stmts, fmt.Sprintf(`CREATE TABLE bench_int_shuffled (
+			id BIGINT NOT NULL PRIMARY KEY,
+			payload VARCHAR(100) NOT NULL%s
+		) ENGINE=InnoDB`, extra))
+	}
+	if cfg.Gapless {
+		// 番号はカウンタ表から採るため、AUTO_INCREMENT を付けない。
+		for _, table := range gaplessTables(cfg) {
+			stmts = append(stmts, fmt.Sprintf(`CREATE TABLE %s (
+			id BIGINT NOT NULL PRIMARY KEY,
+			payload VARCHAR(100) NOT NULL%s
+		) ENGINE=InnoDB`, table, extra))
+		}
+		stmts = append(stmts, gaplessCounterDDL("mysql"), gaplessCounterSeed(gaplessTables(cfg)...))
+	}
+	if cfg.ConcurrentWorkers > 0 {
+		stmts = append(stmts, fmt.Sprintf(`CREATE TABLE bench_auto_concurrent (
+			id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+			payload VARCHAR(100) NOT NULL%s
+		) ENGINE=InnoDB`, extra), fmt.Sprintf(`CREATE TABLE bench_uuid_concurrent (
+			id BINARY(16) NOT NULL PRIMARY KEY,
+			payload VARCHAR(100) NOT NULL%s
+		) ENGINE=InnoDB`, extra))
+	}
+	if cfg.MixedDuration > 0 {
+		stmts = append(stmts, fmt.Sprintf(`CREATE TABLE bench_auto_mixed (
+			id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+			payload VARCHAR(100) NOT NULL%s
+		) ENGINE=InnoDB`, extra), fmt.Sprintf(`CREATE TABLE bench_uuid_mixed (
+			id BINARY(16) NOT NULL PRIMARY KEY,
+			payload VARCHAR(100) NOT NULL%s
+		) ENGINE=InnoDB`, extra))
+	}
+	if cfg.SeqCorrelation {
+		stmts = append(stmts, fmt.Sprintf(`CREATE TABLE bench_uuid_seq (
+			id BINARY(16) NOT NULL PRIMARY KEY,
+			seq BIGINT NOT NULL,
+			payload VARCHAR(100) NOT NULL%s
+		) ENGINE=InnoDB`, extra))
+	}
+	if cfg.NaturalKey {
+		stmts = append(stmts, fmt.Sprintf(`CREATE TABLE bench_natural (
+			country CHAR(2) NOT NULL,
+			email VARCHAR(100) NOT NULL,
+			payload VARCHAR(100) NOT NULL%s,
+			PRIMARY KEY (country, email)
+		) ENGINE=InnoDB`, extra))
+	}
+	if cfg.UUIDChar32 {
+		// CHAR(36) と同じ照合順序で作り、ハイフン 4 文字の有無だけを比べる。
+		stmts = append(stmts, fmt.Sprintf(`CREATE TABLE bench_uuid_char32 (
+			id CHAR(32)%s NOT NULL PRIMARY KEY,
+			payload VARCHAR(100) NOT NULL%s
+		) ENGINE=InnoDB`, collationDDL(cfg.CharCollation), extra))
+	}
+	if cfg.UUIDBase64 {
+		// Base64 は大文字小文字を区別するため、大小を同一視する既定の照合順序では使えない。
+		stmts = append(stmts, fmt.Sprintf(`CREATE TABLE bench_uuid_b64 (
+			id VARCHAR(22) CHARACTER SET ascii COLLATE ascii_bin NOT NULL PRIMARY KEY,
+			payload VARCHAR(100) NOT NULL%s
+		) ENGINE=InnoDB`, extra))
+	}
+	if cfg.RowIDTable {
+		// 主キーも NOT NULL のユニークキーも置かない。どちらかがあると InnoDB はそれをクラスタ化に使う。
+		stmts = append(stmts, fmt.Sprintf(`CREATE TABLE bench_uuid_rowid (
+			id BINARY(16) NOT NULL,
+			payload VARCHAR(100) NOT NULL%s,
+			KEY idx_bench_uuid_rowid_id (id)
+		) ENGINE=InnoDB`, extra))
+	}
+	if cfg.ForeignKeys {
+		stmts = append(stmts, fkCreateStmts("mysql", extra)...)
+	}
+	stmts = withTableOptions("mysql", stmts, cfg.TableOptions)
+	// パーティション句の後ろにはテーブルオプションを置けないため、-table-options は適用しない。
+	if cfg.Partitions > 0 {
+		stmts = append(stmts, partitionDDL("mysql", cfg, extra)...)
+	}
+	if cfg.PayloadNullable {
+		stmts = withNullablePayload(stmts)
+	}
+	return stmts
+}
+
+// collationDDL は CHAR 列へ付ける CHARACTER SET / COLLATE 句を返す。
+// 照合順序が空ならサーバ既定に任せて何も付けない。
+func collationDDL(collation string) string {
+	if collation == "" {
+		return ""
+	}
+	return fmt.Sprintf(" CHARACTER SET %s COLLATE %s", charsetForCollation(collation), collation)
+}
+
+// charsetForCollation は照合順序名から対応する文字セット名を返す。
+// MySQL の照合順序名は "<文字セット>_..." 形式で、binary のみ例外。
+func charsetForCollation(collation string) string {
+	if cs, _, ok := strings.Cut(collation, "_"); ok {
+		return cs
+	}
+	return collation
+}
+
+// pgIndexOptions は UUID 主キーのインデックスへ付ける WITH 句を返す。未指定なら空。
+func pgIndexOptions(cfg Config) string {
+	if cfg.PGFillfactor == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" WITH (fillfactor=%d)", cfg.PGFillfactor)
+}
+
+// withTableOptions は stmts のうち CREATE TABLE 文の末尾へ、
+// opts["<db>.<テーブル名>"] に指定されたテーブルオプションを付け足す。
+func withTableOptions(db string, stmts []string, opts map[string]string) []string {
+	if len(opts) == 0 {
+		return stmts
+	}
+	out := make([]string, len(stmts))
+	for i, stmt := range stmts {
+		out[i] = stmt
+		fields := strings.Fields(stmt)
+		if len(fields) < 3 || fields[0] != "CREATE" || fields[1] != "TABLE" {
+			continue
+		}
+		if o, ok := opts[db+"."+fields[2]]; ok {
+			out[i] = stmt + " " + o
+		}
+	}
+	return out
+}
+
+// withUnlogged は stmts の CREATE TABLE を CREATE UNLOGGED TABLE に置き換えた文の一覧を返す。
+// UNLOGGED テーブルは WAL を書かないため、挿入時間から WAL のコストを除いた値を見られる。
+func withUnlogged(stmts []string) []string {
+	out := make([]string, len(stmts))
+	for i, stmt := range stmts {
+		out[i] = stmt
+		if rest, ok := strings.CutPrefix(stmt, "CREATE TABLE "); ok {
+			out[i] = "CREATE UNLOGGED TABLE " + rest
+		}
+	}
+	return out
+}
+
+// withAutovacuum は stmts の各 CREATE TABLE の直後に、そのテーブルの autovacuum_enabled を
+// value（on / off）にする ALTER TABLE を加えた文の一覧を返す。
+// off にすると計測中に自動 VACUUM / ANALYZE が割り込まず、on と比べるとその影響を見られる。
+func withAutovacuum(stmts []string, value string) []string {
+	out := make([]string, 0, len(stmts))
+	for _, stmt := range stmts {
+		out = append(out, stmt)
+		rest, ok := strings.CutPrefix(stmt, "CREATE TABLE ")
+		if !ok {
+			rest, ok = strings.CutPrefix(stmt, "CREATE UNLOGGED TABLE ")
+		}
+		if !ok {
+			continue
+		}
+		if fields := strings.Fields(rest); len(fields) > 0 {
+			out = append(out, fmt.Sprintf("ALTER TABLE %s SET (autovacuum_enabled = %s)", fields[0], value))
+		}
+	}
+	return out
+}
+
+// setupPostgres はベンチ対象テーブルを作り直す。
+func setupPostgres(ctx context.Context, db *sql.DB, cfg Config) error {
+	stmts := pgSetupStmts(cfg)
+	for _, stmt := range stmts {
+		slog.Debug("postgres setup", "stmt", stmt)
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("postgres setup failed: %w", err)
+		}
+	}
+	slog.Info("postgres setup done", "statements", len(stmts))
+	return nil
+}
+
+// pgSetupStmts は setupPostgres が実行する DROP / CREATE / ALTER 文を実行順に返す。
+func pgSetupStmts(cfg Config) []string {
+	extra := columnsDDL("postgres", payloadFor(cfg).Columns())
+	// UUID 主キーのインデックスにだけ fillfactor を指定し、ランダム挿入によるページ分割の緩和効果を見る。
+	uuidPK := pgIndexOptions(cfg)
+	// 子テーブルは外部キーで親を参照しうるため、親より先に消す。
+	stmts := fkDropStmts("postgres")
+	stmts = append(stmts,
+		"DROP TABLE IF EXISTS bench_auto",
+		"DROP TABLE IF EXISTS bench_uuid",
+		"DROP TABLE IF EXISTS bench_uuid_comb",
+		"DROP TABLE IF EXISTS bench_uuid_bytea",
+		"DROP TABLE IF EXISTS bench_uuid_tenant",
+		"DROP TABLE IF EXISTS bench_hybrid",
+		"DROP TABLE IF EXISTS bench_int_shuffled",
+		"DROP TABLE IF EXISTS bench_gapless",
+		"DROP TABLE IF EXISTS bench_gapless_concurrent",
+		"DROP TABLE IF EXISTS bench_gapless_counter",
+		"DROP TABLE IF EXISTS bench_uuid_seq",
+		"DROP TABLE IF EXISTS bench_auto_part",
+		"DROP TABLE IF EXISTS bench_uuid_part",
+		"DROP TABLE IF EXISTS bench_auto_concurrent",
+		"DROP TABLE IF EXISTS bench_uuid_concurrent",
+		"DROP TABLE IF EXISTS bench_auto_mixed",
+		"DROP TABLE IF EXISTS bench_uuid_mixed",
+		"DROP TABLE IF EXISTS bench_natural",
+		"DROP TABLE IF EXISTS bench_uuid_b64",
+		fmt.Sprintf(`CREATE TABLE bench_auto (
+			id BIGSERIAL PRIMARY KEY,
+			payload TEXT NOT NULL%s
+		)`, extra),
+		fmt.Sprintf(`CREATE TABLE bench_uuid (
+			id UUID PRIMARY KEY%s,
+			payload TEXT NOT NULL%s
+		)`, uuidPK, extra),
+		fmt.Sprintf(`CREATE TABLE bench_uuid_tenant (
+			tenant_id BIGINT NOT NULL,
+			id UUID NOT NULL,
+			payload TEXT NOT NULL%s,
+			PRIMARY KEY (tenant_id, id)%s
+		)`, extra, uuidPK),
+		fmt.Sprintf(`CREATE TABLE bench_hybrid (
+			id BIGSERIAL PRIMARY KEY,
+			public_id UUID NOT NULL UNIQUE,
+			payload TEXT NOT NULL%s
+		)`, extra),
+	)
+	if cfg.UUIDComb {
+		stmts = append(stmts, fmt.Sprintf(`CREATE TABLE bench_uuid_comb (
+			id UUID PRIMARY KEY%s,
+			payload TEXT NOT NULL%s
+		)`, uuidPK, extra))
+	}
+	if cfg.PGUUIDBytea {
+		stmts = append(stmts, fmt.Sprintf(`CREATE TABLE bench_uuid_bytea (
+			id BYTEA PRIMARY KEY%s,
+			payload TEXT NOT NULL%s
+		)`, uuidPK, extra))
+	}
+	if cfg.ConcurrentWorkers > 0 {
+		stmts = append(stmts, fmt.Sprintf(`CREATE TABLE bench_auto_concurrent (
+			id BIGSERIAL PRIMARY KEY,
+			payload TEXT NOT NULL%s
+		)`, extra), fmt.Sprintf(`CREATE TABLE bench_uuid_concurrent (
+			id UUID PRIMARY KEY%s,
+			payload TEXT NOT NULL%s
+		)`, uuidPK, extra))
+	}
+	if cfg.MixedDuration > 0 {
+		stmts = append(stmts, fmt.Sprintf(`CREATE TABLE bench_auto_mixed (
+			id BIGSERIAL PRIMARY KEY,
+			payload TEXT NOT NULL%s
+		)`, extra), fmt.Sprintf(`CREATE TABLE bench_uuid_mixed (
+			id UUID PRIMARY KEY%s,
+			payload TEXT NOT NULL%s
+		)`, uuidPK, extra))
+	}
+	if cfg.SeqCorrelation {
+		stmts = append(stmts, fmt.Sprintf(`CREATE TABLE bench_uuid_seq (
+			id UUID PRIMARY KEY%s,
+			seq BIGINT NOT NULL,
+			payload TEXT NOT NULL%s
+		)`, uuidPK, extra))
+	}
+	if cfg.ShuffleInsertOrder {
+		stmts = append(stmts, fmt.Sprintf(`CREATE TABLE bench_int_shuffled (
+			id BIGINT PRIMARY KEY,
+			payload TEXT NOT NULL%s
+		)`, extra))
+	}
+	if cfg.Gapless {
+		for _, table := range gaplessTables(cfg) {
+			stmts = append(stmts, fmt.Sprintf(`CREATE TABLE %s (
+			id BIGINT PRIMARY KEY,
+			payload TEXT NOT NULL%s
+		)`, table, extra))
+		}
+		stmts = append(stmts, gaplessCounterDDL("postgres"), gaplessCounterSeed(gaplessTables(cfg)...))
+	}
+	if cfg.NaturalKey {
+		stmts = append(stmts, fmt.Sprintf(`CREATE TABLE bench_natural (
+			country CHAR(2) NOT NULL,
+			email VARCHAR(100) NOT NULL,
+			payload TEXT NOT NULL%s,
+			PRIMARY KEY (country, email)
+		)`, extra))
+	}
+	if cfg.UUIDBase64 {
+		// "C" 照合順序でバイト順に比較し、ロケール依存の比較コストを避ける。
+		stmts = append(stmts, fmt.Sprintf(`CREATE TABLE bench_uuid_b64 (
+			id VARCHAR(22) COLLATE "C" PRIMARY KEY%s,
+			payload TEXT NOT NULL%s
+		)`, uuidPK, extra))
+	}
+	if cfg.ForeignKeys {
+		stmts = append(stmts, fkCreateStmts("postgres", extra)...)
+	}
+	stmts = withTableOptions("postgres", stmts, cfg.TableOptions)
+	if cfg.PGUnlogged {
+		stmts = withUnlogged(stmts)
+	}
+	// パーティションの親は autovacuum_enabled を持てないため、パーティションより先に付ける。
+	if cfg.PGAutovacuum != "" {
+		stmts = withAutovacuum(stmts, cfg.PGAutovacuum)
+	}
+	// パーティションテーブルは UNLOGGED にできないため、-pg-unlogged でも通常のテーブルで作る。
+	if cfg.Partitions > 0 {
+		stmts = append(stmts, partitionDDL("postgres", cfg, extra)...)
+	}
+	if cfg.PayloadNullable {
+		stmts = withNullablePayload(stmts)
+	}
+	return stmts
+}
+
+// uuidBytesProbe は BINARY(16) 往復検査に使う既知の UUID。
+// NUL、バックスラッシュ、引用符、上位ビットの立ったバイトを含め、
+// 文字コード変換や符号の扱いで壊れやすい値にしている。
+var uuidBytesProbe = uuid.UUID{0x00, 0xff, 0x5c, 0x27, 0x80, 0xe2, 0x4a, 0x7f, 0x9f, 0xbd, 0xc3, 0xa0, 0x22, 0x9d, 0x80, 0x00}
+
+// uuidBytesProbeInsert は検査行を入れる INSERT 文と引数を返す。
+// -columns-spec の追加カラムは NOT NULL のため、0 行目の値で埋めて strict モードでも通るようにする。
+func uuidBytesProbeInsert(cfg Config) (string, []any) {
+	gen := payloadFor(cfg)
+	query := insertSQL("mysql", "bench_uuid_bin", []string{"id", "payload"}, gen.Columns())
+	args := append([]any{UUIDToBytes(uuidBytesProbe), "uuid-bytes-probe"}, gen.Value(0)[1:]...)
+	return query, args
+}
+
+// validateUUIDBytes は bench_uuid_bin へ既知の UUID を 1 件書き込んで読み戻し、
+// BytesToUUID で元の値に戻ることを確認する。検査行は確認後に削除する。
+func validateUUIDBytes(ctx context.Context, db queryer, cfg Config) error {
+	query, args := uuidBytesProbeInsert(cfg)
+	if _, err := db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("uuid bytes self-test insert failed: %w", err)
+	}
+	var b []byte
+	if err := db.QueryRowContext(ctx, "SELECT id FROM bench_uuid_bin WHERE payload = ?", "uuid-bytes-probe").Scan(&b); err != nil {
+		return fmt.Errorf("uuid bytes self-test read failed: %w", err)
+	}
+	if _, err := db.ExecContext(ctx, "DELETE FROM bench_uuid_bin WHERE payload = ?", "uuid-bytes-probe"); err != nil {
+		return fmt.Errorf("uuid bytes self-test cleanup failed: %w", err)
+	}
+	got, err := BytesToUUID(b)
+	if err != nil {
+		return fmt.Errorf("uuid bytes self-test: driver returned a corrupted BINARY(16) value (check charset/driver settings): %w", err)
+	}
+	if got != uuidBytesProbe {
+		return fmt.Errorf("uuid bytes self-test: wrote %s (%x) but read back %s (%x); check charset/driver settings", uuidBytesProbe, uuidBytesProbe[:], got, b)
+	}
+	slog.Debug("uuid bytes self-test passed", "uuid", got)
+	return nil
+}
+
+// stmt は計測ループが使う文の操作。*sql.Stmt と adhocStmt が満たす。
+type stmt interface {
+	ExecContext(ctx context.Context, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, args ...any) *sql.Row
+	Close() error
+}
+
+// queryer は計測が SQL を送る相手。*sql.DB・*sql.Conn・*sql.Tx が満たし、
+// -rollback では同じ計測をトランザクションの中で行うために使う。
+type queryer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+// prepare は query をプリペアドステートメントとして準備する。
+// cfg.NoPrepare なら準備せず、毎回 query を送る adhocStmt を返す。
+func prepare(ctx context.Context, db queryer, cfg Config, query string) (stmt, error) {
+	if cfg.NoPrepare {
+		return adhocStmt{db: db, query: query}, nil
+	}
+	return db.PrepareContext(ctx, query)
+}
+
+// adhocStmt は ORM のように呼び出しごとに SQL 文を送る。
+// ドライバ側でも準備させないよう、DSN でクライアント側の埋め込み
+// (MySQL: interpolateParams、pgx: default_query_exec_mode=exec) を合わせて指定する。
+type adhocStmt struct {
+	db    queryer
+	query string
+}
+
+func (s adhocStmt) ExecContext(ctx context.Context, args ...any) (sql.Result, error) {
+	return s.db.ExecContext(ctx, s.query, args...)
+}
+
+func (s adhocStmt) QueryContext(ctx context.Context, args ...any) (*sql.Rows, error) {
+	return s.db.QueryContext(ctx, s.query, args...)
+}
+
+func (s adhocStmt) QueryRowContext(ctx context.Context, args ...any) *sql.Row {
+	return s.db.QueryRowContext(ctx, s.query, args...)
+}
+
+func (s adhocStmt) Close() error { return nil }
+
+// queryContext は cfg.QueryTimeout が正なら、1 文ぶんの期限を付けた ctx を返す。
+// 0 なら ctx をそのまま返し、全体のタイムアウトだけに任せる。
+func queryContext(ctx context.Context, cfg Config) (context.Context, context.CancelFunc) {
+	if cfg.QueryTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, cfg.QueryTimeout)
+}
+
+// queryTimeoutError は qctx の期限切れで失敗した err に、-query-timeout を超えたことを付け加える。
+// 親の ctx が中断されている場合やそれ以外の失敗は err をそのまま返す。
+func queryTimeoutError(ctx, qctx context.Context, cfg Config, err error) error {
+	if err == nil || ctx.Err() != nil || !errors.Is(qctx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+	return fmt.Errorf("query exceeded -query-timeout %s: %w", cfg.QueryTimeout, err)
+}
+
+// withQueryTimeout は fn(qctx, i) を 1 文ぶんの期限付きで呼ぶ。
+func withQueryTimeout(ctx context.Context, cfg Config, i int, fn func(ctx context.Context, i int) error) error {
+	qctx, cancel := queryContext(ctx, cfg)
+	defer cancel()
+	return queryTimeoutError(ctx, qctx, cfg, fn(qctx, i))
+}
+
+// insertCheckpoint は挿入進捗をログへ出す間隔（件数）。
+const insertCheckpoint = 10000
+
+// insertLoop は insert(ctx, i) を繰り返し呼び、挿入件数と所要秒数を返す。
+// cfg.InsertDuration が正なら件数ではなく経過時間で打ち切る。
+// insert へ渡す ctx には 1 行ごとに cfg.QueryTimeout の期限が付く。
+// ctx が中断されたら次の挿入を行わず ctx.Err() を返す。
+// 進捗は insertCheckpoint 件ごとに Debug レベルで記録する。
+// ctx に Tracer が載っていれば全体を insert スパンで囲む。
+// -latency-dump 指定時は 1 行ごとの所要時間を insert フェーズのファイルへ書き出す。
+func insertLoop(ctx context.Context, cfg Config, log *slog.Logger, insert func(ctx context.Context, i int) error) (n int, sec float64, err error) {
+	ctx, end := startSpan(ctx, "insert")
+	defer func() { end(err) }()
+	record := latencyRecorder(ctx, "insert")
+	total := cfg.Rows
+	if cfg.InsertDuration > 0 {
+		total = 0
+	}
+	progress := progressReporter(ctx, "insert", total)
+	log.Debug("insert start", "rows", cfg.Rows, "duration", cfg.InsertDuration)
+	start := time.Now()
+	for cfg.InsertDuration > 0 || n < cfg.Rows {
+		if cfg.InsertDuration > 0 && time.Since(start) >= cfg.InsertDuration {
+			break
+		}
+		// 中断時は次の DB 呼び出しを待たずに抜ける。
+		if err := ctx.Err(); err != nil {
+			return n, time.Since(start).Seconds(), err
+		}
+		opStart := time.Now()
+		if err := simulateRTT(ctx, cfg); err != nil {
+			return n, time.Since(start).Seconds(), err
+		}
+		if err := withQueryTimeout(ctx, cfg, n, insert); err != nil {
+			return n, time.Since(start).Seconds(), err
+		}
+		if record != nil {
+			record(time.Since(opStart))
+		}
+		n++
+		if progress != nil {
+			progress(n)
+		}
+		if n%insertCheckpoint == 0 {
+			log.Debug("insert progress", "rows", n, "elapsed", time.Since(start))
+		}
+	}
+	sec = time.Since(start).Seconds()
+	log.Info("insert done", "rows", n, "sec", sec)
+	return n, sec, nil
+}
+
+// minLookupRounds は -target-error-margin で収束判定を始める最小ラウンド数。
+const minLookupRounds = 3
+
+// pointTiming は pointLoop の計測結果。
+type pointTiming struct {
+	// Seconds は cfg.Aggregate で集計した 1 ラウンドの秒数。
+	Seconds float64
+	// Rounds は -target-error-margin 指定時の実行ラウンド数。未指定なら 0。
+	Rounds int
+	// Warm / Steady は cfg.PointWarmup が正のとき、最初のラウンドの先頭 cfg.PointWarmup 件と残りの秒数。
+	Warm   float64
+	Steady float64
+}
+
+// pointLoop は lookup(ctx, 0..n-1) を 1 ラウンドとして実行し、1 ラウンドの所要秒数を返す。
+// cfg.TargetErrorMargin が正なら、ラウンド時間の変動係数がその値を下回るまで
+// (最大 cfg.MaxLookupRounds まで) 繰り返し、cfg.Aggregate で集計した秒数と実行ラウンド数を返す。
+// それ以外のときラウンド数は 0 を返す。
+// cfg.PointWarmup が正なら、最初のラウンドを先頭 cfg.PointWarmup 件（準備直後の文の初回実行を含む）と
+// 残りとに分けた秒数も返す。ctx に Tracer が載っていれば全体を point スパンで囲む。
+// -latency-dump 指定時は 1 件ごとの所要時間を point フェーズのファイルへ書き出す。
+func pointLoop(ctx context.Context, cfg Config, log *slog.Logger, n int, lookup func(ctx context.Context, i int) error) (_ pointTiming, err error) {
+	ctx, end := startSpan(ctx, "point")
+	defer func() { end(err) }()
+	record := latencyRecorder(ctx, "point")
+	progress := progressReporter(ctx, "point", n)
+	log.Debug("point lookup start", "lookups", n)
+	var (
+		rounds []float64
+		t      pointTiming
+	)
+	for {
+		start := time.Now()
+		warmEnd := start
+		for i := 0; i < n; i++ {
+			if err := ctx.Err(); err != nil {
+				return pointTiming{}, err
+			}
+			opStart := time.Now()
+			if err := withQueryTimeout(ctx, cfg, i, lookup); err != nil {
+				return pointTiming{}, err
+			}
+			if record != nil {
+				record(time.Since(opStart))
+			}
+			if progress != nil {
+				progress(i + 1)
+			}
+			if len(rounds) == 0 && i+1 == cfg.PointWarmup {
+				warmEnd = time.Now()
+			}
+		}
+		end := time.Now()
+		if len(rounds) == 0 && cfg.PointWarmup > 0 {
+			if cfg.PointWarmup >= n {
+				warmEnd = end
+			}
+			t.Warm, t.Steady = warmEnd.Sub(start).Seconds(), end.Sub(warmEnd).Seconds()
+		}
+		rounds = append(rounds, end.Sub(start).Seconds())
+		if cfg.TargetErrorMargin <= 0 {
+			break
+		}
+		if len(rounds) >= minLookupRounds && RelStdDev(rounds) < cfg.TargetErrorMargin {
+			break
+		}
+		if len(rounds) >= cfg.MaxLookupRounds {
+			log.Warn("point lookup did not reach target error margin", "rounds", len(rounds), "rel_stddev", RelStdDev(rounds), "target", cfg.TargetErrorMargin)
+			break
+		}
+	}
+	t.Seconds = Aggregate(cfg.Aggregate, rounds, cfg.AggregateTrim)
+	log.Info("point lookup done", "lookups", n, "rounds", len(rounds), "sec", t.Seconds, "rel_stddev", RelStdDev(rounds), "warm_sec", t.Warm, "steady_sec", t.Steady)
+	if cfg.TargetErrorMargin > 0 {
+		t.Rounds = len(rounds)
+	}
+	return t, nil
+}
+
+// readbackLoop は「1 件挿入し、直後にそのキーで読み戻す」操作を cfg.Lookups 回繰り返し、
+// 合計秒数を返す。cfg.InsertReadback が偽なら何もせず 0 を返す。
+// readback(ctx, i) は挿入計測で使った行番号と重ならないよう、呼び出し側で i をずらして使う。
+func readbackLoop(ctx context.Context, cfg Config, log *slog.Logger, readback func(ctx context.Context, i int) error) (float64, error) {
+	if !cfg.InsertReadback {
+		return 0, nil
+	}
+	log.Debug("insert readback start", "count", cfg.Lookups)
+	start := time.Now()
+	for i := 0; i < cfg.Lookups; i++ {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		if err := simulateRTT(ctx, cfg); err != nil {
+			return 0, err
+		}
+		if err := withQueryTimeout(ctx, cfg, i, readback); err != nil {
+			return 0, err
+		}
+	}
+	sec := time.Since(start).Seconds()
+	log.Info("insert readback done", "count", cfg.Lookups, "sec", sec)
+	return sec, nil
+}
+
+// valueBytes は範囲検索で読み出した値 1 つぶんのバイト数を返す。
+// 文字列とバイト列は長さ、UUID は 16、整数は 8 とし、プロトコルのヘッダなどは含めない。
+func valueBytes(v any) int64 {
+	switch v := v.(type) {
+	case string:
+		return int64(len(v))
+	case []byte:
+		return int64(len(v))
+	case uuid.UUID:
+		return int64(len(v))
+	case int64:
+		return 8
+	}
+	return 0
+}
+
+// benchMySQLAuto は MySQL の AUTO_INCREMENT 主キーを計測する。
+func benchMySQLAuto(ctx context.Context, db *sql.DB, cfg Config) (Result, error) {
+	log := slog.With("db", "mysql", "table", "bench_auto")
+	gen := payloadFor(cfg)
+	insertStmt, err := prepare(ctx, db, cfg, insertSQL("mysql", "bench_auto", []string{"payload"}, gen.Columns()))
+	if err != nil {
+		return Result{}, err
+	}
+	defer insertStmt.Close()
+
+	// Insert 計測: 指定件数（-insert-duration 指定時は指定時間）ぶん連続投入する。
+	inserted, insertSec, err := insertLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		_, err := insertStmt.ExecContext(ctx, insertArgs(gen, i)...)
+		return err
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	if err := analyzeTable(ctx, db, cfg, log, "mysql", "bench_auto"); err != nil {
+		return Result{}, err
+	}
+
+	// 参照用 ID 一覧を主キー順で収集する。
+	ids := make([]int64, 0, inserted)
+	rowsRes, err := db.QueryContext(ctx, "SELECT id FROM bench_auto ORDER BY id")
+	if err != nil {
+		return Result{}, err
+	}
+	for rowsRes.Next() {
+		var id int64
+		if err := rowsRes.Scan(&id); err != nil {
+			rowsRes.Close()
+			return Result{}, err
+		}
+		ids = append(ids, id)
+	}
+	rowsRes.Close()
+
+	// 点検索は先頭から lookups 件をサンプルとして使う。
+	sample := ids
+	if len(sample) > cfg.Lookups {
+		sample = sample[:cfg.Lookups]
+	}
+
+	selectStmt, err := prepare(ctx, db, cfg, "SELECT payload FROM bench_auto WHERE id = ?")
+	if err != nil {
+		return Result{}, err
+	}
+	defer selectStmt.Close()
+
+	// Point Lookup 計測: 主キー完全一致検索の反復時間。
+	point, err := pointLoop(ctx, cfg, log, len(sample), func(ctx context.Context, i int) error {
+		return scanPayload(cfg, gen, i, sample[i], selectStmt.QueryRowContext(ctx, sample[i]))
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	// Hot/Cold 計測: 直近に挿入した行と古い行とで点検索時間を分けて計る。
+	hotSec, coldSec, err := hotColdLoop(ctx, cfg, log, ids, func(ctx context.Context, id int64) error {
+		var payload sql.NullString
+		return selectStmt.QueryRowContext(ctx, id).Scan(&payload)
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	// 範囲検索の下限/上限は全 ID の 25%〜75% 点から決める。
+	lo, hi := int64(0), int64(0)
+	if len(ids) > 0 {
+		lo = ids[len(ids)/4]
+		hi = ids[(len(ids)*3)/4]
+	}
+	log.Debug("range scan start")
+	_, endRange := startSpan(ctx, "range")
+	rctx, cancel := queryContext(ctx, cfg)
+	defer cancel()
+	start := time.Now()
+	var c int64
+	// COUNT(*) は結果サイズに依存せず比較しやすい。
+	if err := db.QueryRowContext(rctx, "SELECT COUNT(*) FROM bench_auto WHERE id BETWEEN ? AND ?", lo, hi).Scan(&c); err != nil {
+		return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
+	}
+	rangeSec := time.Since(start).Seconds()
+	log.Info("range scan done", "sec", rangeSec)
+	endRange(nil)
+	rangeUsedIndex, err := explainRange(ctx, db, cfg, log, "mysql", "SELECT COUNT(*) FROM bench_auto WHERE id BETWEEN ? AND ?", lo, hi)
+	if err != nil {
+		return Result{}, err
+	}
+
+	// Insert→Readback 計測: 採番された ID を LastInsertId で受け取ってから読み戻す。
+	readbackSec, err := readbackLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		res, err := insertStmt.ExecContext(ctx, insertArgs(gen, inserted+i)...)
+		if err != nil {
+			return err
+		}
+		id, err := res.LastInsertId()
+		if err != nil {
+			return err
+		}
+		var payload sql.NullString
+		return selectStmt.QueryRowContext(ctx, id).Scan(&payload)
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		DB:                    "mysql",
+		Table:                 "bench_auto",
+		InsertRows:            inserted,
+		InsertSeconds:         insertSec,
+		PointLookupCount:      len(sample),
+		PointSeconds:          point.Seconds,
+		PointRounds:           point.Rounds,
+		PointWarmSeconds:      point.Warm,
+		PointSteadySeconds:    point.Steady,
+		HotPointSeconds:       hotSec,
+		ColdPointSeconds:      coldSec,
+		RangeSeconds:          rangeSec,
+		RangeUsedIndex:        rangeUsedIndex,
+		InsertReadbackSeconds: readbackSec,
+	}, nil
+}
+
+// benchMySQLUUIDChar は MySQL の CHAR(36) UUID 主キーを計測する。
+func benchMySQLUUIDChar(ctx context.Context, db *sql.DB, cfg Config) (Result, error) {
+	log := slog.With("db", "mysql", "table", "bench_uuid_char")
+	gen := payloadFor(cfg)
+	insertStmt, err := prepare(ctx, db, cfg, insertSQL("mysql", "bench_uuid_char", []string{"id", "payload"}, gen.Columns()))
+	if err != nil {
+		return Result{}, err
+	}
+	defer insertStmt.Close()
+
+	// ランダム UUID 文字列を生成しながら挿入する。
+	ids := make([]string, 0, cfg.Rows)
+	inserted, insertSec, err := insertLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		id := uuidCharKey(cfg, i)
+		ids = append(ids, id)
+		_, err := insertStmt.ExecContext(ctx, insertArgs(gen, i, id)...)
+		return err
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	if err := analyzeTable(ctx, db, cfg, log, "mysql", "bench_uuid_char"); err != nil {
+		return Result{}, err
+	}
+
+	// 点検索サンプル数は lookups 件までに制限する。
+	sample := ids
+	if len(sample) > cfg.Lookups {
+		sample = sample[:cfg.Lookups]
+	}
+	selectStmt, err := prepare(ctx, db, cfg, "SELECT payload FROM bench_uuid_char WHERE id = ?")
+	if err != nil {
+		return Result{}, err
+	}
+	defer selectStmt.Close()
+
+	// Point Lookup 計測: UUID 文字列キーの完全一致検索。
+	point, err := pointLoop(ctx, cfg, log, len(sample), func(ctx context.Context, i int) error {
+		return scanPayload(cfg, gen, i, sample[i], selectStmt.QueryRowContext(ctx, sample[i]))
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	// Hot/Cold 計測: 直近に挿入した行と古い行とで点検索時間を分けて計る。
+	hotSec, coldSec, err := hotColdLoop(ctx, cfg, log, ids, func(ctx context.Context, id string) error {
+		var payload sql.NullString
+		return selectStmt.QueryRowContext(ctx, id).Scan(&payload)
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	log.Debug("range scan start")
+	_, endRange := startSpan(ctx, "range")
+	var rangeBytes int64
+	rctx, cancel := queryContext(ctx, cfg)
+	defer cancel()
+	// 範囲代替として ORDER BY + LIMIT の読み出し時間を計測する。
+	start := time.Now()
+	rowsRes, err := db.QueryContext(rctx, "SELECT id FROM bench_uuid_char ORDER BY id LIMIT 10000")
+	if err != nil {
+		return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
+	}
+	for rowsRes.Next() {
+		var id string
+		if err := rowsRes.Scan(&id); err != nil {
+			rowsRes.Close()
+			return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
+		}
+		rangeBytes += valueBytes(id)
+	}
+	rowsRes.Close()
+	rangeSec := time.Since(start).Seconds()
+	log.Info("range scan done", "sec", rangeSec)
+	endRange(nil)
+	rangeUsedIndex, err := explainRange(ctx, db, cfg, log, "mysql", "SELECT id FROM bench_uuid_char ORDER BY id LIMIT 10000")
+	if err != nil {
+		return Result{}, err
+	}
+
+	// Insert→Readback 計測: キーは挿入前に手元で決まるため、挿入後すぐそのキーで読み戻せる。
+	readbackSec, err := readbackLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		id := uuidCharKey(cfg, inserted+i)
+		if _, err := insertStmt.ExecContext(ctx, insertArgs(gen, inserted+i, id)...); err != nil {
+			return err
+		}
+		var payload sql.NullString
+		return selectStmt.QueryRowContext(ctx, id).Scan(&payload)
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		DB:                    "mysql",
+		Table:                 "bench_uuid_char",
+		InsertRows:            inserted,
+		InsertSeconds:         insertSec,
+		PointLookupCount:      len(sample),
+		PointSeconds:          point.Seconds,
+		PointRounds:           point.Rounds,
+		PointWarmSeconds:      point.Warm,
+		PointSteadySeconds:    point.Steady,
+		HotPointSeconds:       hotSec,
+		ColdPointSeconds:      coldSec,
+		RangeSeconds:          rangeSec,
+		RangeBytes:            rangeBytes,
+		RangeUsedIndex:        rangeUsedIndex,
+		InsertReadbackSeconds: readbackSec,
+	}, nil
+}
+
+// benchMySQLUUIDBin は MySQL の BINARY(16) UUID 主キーを計測する。
+func benchMySQLUUIDBin(ctx context.Context, db *sql.DB, cfg Config) (Result, error) {
+	return benchMySQLUUIDBinary(ctx, db, cfg, "bench_uuid_bin", uuidBinKey)
+}
+
+// benchMySQLUUIDBinSwapped は UUID_TO_BIN(uuid, 1) と同じ並びの BINARY(16) 主キーを計測する。
+// 時刻フィールドを先頭へ移すのは UUIDv1 で効く並べ替えで、乱数の v4 では並びは変わらない。
+func benchMySQLUUIDBinSwapped(ctx context.Context, db *sql.DB, cfg Config) (Result, error) {
+	return benchMySQLUUIDBinary(ctx, db, cfg, "bench_uuid_bin_swapped", func(cfg Config, i int) []byte {
+		return UUIDToSwappedBytes(newUUID(cfg, i))
+	})
+}
+
+// benchMySQLUUIDComb は先頭 6 バイトに時刻を入れた COMB 形式の BINARY(16) 主キーを計測する。
+func benchMySQLUUIDComb(ctx context.Context, db *sql.DB, cfg Config) (Result, error) {
+	return withTimeRegressions(combRegressions, func() (Result, error) {
+		return benchMySQLUUIDBinary(ctx, db, cfg, "bench_uuid_comb", func(cfg Config, i int) []byte {
+			return UUIDToBytes(uuidCombKey(cfg, i))
+		})
+	})
+}
+
+// benchMySQLUUIDBinary は key で作った i 行目の 16 バイトのキーを table の BINARY(16) 主キーへ入れて計測する。
+func benchMySQLUUIDBinary(ctx context.Context, db *sql.DB, cfg Config, table string, key func(cfg Config, i int) []byte) (Result, error) {
+	log := slog.With("db", "mysql", "table", table)
+	gen := payloadFor(cfg)
+	insertStmt, err := prepare(ctx, db, cfg, insertSQL("mysql", table, []string{"id", "payload"}, gen.Columns()))
+	if err != nil {
+		return Result{}, err
+	}
+	defer insertStmt.Close()
+
+	// UUID の 16 バイト表現を挿入する。
+	ids := make([][]byte, 0, cfg.Rows)
+	inserted, insertSec, err := insertLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		b := key(cfg, i)
+		ids = append(ids, b)
+		_, err := insertStmt.ExecContext(ctx, insertArgs(gen, i, b)...)
+		return err
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	if err := analyzeTable(ctx, db, cfg, log, "mysql", table); err != nil {
+		return Result{}, err
+	}
+
+	// 点検索サンプル数は lookups 件までに制限する。
+	sample := ids
+	if len(sample) > cfg.Lookups {
+		sample = sample[:cfg.Lookups]
+	}
+	selectStmt, err := prepare(ctx, db, cfg, "SELECT payload FROM "+table+" WHERE id = ?")
+	if err != nil {
+		return Result{}, err
+	}
+	defer selectStmt.Close()
+
+	// Point Lookup 計測: BINARY(16) キーの完全一致検索。
+	point, err := pointLoop(ctx, cfg, log, len(sample), func(ctx context.Context, i int) error {
+		return scanPayload(cfg, gen, i, sample[i], selectStmt.QueryRowContext(ctx, sample[i]))
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	// Hot/Cold 計測: 直近に挿入した行と古い行とで点検索時間を分けて計る。
+	hotSec, coldSec, err := hotColdLoop(ctx, cfg, log, ids, func(ctx context.Context, id []byte) error {
+		var payload sql.NullString
+		return selectStmt.QueryRowContext(ctx, id).Scan(&payload)
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	log.Debug("range scan start")
+	_, endRange := startSpan(ctx, "range")
+	var rangeBytes int64
+	rctx, cancel := queryContext(ctx, cfg)
+	defer cancel()
+	// 範囲代替として ORDER BY + LIMIT の読み出し時間を計測する。
+	start := time.Now()
+	rowsRes, err := db.QueryContext(rctx, "SELECT id FROM "+table+" ORDER BY id LIMIT 10000")
+	if err != nil {
+		return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
+	}
+	for rowsRes.Next() {
+		var b []byte
+		if err := rowsRes.Scan(&b); err != nil {
+			rowsRes.Close()
+			return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
+		}
+		rangeBytes += valueBytes(b)
+	}
+	rowsRes.Close()
+	rangeSec := time.Since(start).Seconds()
+	log.Info("range scan done", "sec", rangeSec)
+	endRange(nil)
+	rangeUsedIndex, err := explainRange(ctx, db, cfg, log, "mysql", "SELECT id FROM "+table+" ORDER BY id LIMIT 10000")
+	if err != nil {
+		return Result{}, err
+	}
+
+	// Insert→Readback 計測: キーは挿入前に手元で決まるため、挿入後すぐそのキーで読み戻せる。
+	readbackSec, err := readbackLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		id := key(cfg, inserted+i)
+		if _, err := insertStmt.ExecContext(ctx, insertArgs(gen, inserted+i, id)...); err != nil {
+			return err
+		}
+		var payload sql.NullString
+		return selectStmt.QueryRowContext(ctx, id).Scan(&payload)
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		DB:                    "mysql",
+		Table:                 table,
+		InsertRows:            inserted,
+		InsertSeconds:         insertSec,
+		PointLookupCount:      len(sample),
+		PointSeconds:          point.Seconds,
+		PointRounds:           point.Rounds,
+		PointWarmSeconds:      point.Warm,
+		PointSteadySeconds:    point.Steady,
+		HotPointSeconds:       hotSec,
+		ColdPointSeconds:      coldSec,
+		RangeSeconds:          rangeSec,
+		RangeBytes:            rangeBytes,
+		RangeUsedIndex:        rangeUsedIndex,
+		InsertReadbackSeconds: readbackSec,
+	}, nil
+}
+
+// benchMySQLUUIDSeq は BINARY(16) UUID 主キーに挿入順の seq 列を持たせて計測する。
+// 主キー順に読んだときの seq の並びから、ランダムキーで挿入順がどれだけ散らばるかを数値化する。
+func benchMySQLUUIDSeq(ctx context.Context, db *sql.DB, cfg Config) (Result, error) {
+	log := slog.With("db", "mysql", "table", "bench_uuid_seq")
+	gen := payloadFor(cfg)
+	insertStmt, err := prepare(ctx, db, cfg, insertSQL("mysql", "bench_uuid_seq", []string{"id", "seq", "payload"}, gen.Columns()))
+	if err != nil {
+		return Result{}, err
+	}
+	defer insertStmt.Close()
+
+	// UUID を 16 バイト表現へ変換して挿入する。
+	ids := make([][]byte, 0, cfg.Rows)
+	inserted, insertSec, err := insertLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		b := uuidBinKey(cfg, i)
+		ids = append(ids, b)
+		_, err := insertStmt.ExecContext(ctx, insertArgs(gen, i, b, int64(i+1))...)
+		return err
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	if err := analyzeTable(ctx, db, cfg, log, "mysql", "bench_uuid_seq"); err != nil {
+		return Result{}, err
+	}
+
+	// 点検索サンプル数は lookups 件までに制限する。
+	sample := ids
+	if len(sample) > cfg.Lookups {
+		sample = sample[:cfg.Lookups]
+	}
+	selectStmt, err := prepare(ctx, db, cfg, "SELECT payload FROM bench_uuid_seq WHERE id = ?")
+	if err != nil {
+		return Result{}, err
+	}
+	defer selectStmt.Close()
+
+	// Point Lookup 計測: BINARY(16) キーの完全一致検索。
+	point, err := pointLoop(ctx, cfg, log, len(sample), func(ctx context.Context, i int) error {
+		return scanPayload(cfg, gen, i, sample[i], selectStmt.QueryRowContext(ctx, sample[i]))
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	// 主キー順の全件走査で seq（挿入順）を読み出し、所要時間と挿入順との相関を求める。
+	log.Debug("range scan start")
+	_, endRange := startSpan(ctx, "range")
+	var rangeBytes int64
+	rctx, cancel := queryContext(ctx, cfg)
+	defer cancel()
+	start := time.Now()
+	rowsRes, err := db.QueryContext(rctx, "SELECT seq FROM bench_uuid_seq ORDER BY id")
+	if err != nil {
+		return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
+	}
+	seqs := make([]int64, 0, inserted)
+	for rowsRes.Next() {
+		var seq int64
+		if err := rowsRes.Scan(&seq); err != nil {
+			rowsRes.Close()
+			return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
+		}
+		rangeBytes += valueBytes(seq)
+		seqs = append(seqs, seq)
+	}
+	rowsRes.Close()
+	if err := rowsRes.Err(); err != nil {
+		return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
+	}
+	rangeSec := time.Since(start).Seconds()
+	corr := SpearmanRank(seqs)
+	log.Info("range scan done", "sec", rangeSec, "seq_correlation", corr)
+	endRange(nil)
+
+	// Insert→Readback 計測: キーは挿入前に手元で決まるため、挿入後すぐそのキーで読み戻せる。
+	readbackSec, err := readbackLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		id := uuidBinKey(cfg, inserted+i)
+		if _, err := insertStmt.ExecContext(ctx, insertArgs(gen, inserted+i, id, int64(inserted+i+1))...); err != nil {
+			return err
+		}
+		var payload sql.NullString
+		return selectStmt.QueryRowContext(ctx, id).Scan(&payload)
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		DB:                    "mysql",
+		Table:                 "bench_uuid_seq",
+		InsertRows:            inserted,
+		InsertSeconds:         insertSec,
+		PointLookupCount:      len(sample),
+		PointSeconds:          point.Seconds,
+		PointRounds:           point.Rounds,
+		PointWarmSeconds:      point.Warm,
+		PointSteadySeconds:    point.Steady,
+		RangeSeconds:          rangeSec,
+		RangeBytes:            rangeBytes,
+		InsertReadbackSeconds: readbackSec,
+		SeqCorrelation:        &corr,
+	}, nil
+}
+
+// benchMySQLUUIDRowID は主キーを持たず BINARY(16) UUID を非ユニーク二次インデックスで引く構成を計測する。
+// InnoDB は隠し行 ID（DB_ROW_ID）で行をクラスタ化するため、SQLite の rowid テーブルに相当する。
+func benchMySQLUUIDRowID(ctx context.Context, db *sql.DB, cfg Config) (Result, error) {
+	log := slog.With("db", "mysql", "table", "bench_uuid_rowid")
+	gen := payloadFor(cfg)
+	insertStmt, err := prepare(ctx, db, cfg, insertSQL("mysql", "bench_uuid_rowid", []string{"id", "payload"}, gen.Columns()))
+	if err != nil {
+		return Result{}, err
+	}
+	defer insertStmt.Close()
+
+	// UUID を 16 バイト表現へ変換して挿入する。
+	ids := make([][]byte, 0, cfg.Rows)
+	inserted, insertSec, err := insertLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		b := uuidBinKey(cfg, i)
+		ids = append(ids, b)
+		_, err := insertStmt.ExecContext(ctx, insertArgs(gen, i, b)...)
+		return err
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	if err := analyzeTable(ctx, db, cfg, log, "mysql", "bench_uuid_rowid"); err != nil {
+		return Result{}, err
+	}
+
+	// 点検索サンプル数は lookups 件までに制限する。
+	sample := ids
+	if len(sample) > cfg.Lookups {
+		sample = sample[:cfg.Lookups]
+	}
+	selectStmt, err := prepare(ctx, db, cfg, "SELECT payload FROM bench_uuid_rowid WHERE id = ?")
+	if err != nil {
+		return Result{}, err
+	}
+	defer selectStmt.Close()
+
+	// Point Lookup 計測: 二次インデックス経由で隠し行 ID を辿る UUID 完全一致検索。
+	point, err := pointLoop(ctx, cfg, log, len(sample), func(ctx context.Context, i int) error {
+		return scanPayload(cfg, gen, i, sample[i], selectStmt.QueryRowContext(ctx, sample[i]))
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	log.Debug("range scan start")
+	_, endRange := startSpan(ctx, "range")
+	var rangeBytes int64
+	rctx, cancel := queryContext(ctx, cfg)
+	defer cancel()
+	// 範囲代替として ORDER BY + LIMIT の読み出し時間を計測する。
+	start := time.Now()
+	rowsRes, err := db.QueryContext(rctx, "SELECT id FROM bench_uuid_rowid ORDER BY id LIMIT 10000")
+	if err != nil {
+		return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
+	}
+	for rowsRes.Next() {
+		var b []byte
+		if err := rowsRes.Scan(&b); err != nil {
+			rowsRes.Close()
+			return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
+		}
+		rangeBytes += valueBytes(b)
+	}
+	rowsRes.Close()
+	rangeSec := time.Since(start).Seconds()
+	log.Info("range scan done", "sec", rangeSec)
+	endRange(nil)
+	rangeUsedIndex, err := explainRange(ctx, db, cfg, log, "mysql", "SELECT id FROM bench_uuid_rowid ORDER BY id LIMIT 10000")
+	if err != nil {
+		return Result{}, err
+	}
+
+	// Insert→Readback 計測: キーは挿入前に手元で決まるため、挿入後すぐそのキーで読み戻せる。
+	readbackSec, err := readbackLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		id := uuidBinKey(cfg, inserted+i)
+		if _, err := insertStmt.ExecContext(ctx, insertArgs(gen, inserted+i, id)...); err != nil {
+			return err
+		}
+		var payload sql.NullString
+		return selectStmt.QueryRowContext(ctx, id).Scan(&payload)
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		DB:                    "mysql",
+		Table:                 "bench_uuid_rowid",
+		InsertRows:            inserted,
+		InsertSeconds:         insertSec,
+		PointLookupCount:      len(sample),
+		PointSeconds:          point.Seconds,
+		PointRounds:           point.Rounds,
+		PointWarmSeconds:      point.Warm,
+		PointSteadySeconds:    point.Steady,
+		RangeSeconds:          rangeSec,
+		RangeBytes:            rangeBytes,
+		RangeUsedIndex:        rangeUsedIndex,
+		InsertReadbackSeconds: readbackSec,
+	}, nil
+}
+
+// benchPGAuto は PostgreSQL の BIGSERIAL 主キーを計測する。
+func benchPGAuto(ctx context.Context, db *sql.DB, cfg Config) (Result, error) {
+	log := slog.With("db", "postgres", "table", "bench_auto")
+	gen := payloadFor(cfg)
+	insertStmt, err := prepare(ctx, db, cfg, insertSQL("postgres", "bench_auto", []string{"payload"}, gen.Columns()))
+	if err != nil {
+		return Result{}, err
+	}
+	defer insertStmt.Close()
+
+	// Insert 計測: 指定件数（-insert-duration 指定時は指定時間）ぶん連続投入する。
+	inserted, insertSec, err := insertLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		_, err := insertStmt.ExecContext(ctx, insertArgs(gen, i)...)
+		return err
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	if err := analyzeTable(ctx, db, cfg, log, "postgres", "bench_auto"); err != nil {
+		return Result{}, err
+	}
+
+	// 参照用 ID 一覧を主キー順で収集する。
+	ids := make([]int64, 0, inserted)
+	rowsRes, err := db.QueryContext(ctx, "SELECT id FROM bench_auto ORDER BY id")
+	if err != nil {
+		return Result{}, err
+	}
+	for rowsRes.Next() {
+		var id int64
+		if err := rowsRes.Scan(&id); err != nil {
+			rowsRes.Close()
+			return Result{}, err
+		}
+		ids = append(ids, id)
+	}
+	rowsRes.Close()
+
+	// 点検索は先頭から lookups 件をサンプルとして使う。
+	sample := ids
+	if len(sample) > cfg.Lookups {
+		sample = sample[:cfg.Lookups]
+	}
+	selectStmt, err := prepare(ctx, db, cfg, "SELECT payload FROM bench_auto WHERE id = $1")
+	if err != nil {
+		return Result{}, err
+	}
+	defer selectStmt.Close()
+
+	// Point Lookup 計測: 主キー完全一致検索の反復時間。
+	point, err := pointLoop(ctx, cfg, log, len(sample), func(ctx context.Context, i int) error {
+		return scanPayload(cfg, gen, i, sample[i], selectStmt.QueryRowContext(ctx, sample[i]))
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	// Hot/Cold 計測: 直近に挿入した行と古い行とで点検索時間を分けて計る。
+	hotSec, coldSec, err := hotColdLoop(ctx, cfg, log, ids, func(ctx context.Context, id int64) error {
+		var payload sql.NullString
+		return selectStmt.QueryRowContext(ctx, id).Scan(&payload)
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	// 範囲検索の下限/上限は全 ID の 25%〜75% 点から決める。
+	lo, hi := int64(0), int64(0)
+	if len(ids) > 0 {
+		lo = ids[len(ids)/4]
+		hi = ids[(len(ids)*3)/4]
+	}
+	log.Debug("range scan start")
+	_, endRange := startSpan(ctx, "range")
+	rctx, cancel := queryContext(ctx, cfg)
+	defer cancel()
+	start := time.Now()
+	var c int64
+	// COUNT(*) は結果サイズに依存せず比較しやすい。
+	if err := db.QueryRowContext(rctx, "SELECT COUNT(*) FROM bench_auto WHERE id BETWEEN $1 AND $2", lo, hi).Scan(&c); err != nil {
+		return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
+	}
+	rangeSec := time.Since(start).Seconds()
+	log.Info("range scan done", "sec", rangeSec)
+	endRange(nil)
+	rangeUsedIndex, err := explainRange(ctx, db, cfg, log, "postgres", "SELECT COUNT(*) FROM bench_auto WHERE id BETWEEN $1 AND $2", lo, hi)
+	if err != nil {
+		return Result{}, err
+	}
+
+	// Insert→Readback 計測: 採番された ID を RETURNING で受け取ってから読み戻す。
+	returningStmt, err := prepare(ctx, db, cfg, insertSQL("postgres", "bench_auto", []string{"payload"}, gen.Columns())+" RETURNING id")
+	if err != nil {
+		return Result{}, err
+	}
+	defer returningStmt.Close()
+	readbackSec, err := readbackLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		var id int64
+		if err := returningStmt.QueryRowContext(ctx, insertArgs(gen, inserted+i)...).Scan(&id); err != nil {
+			return err
+		}
+		var payload sql.NullString
+		return selectStmt.QueryRowContext(ctx, id).Scan(&payload)
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		DB:                    "postgres",
+		Table:                 "bench_auto",
+		InsertRows:            inserted,
+		InsertSeconds:         insertSec,
+		PointLookupCount:      len(sample),
+		PointSeconds:          point.Seconds,
+		PointRounds:           point.Rounds,
+		PointWarmSeconds:      point.Warm,
+		PointSteadySeconds:    point.Steady,
+		HotPointSeconds:       hotSec,
+		ColdPointSeconds:      coldSec,
+		RangeSeconds:          rangeSec,
+		RangeUsedIndex:        rangeUsedIndex,
+		InsertReadbackSeconds: readbackSec,
+	}, nil
+}
+
+// benchPGUUID は PostgreSQL の UUID 主キーを計測する。
+func benchPGUUID(ctx context.Context, db *sql.DB, cfg Config) (Result, error) {
+	return benchPGUUIDKeys(ctx, db, cfg, "bench_uuid", newUUID)
+}
+
+// benchPGUUIDComb は先頭 6 バイトに時刻を入れた COMB 形式の UUID 主キーを計測する。
+func benchPGUUIDComb(ctx context.Context, db *sql.DB, cfg Config) (Result, error) {
+	return withTimeRegressions(combRegressions, func() (Result, error) {
+		return benchPGUUIDKeys(ctx, db, cfg, "bench_uuid_comb", uuidCombKey)
+	})
+}
+
+// benchPGUUIDBytea は UUIDv4 の 16 バイトをそのまま BYTEA 主キー (bench_uuid_bytea) に入れて計測する。
+// MySQL の BINARY(16) に当たる保存方法で、内部表現が同じ 16 バイトの UUID 型との差を見る。
+func benchPGUUIDBytea(ctx context.Context, db *sql.DB, cfg Config) (Result, error) {
+	return benchPGUUIDKeys(ctx, db, cfg, "bench_uuid_bytea", uuidBinKey)
+}
+
+// benchPGUUIDKeys は key で作った i 行目の UUID を table の主キーへ入れて計測する。
+// K は UUID 型の列なら uuid.UUID、BYTEA の列なら []byte。
+func benchPGUUIDKeys[K any](ctx context.Context, db *sql.DB, cfg Config, table string, key func(cfg Config, i int) K) (Result, error) {
+	log := slog.With("db", "postgres", "table", table)
+	gen := payloadFor(cfg)
+	insertStmt, err := prepare(ctx, db, cfg, insertSQL("postgres", table, []string{"id", "payload"}, gen.Columns()))
+	if err != nil {
+		return Result{}, err
+	}
+	defer insertStmt.Close()
+
+	// UUID を生成しながら挿入する。
+	ids := make([]K, 0, cfg.Rows)
+	inserted, insertSec, err := insertLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		id := key(cfg, i)
+		ids = append(ids, id)
+		_, err := insertStmt.ExecContext(ctx, insertArgs(gen, i, id)...)
+		return err
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	if err := analyzeTable(ctx, db, cfg, log, "postgres", table); err != nil {
+		return Result{}, err
+	}
+
+	// 点検索サンプル数は lookups 件までに制限する。
+	sample := ids
+	if len(sample) > cfg.Lookups {
+		sample = sample[:cfg.Lookups]
+	}
+	selectStmt, err := prepare(ctx, db, cfg, "SELECT payload FROM "+table+" WHERE id = $1")
+	if err != nil {
+		return Result{}, err
+	}
+	defer selectStmt.Close()
+
+	// Point Lookup 計測: UUID キーの完全一致検索。
+	point, err := pointLoop(ctx, cfg, log, len(sample), func(ctx context.Context, i int) error {
+		return scanPayload(cfg, gen, i, sample[i], selectStmt.QueryRowContext(ctx, sample[i]))
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	// Hot/Cold 計測: 直近に挿入した行と古い行とで点検索時間を分けて計る。
+	hotSec, coldSec, err := hotColdLoop(ctx, cfg, log, ids, func(ctx context.Context, id K) error {
+		var payload sql.NullString
+		return selectStmt.QueryRowContext(ctx, id).Scan(&payload)
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	log.Debug("range scan start")
+	_, endRange := startSpan(ctx, "range")
+	var rangeBytes int64
+	rctx, cancel := queryContext(ctx, cfg)
+	defer cancel()
+	// 範囲代替として ORDER BY + LIMIT の読み出し時間を計測する。
+	start := time.Now()
+	rowsRes, err := db.QueryContext(rctx, "SELECT id FROM "+table+" ORDER BY id LIMIT 10000")
+	if err != nil {
+		return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
+	}
+	for rowsRes.Next() {
+		var id K
+		if err := rowsRes.Scan(&id); err != nil {
+			rowsRes.Close()
+			return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
+		}
+		rangeBytes += valueBytes(id)
+	}
+	rowsRes.Close()
+	rangeSec := time.Since(start).Seconds()
+	log.Info("range scan done", "sec", rangeSec)
+	endRange(nil)
+	rangeUsedIndex, err := explainRange(ctx, db, cfg, log, "postgres", "SELECT id FROM "+table+" ORDER BY id LIMIT 10000")
+	if err != nil {
+		return Result{}, err
+	}
+
+	// Insert→Readback 計測: キーは挿入前に手元で決まるため、挿入後すぐそのキーで読み戻せる。
+	readbackSec, err := readbackLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		id := key(cfg, inserted+i)
+		if _, err := insertStmt.ExecContext(ctx, insertArgs(gen, inserted+i, id)...); err != nil {
+			return err
+		}
+		var payload sql.NullString
+		return selectStmt.QueryRowContext(ctx, id).Scan(&payload)
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		DB:                    "postgres",
+		Table:                 table,
+		InsertRows:            inserted,
+		InsertSeconds:         insertSec,
+		PointLookupCount:      len(sample),
+		PointSeconds:          point.Seconds,
+		PointRounds:           point.Rounds,
+		PointWarmSeconds:      point.Warm,
+		PointSteadySeconds:    point.Steady,
+		HotPointSeconds:       hotSec,
+		ColdPointSeconds:      coldSec,
+		RangeSeconds:          rangeSec,
+		RangeBytes:            rangeBytes,
+		RangeUsedIndex:        rangeUsedIndex,
+		InsertReadbackSeconds: readbackSec,
+	}, nil
+}
+
+// benchPGUUIDSeq は UUID 主キーに挿入順の seq 列を持たせて計測する。
+// 主キー順に読んだときの seq の並びから、ランダムキーで挿入順がどれだけ散らばるかを数値化する。
+func benchPGUUIDSeq(ctx context.Context, db *sql.DB, cfg Config) (Result, error) {
+	log := slog.With("db", "postgres", "table", "bench_uuid_seq")
+	gen := payloadFor(cfg)
+	insertStmt, err := prepare(ctx, db, cfg, insertSQL("postgres", "bench_uuid_seq", []string{"id", "seq", "payload"}, gen.Columns()))
+	if err != nil {
+		return Result{}, err
+	}
+	defer insertStmt.Close()
+
+	// ランダム UUID を生成しながら挿入する。
+	ids := make([]uuid.UUID, 0, cfg.Rows)
+	inserted, insertSec, err := insertLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		id := newUUID(cfg, i)
+		ids = append(ids, id)
+		_, err := insertStmt.ExecContext(ctx, insertArgs(gen, i, id, int64(i+1))...)
+		return err
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	if err := analyzeTable(ctx, db, cfg, log, "postgres", "bench_uuid_seq"); err != nil {
+		return Result{}, err
+	}
+
+	// 点検索サンプル数は lookups 件までに制限する。
+	sample := ids
+	if len(sample) > cfg.Lookups {
+		sample = sample[:cfg.Lookups]
+	}
+	selectStmt, err := prepare(ctx, db, cfg, "SELECT payload FROM bench_uuid_seq WHERE id = $1")
+	if err != nil {
+		return Result{}, err
+	}
+	defer selectStmt.Close()
+
+	// Point Lookup 計測: UUID キーの完全一致検索。
+	point, err := pointLoop(ctx, cfg, log, len(sample), func(ctx context.Context, i int) error {
+		return scanPayload(cfg, gen, i, sample[i], selectStmt.QueryRowContext(ctx, sample[i]))
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	// 主キー順の全件走査で seq（挿入順）を読み出し、所要時間と挿入順との相関を求める。
+	log.Debug("range scan start")
+	_, endRange := startSpan(ctx, "range")
+	var rangeBytes int64
+	rctx, cancel := queryContext(ctx, cfg)
+	defer cancel()
+	start := time.Now()
+	rowsRes, err := db.QueryContext(rctx, "SELECT seq FROM bench_uuid_seq ORDER BY id")
+	if err != nil {
+		return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
+	}
+	seqs := make([]int64, 0, inserted)
+	for rowsRes.Next() {
+		var seq int64
+		if err := rowsRes.Scan(&seq); err != nil {
+			rowsRes.Close()
+			return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
+		}
+		rangeBytes += valueBytes(seq)
+		seqs = append(seqs, seq)
+	}
+	rowsRes.Close()
+	if err := rowsRes.Err(); err != nil {
+		return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
 	}
+	rangeSec := time.Since(start).Seconds()
+	corr := SpearmanRank(seqs)
+	log.Info("range scan done", "sec", rangeSec, "seq_correlation", corr)
+	endRange(nil)
 
-	results := make([]Result, 0, 5)
-	// MySQL: AUTO_INCREMENT 主キー
-	r, err := benchMySQLAuto(ctx, mysqlDB, cfg.Rows, cfg.Lookups)
+	// Insert→Readback 計測: キーは挿入前に手元で決まるため、挿入後すぐそのキーで読み戻せる。
+	readbackSec, err := readbackLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		id := newUUID(cfg, inserted+i)
+		if _, err := insertStmt.ExecContext(ctx, insertArgs(gen, inserted+i, id, int64(inserted+i+1))...); err != nil {
+			return err
+		}
+		var payload sql.NullString
+		return selectStmt.QueryRowContext(ctx, id).Scan(&payload)
+	})
 	if err != nil {
-		return nil, err
+		return Result{}, err
 	}
-	results = append(results, r)
-	// MySQL: CHAR(36) UUID 主キー
-	r, err = benchMySQLUUIDChar(ctx, mysqlDB, cfg.Rows, cfg.Lookups)
+
+	return Result{
+		DB:                    "postgres",
+		Table:                 "bench_uuid_seq",
+		InsertRows:            inserted,
+		InsertSeconds:         insertSec,
+		PointLookupCount:      len(sample),
+		PointSeconds:          point.Seconds,
+		PointRounds:           point.Rounds,
+		PointWarmSeconds:      point.Warm,
+		PointSteadySeconds:    point.Steady,
+		RangeSeconds:          rangeSec,
+		RangeBytes:            rangeBytes,
+		InsertReadbackSeconds: readbackSec,
+		SeqCorrelation:        &corr,
+	}, nil
+}
+
+// benchMySQLUUIDTenant は MySQL の (tenant_id, BINARY(16)) 複合主キーを計測する。
+// 行は tenants 個のテナントへ割り当てる（-tenant-skew 指定時は Zipf 分布で偏らせる）。
+func benchMySQLUUIDTenant(ctx context.Context, db *sql.DB, cfg Config) (Result, error) {
+	log := slog.With("db", "mysql", "table", "bench_uuid_tenant")
+	gen := payloadFor(cfg)
+	insertStmt, err := prepare(ctx, db, cfg, insertSQL("mysql", "bench_uuid_tenant", []string{"tenant_id", "id", "payload"}, gen.Columns()))
 	if err != nil {
-		return nil, err
+		return Result{}, err
 	}
-	results = append(results, r)
-	// MySQL: BINARY(16) UUID 主キー
-	r, err = benchMySQLUUIDBin(ctx, mysqlDB, cfg.Rows, cfg.Lookups)
+	defer insertStmt.Close()
+
+	// テナント ID と UUID バイト列の組を保持しながら挿入する。
+	pickTenant := TenantPicker(cfg.Tenants, cfg.TenantSkew, tenantSeed)
+	tenantIDs := make([]int64, 0, cfg.Rows)
+	ids := make([][]byte, 0, cfg.Rows)
+	inserted, insertSec, err := insertLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		tenantIDs = append(tenantIDs, pickTenant(i))
+		ids = append(ids, uuidBinKey(cfg, i))
+		_, err := insertStmt.ExecContext(ctx, insertArgs(gen, i, tenantIDs[i], ids[i])...)
+		return err
+	})
 	if err != nil {
-		return nil, err
+		return Result{}, err
+	}
+	if err := analyzeTable(ctx, db, cfg, log, "mysql", "bench_uuid_tenant"); err != nil {
+		return Result{}, err
 	}
-	results = append(results, r)
-	// PostgreSQL: BIGSERIAL 主キー
-	r, err = benchPGAuto(ctx, pgDB, cfg.Rows, cfg.Lookups)
+
+	// 点検索サンプル数は lookups 件までに制限する。
+	n := len(ids)
+	if n > cfg.Lookups {
+		n = cfg.Lookups
+	}
+	selectStmt, err := prepare(ctx, db, cfg, "SELECT payload FROM bench_uuid_tenant WHERE tenant_id = ? AND id = ?")
 	if err != nil {
-		return nil, err
+		return Result{}, err
 	}
-	results = append(results, r)
-	// PostgreSQL: UUID 主キー
-	r, err = benchPGUUID(ctx, pgDB, cfg.Rows, cfg.Lookups)
+	defer selectStmt.Close()
+
+	// Point Lookup 計測: 複合主キーの完全一致検索。
+	point, err := pointLoop(ctx, cfg, log, n, func(ctx context.Context, i int) error {
+		return scanPayload(cfg, gen, i, ids[i], selectStmt.QueryRowContext(ctx, tenantIDs[i], ids[i]))
+	})
 	if err != nil {
-		return nil, err
+		return Result{}, err
 	}
-	results = append(results, r)
-	return results, nil
-}
 
-// setupMySQL はベンチ対象テーブルを作り直す。
-func setupMySQL(ctx context.Context, db *sql.DB) error {
-	stmts := []string{
-		"DROP TABLE IF EXISTS bench_auto",
-		"DROP TABLE IF EXISTS bench_uuid_char",
-		"DROP TABLE IF EXISTS bench_uuid_bin",
-		`CREATE TABLE bench_auto (
-			id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
-			payload VARCHAR(100) NOT NULL
-		) ENGINE=InnoDB`,
-		`CREATE TABLE bench_uuid_char (
-			id CHAR(36) NOT NULL PRIMARY KEY,
-			payload VARCHAR(100) NOT NULL
-		) ENGINE=InnoDB`,
-		`CREATE TABLE bench_uuid_bin (
-			id BINARY(16) NOT NULL PRIMARY KEY,
-			payload VARCHAR(100) NOT NULL
-		) ENGINE=InnoDB`,
+	log.Debug("range scan start")
+	_, endRange := startSpan(ctx, "range")
+	var rangeBytes int64
+	rctx, cancel := queryContext(ctx, cfg)
+	defer cancel()
+	// 範囲代替として 1 テナント内の ORDER BY + LIMIT の読み出し時間を計測する。
+	start := time.Now()
+	rowsRes, err := db.QueryContext(rctx, "SELECT id FROM bench_uuid_tenant WHERE tenant_id = ? ORDER BY id LIMIT 10000", 0)
+	if err != nil {
+		return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
 	}
-	for _, stmt := range stmts {
-		// 途中で失敗した場合は以降を実行せずエラーを返す。
-		if _, err := db.ExecContext(ctx, stmt); err != nil {
-			return fmt.Errorf("mysql setup failed: %w", err)
+	for rowsRes.Next() {
+		var b []byte
+		if err := rowsRes.Scan(&b); err != nil {
+			rowsRes.Close()
+			return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
 		}
+		rangeBytes += valueBytes(b)
 	}
-	return nil
-}
-
-// setupPostgres はベンチ対象テーブルを作り直す。
-func setupPostgres(ctx context.Context, db *sql.DB) error {
-	stmts := []string{
-		"DROP TABLE IF EXISTS bench_auto",
-		"DROP TABLE IF EXISTS bench_uuid",
-		`CREATE TABLE bench_auto (
-			id BIGSERIAL PRIMARY KEY,
-			payload TEXT NOT NULL
-		)`,
-		`CREATE TABLE bench_uuid (
-			id UUID PRIMARY KEY,
-			payload TEXT NOT NULL
-		)`,
+	rowsRes.Close()
+	rangeSec := time.Since(start).Seconds()
+	log.Info("range scan done", "sec", rangeSec)
+	endRange(nil)
+	rangeUsedIndex, err := explainRange(ctx, db, cfg, log, "mysql", "SELECT id FROM bench_uuid_tenant WHERE tenant_id = ? ORDER BY id LIMIT 10000", 0)
+	if err != nil {
+		return Result{}, err
 	}
-	for _, stmt := range stmts {
-		if _, err := db.ExecContext(ctx, stmt); err != nil {
-			return fmt.Errorf("postgres setup failed: %w", err)
+
+	// Insert→Readback 計測: キーは挿入前に手元で決まるため、挿入後すぐそのキーで読み戻せる。
+	readbackSec, err := readbackLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		tenantID, id := pickTenant(inserted+i), uuidBinKey(cfg, inserted+i)
+		if _, err := insertStmt.ExecContext(ctx, insertArgs(gen, inserted+i, tenantID, id)...); err != nil {
+			return err
 		}
+		var payload sql.NullString
+		return selectStmt.QueryRowContext(ctx, tenantID, id).Scan(&payload)
+	})
+	if err != nil {
+		return Result{}, err
 	}
-	return nil
+
+	return Result{
+		DB:                    "mysql",
+		Table:                 "bench_uuid_tenant",
+		InsertRows:            inserted,
+		InsertSeconds:         insertSec,
+		PointLookupCount:      n,
+		PointSeconds:          point.Seconds,
+		PointRounds:           point.Rounds,
+		PointWarmSeconds:      point.Warm,
+		PointSteadySeconds:    point.Steady,
+		RangeSeconds:          rangeSec,
+		RangeBytes:            rangeBytes,
+		RangeUsedIndex:        rangeUsedIndex,
+		InsertReadbackSeconds: readbackSec,
+	}, nil
 }
 
-// benchMySQLAuto は MySQL の AUTO_INCREMENT 主キーを計測する。
-func benchMySQLAuto(ctx context.Context, db *sql.DB, rows, lookups int) (Result, error) {
-	insertStmt, err := db.PrepareContext(ctx, "INSERT INTO bench_auto (payload) VALUES (?)")
+// benchPGUUIDTenant は PostgreSQL の (tenant_id, UUID) 複合主キーを計測する。
+// 行は tenants 個のテナントへ割り当てる（-tenant-skew 指定時は Zipf 分布で偏らせる）。
+func benchPGUUIDTenant(ctx context.Context, db *sql.DB, cfg Config) (Result, error) {
+	log := slog.With("db", "postgres", "table", "bench_uuid_tenant")
+	gen := payloadFor(cfg)
+	insertStmt, err := prepare(ctx, db, cfg, insertSQL("postgres", "bench_uuid_tenant", []string{"tenant_id", "id", "payload"}, gen.Columns()))
 	if err != nil {
 		return Result{}, err
 	}
 	defer insertStmt.Close()
 
-	// Insert 計測: 指定件数を連続投入する。
-	start := time.Now()
-	for i := 0; i < rows; i++ {
-		if _, err := insertStmt.ExecContext(ctx, fmt.Sprintf("p-%d", i)); err != nil {
-			return Result{}, err
-		}
+	// テナント ID と UUID の組を保持しながら挿入する。
+	pickTenant := TenantPicker(cfg.Tenants, cfg.TenantSkew, tenantSeed)
+	tenantIDs := make([]int64, 0, cfg.Rows)
+	ids := make([]uuid.UUID, 0, cfg.Rows)
+	inserted, insertSec, err := insertLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		tenantIDs = append(tenantIDs, pickTenant(i))
+		ids = append(ids, newUUID(cfg, i))
+		_, err := insertStmt.ExecContext(ctx, insertArgs(gen, i, tenantIDs[i], ids[i])...)
+		return err
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	if err := analyzeTable(ctx, db, cfg, log, "postgres", "bench_uuid_tenant"); err != nil {
+		return Result{}, err
 	}
-	insertSec := time.Since(start).Seconds()
 
-	// 参照用 ID 一覧を主キー順で収集する。
-	ids := make([]int64, 0, rows)
-	rowsRes, err := db.QueryContext(ctx, "SELECT id FROM bench_auto ORDER BY id")
+	// 点検索サンプル数は lookups 件までに制限する。
+	n := len(ids)
+	if n > cfg.Lookups {
+		n = cfg.Lookups
+	}
+	selectStmt, err := prepare(ctx, db, cfg, "SELECT payload FROM bench_uuid_tenant WHERE tenant_id = $1 AND id = $2")
 	if err != nil {
 		return Result{}, err
 	}
+	defer selectStmt.Close()
+
+	// Point Lookup 計測: 複合主キーの完全一致検索。
+	point, err := pointLoop(ctx, cfg, log, n, func(ctx context.Context, i int) error {
+		return scanPayload(cfg, gen, i, ids[i], selectStmt.QueryRowContext(ctx, tenantIDs[i], ids[i]))
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	log.Debug("range scan start")
+	_, endRange := startSpan(ctx, "range")
+	var rangeBytes int64
+	rctx, cancel := queryContext(ctx, cfg)
+	defer cancel()
+	// 範囲代替として 1 テナント内の ORDER BY + LIMIT の読み出し時間を計測する。
+	start := time.Now()
+	rowsRes, err := db.QueryContext(rctx, "SELECT id FROM bench_uuid_tenant WHERE tenant_id = $1 ORDER BY id LIMIT 10000", 0)
+	if err != nil {
+		return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
+	}
 	for rowsRes.Next() {
-		var id int64
+		var id uuid.UUID
 		if err := rowsRes.Scan(&id); err != nil {
 			rowsRes.Close()
-			return Result{}, err
+			return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
 		}
-		ids = append(ids, id)
+		rangeBytes += valueBytes(id)
 	}
 	rowsRes.Close()
-
-	// 点検索は先頭から lookups 件をサンプルとして使う。
-	sample := ids
-	if len(sample) > lookups {
-		sample = sample[:lookups]
-	}
-
-	selectStmt, err := db.PrepareContext(ctx, "SELECT payload FROM bench_auto WHERE id = ?")
+	rangeSec := time.Since(start).Seconds()
+	log.Info("range scan done", "sec", rangeSec)
+	endRange(nil)
+	rangeUsedIndex, err := explainRange(ctx, db, cfg, log, "postgres", "SELECT id FROM bench_uuid_tenant WHERE tenant_id = $1 ORDER BY id LIMIT 10000", 0)
 	if err != nil {
 		return Result{}, err
 	}
-	defer selectStmt.Close()
 
-	// Point Lookup 計測: 主キー完全一致検索の反復時間。
-	start = time.Now()
-	for _, id := range sample {
-		var payload string
-		if err := selectStmt.QueryRowContext(ctx, id).Scan(&payload); err != nil {
-			return Result{}, err
+	// Insert→Readback 計測: キーは挿入前に手元で決まるため、挿入後すぐそのキーで読み戻せる。
+	readbackSec, err := readbackLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		tenantID, id := pickTenant(inserted+i), newUUID(cfg, inserted+i)
+		if _, err := insertStmt.ExecContext(ctx, insertArgs(gen, inserted+i, tenantID, id)...); err != nil {
+			return err
 		}
-	}
-	pointSec := time.Since(start).Seconds()
-
-	// 範囲検索の下限/上限は全 ID の 25%〜75% 点から決める。
-	lo, hi := int64(0), int64(0)
-	if len(ids) > 0 {
-		lo = ids[len(ids)/4]
-		hi = ids[(len(ids)*3)/4]
-	}
-	start = time.Now()
-	var c int64
-	// COUNT(*) は結果サイズに依存せず比較しやすい。
-	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM bench_auto WHERE id BETWEEN ? AND ?", lo, hi).Scan(&c); err != nil {
+		var payload sql.NullString
+		return selectStmt.QueryRowContext(ctx, tenantID, id).Scan(&payload)
+	})
+	if err != nil {
 		return Result{}, err
 	}
-	rangeSec := time.Since(start).Seconds()
 
 	return Result{
-		DB:               "mysql",
-		Table:            "bench_auto",
-		InsertRows:       rows,
-		InsertSeconds:    insertSec,
-		PointLookupCount: len(sample),
-		PointSeconds:     pointSec,
-		RangeSeconds:     rangeSec,
+		DB:                    "postgres",
+		Table:                 "bench_uuid_tenant",
+		InsertRows:            inserted,
+		InsertSeconds:         insertSec,
+		PointLookupCount:      n,
+		PointSeconds:          point.Seconds,
+		PointRounds:           point.Rounds,
+		PointWarmSeconds:      point.Warm,
+		PointSteadySeconds:    point.Steady,
+		RangeSeconds:          rangeSec,
+		RangeBytes:            rangeBytes,
+		RangeUsedIndex:        rangeUsedIndex,
+		InsertReadbackSeconds: readbackSec,
 	}, nil
 }
 
-// benchMySQLUUIDChar は MySQL の CHAR(36) UUID 主キーを計測する。
-func benchMySQLUUIDChar(ctx context.Context, db *sql.DB, rows, lookups int) (Result, error) {
-	insertStmt, err := db.PrepareContext(ctx, "INSERT INTO bench_uuid_char (id, payload) VALUES (?, ?)")
+// benchMySQLHybrid は MySQL の AUTO_INCREMENT 主キー + BINARY(16) UUID 二次インデックス構成を計測する。
+// 外部公開用の UUID 列での点検索を計測し、UUID 主キーとの差を見る。
+func benchMySQLHybrid(ctx context.Context, db *sql.DB, cfg Config) (Result, error) {
+	log := slog.With("db", "mysql", "table", "bench_hybrid")
+	gen := payloadFor(cfg)
+	insertStmt, err := prepare(ctx, db, cfg, insertSQL("mysql", "bench_hybrid", []string{"public_id", "payload"}, gen.Columns()))
 	if err != nil {
 		return Result{}, err
 	}
 	defer insertStmt.Close()
 
-	// ランダム UUID 文字列を生成しながら挿入する。
-	ids := make([]string, rows)
-	start := time.Now()
-	for i := 0; i < rows; i++ {
-		id := uuid.NewString()
-		ids[i] = id
-		if _, err := insertStmt.ExecContext(ctx, id, fmt.Sprintf("p-%d", i)); err != nil {
-			return Result{}, err
-		}
+	// 主キーは DB 採番に任せ、UUID は外部参照用の列へ入れる。
+	publicIDs := make([][]byte, 0, cfg.Rows)
+	inserted, insertSec, err := insertLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		id := uuidBinKey(cfg, i)
+		publicIDs = append(publicIDs, id)
+		_, err := insertStmt.ExecContext(ctx, insertArgs(gen, i, id)...)
+		return err
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	if err := analyzeTable(ctx, db, cfg, log, "mysql", "bench_hybrid"); err != nil {
+		return Result{}, err
 	}
-	insertSec := time.Since(start).Seconds()
 
 	// 点検索サンプル数は lookups 件までに制限する。
-	sample := ids
-	if len(sample) > lookups {
-		sample = sample[:lookups]
+	sample := publicIDs
+	if len(sample) > cfg.Lookups {
+		sample = sample[:cfg.Lookups]
 	}
-	selectStmt, err := db.PrepareContext(ctx, "SELECT payload FROM bench_uuid_char WHERE id = ?")
+	selectStmt, err := prepare(ctx, db, cfg, "SELECT payload FROM bench_hybrid WHERE public_id = ?")
 	if err != nil {
 		return Result{}, err
 	}
 	defer selectStmt.Close()
 
-	// Point Lookup 計測: UUID 文字列キーの完全一致検索。
-	start = time.Now()
-	for _, id := range sample {
-		var payload string
-		if err := selectStmt.QueryRowContext(ctx, id).Scan(&payload); err != nil {
-			return Result{}, err
-		}
+	// Point Lookup 計測: 二次インデックス経由の UUID 完全一致検索。
+	point, err := pointLoop(ctx, cfg, log, len(sample), func(ctx context.Context, i int) error {
+		return scanPayload(cfg, gen, i, sample[i], selectStmt.QueryRowContext(ctx, sample[i]))
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	// -secondary-lookups: 同じキーで二次インデックスだけを読む検索も計り、主キーへの 2 回目の探索を切り分ける。
+	indexOnlySec, err := indexOnlyLookups(ctx, db, cfg, log, "SELECT 1 FROM bench_hybrid WHERE public_id = ?", sample)
+	if err != nil {
+		return Result{}, err
 	}
-	pointSec := time.Since(start).Seconds()
 
-	// 範囲代替として ORDER BY + LIMIT の読み出し時間を計測する。
-	start = time.Now()
-	rowsRes, err := db.QueryContext(ctx, "SELECT id FROM bench_uuid_char ORDER BY id LIMIT 10000")
+	// 範囲検索は連番主キーの 25%〜75% 区間で行う。
+	var minID, maxID int64
+	if err := db.QueryRowContext(ctx, "SELECT COALESCE(MIN(id), 0), COALESCE(MAX(id), 0) FROM bench_hybrid").Scan(&minID, &maxID); err != nil {
+		return Result{}, err
+	}
+	lo := minID + (maxID-minID)/4
+	hi := minID + (maxID-minID)*3/4
+	log.Debug("range scan start")
+	_, endRange := startSpan(ctx, "range")
+	rctx, cancel := queryContext(ctx, cfg)
+	defer cancel()
+	start := time.Now()
+	var c int64
+	if err := db.QueryRowContext(rctx, "SELECT COUNT(*) FROM bench_hybrid WHERE id BETWEEN ? AND ?", lo, hi).Scan(&c); err != nil {
+		return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
+	}
+	rangeSec := time.Since(start).Seconds()
+	log.Info("range scan done", "sec", rangeSec)
+	endRange(nil)
+	rangeUsedIndex, err := explainRange(ctx, db, cfg, log, "mysql", "SELECT COUNT(*) FROM bench_hybrid WHERE id BETWEEN ? AND ?", lo, hi)
 	if err != nil {
 		return Result{}, err
 	}
-	for rowsRes.Next() {
-		var id string
-		if err := rowsRes.Scan(&id); err != nil {
-			rowsRes.Close()
-			return Result{}, err
+
+	// Insert→Readback 計測: キーは挿入前に手元で決まるため、挿入後すぐそのキーで読み戻せる。
+	readbackSec, err := readbackLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		id := uuidBinKey(cfg, inserted+i)
+		if _, err := insertStmt.ExecContext(ctx, insertArgs(gen, inserted+i, id)...); err != nil {
+			return err
 		}
+		var payload sql.NullString
+		return selectStmt.QueryRowContext(ctx, id).Scan(&payload)
+	})
+	if err != nil {
+		return Result{}, err
 	}
-	rowsRes.Close()
-	rangeSec := time.Since(start).Seconds()
 
 	return Result{
-		DB:               "mysql",
-		Table:            "bench_uuid_char",
-		InsertRows:       rows,
-		InsertSeconds:    insertSec,
-		PointLookupCount: len(sample),
-		PointSeconds:     pointSec,
-		RangeSeconds:     rangeSec,
+		DB:                    "mysql",
+		Table:                 "bench_hybrid",
+		InsertRows:            inserted,
+		InsertSeconds:         insertSec,
+		PointLookupCount:      len(sample),
+		PointSeconds:          point.Seconds,
+		IndexOnlyPointSeconds: indexOnlySec,
+		PointRounds:           point.Rounds,
+		PointWarmSeconds:      point.Warm,
+		PointSteadySeconds:    point.Steady,
+		RangeSeconds:          rangeSec,
+		RangeUsedIndex:        rangeUsedIndex,
+		InsertReadbackSeconds: readbackSec,
 	}, nil
 }
 
-// benchMySQLUUIDBin は MySQL の BINARY(16) UUID 主キーを計測する。
-func benchMySQLUUIDBin(ctx context.Context, db *sql.DB, rows, lookups int) (Result, error) {
-	insertStmt, err := db.PrepareContext(ctx, "INSERT INTO bench_uuid_bin (id, payload) VALUES (?, ?)")
+// benchPGHybrid は PostgreSQL の BIGSERIAL 主キー + UUID UUID 二次インデックス構成を計測する。
+// 外部公開用の UUID 列での点検索を計測し、UUID 主キーとの差を見る。
+func benchPGHybrid(ctx context.Context, db *sql.DB, cfg Config) (Result, error) {
+	log := slog.With("db", "postgres", "table", "bench_hybrid")
+	gen := payloadFor(cfg)
+	insertStmt, err := prepare(ctx, db, cfg, insertSQL("postgres", "bench_hybrid", []string{"public_id", "payload"}, gen.Columns()))
 	if err != nil {
 		return Result{}, err
 	}
 	defer insertStmt.Close()
 
-	// UUID を 16 バイト表現へ変換して挿入する。
-	ids := make([][]byte, rows)
-	start := time.Now()
-	for i := 0; i < rows; i++ {
-		b := UUIDToBytes(uuid.New())
-		ids[i] = b
-		if _, err := insertStmt.ExecContext(ctx, b, fmt.Sprintf("p-%d", i)); err != nil {
-			return Result{}, err
-		}
+	// 主キーは DB 採番に任せ、UUID は外部参照用の列へ入れる。
+	publicIDs := make([]uuid.UUID, 0, cfg.Rows)
+	inserted, insertSec, err := insertLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		id := newUUID(cfg, i)
+		publicIDs = append(publicIDs, id)
+		_, err := insertStmt.ExecContext(ctx, insertArgs(gen, i, id)...)
+		return err
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	if err := analyzeTable(ctx, db, cfg, log, "postgres", "bench_hybrid"); err != nil {
+		return Result{}, err
 	}
-	insertSec := time.Since(start).Seconds()
 
 	// 点検索サンプル数は lookups 件までに制限する。
-	sample := ids
-	if len(sample) > lookups {
-		sample = sample[:lookups]
+	sample := publicIDs
+	if len(sample) > cfg.Lookups {
+		sample = sample[:cfg.Lookups]
 	}
-	selectStmt, err := db.PrepareContext(ctx, "SELECT payload FROM bench_uuid_bin WHERE id = ?")
+	selectStmt, err := prepare(ctx, db, cfg, "SELECT payload FROM bench_hybrid WHERE public_id = $1")
 	if err != nil {
 		return Result{}, err
 	}
 	defer selectStmt.Close()
 
-	// Point Lookup 計測: BINARY(16) キーの完全一致検索。
-	start = time.Now()
-	for _, id := range sample {
-		var payload string
-		if err := selectStmt.QueryRowContext(ctx, id).Scan(&payload); err != nil {
-			return Result{}, err
-		}
+	// Point Lookup 計測: 二次インデックス経由の UUID 完全一致検索。
+	point, err := pointLoop(ctx, cfg, log, len(sample), func(ctx context.Context, i int) error {
+		return scanPayload(cfg, gen, i, sample[i], selectStmt.QueryRowContext(ctx, sample[i]))
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	// -secondary-lookups: 同じキーで二次インデックスだけを読む検索も計り、主キーへの 2 回目の探索を切り分ける。
+	indexOnlySec, err := indexOnlyLookups(ctx, db, cfg, log, "SELECT 1 FROM bench_hybrid WHERE public_id = $1", sample)
+	if err != nil {
+		return Result{}, err
 	}
-	pointSec := time.Since(start).Seconds()
 
-	// 範囲代替として ORDER BY + LIMIT の読み出し時間を計測する。
-	start = time.Now()
-	rowsRes, err := db.QueryContext(ctx, "SELECT id FROM bench_uuid_bin ORDER BY id LIMIT 10000")
+	// 範囲検索は連番主キーの 25%〜75% 区間で行う。
+	var minID, maxID int64
+	if err := db.QueryRowContext(ctx, "SELECT COALESCE(MIN(id), 0), COALESCE(MAX(id), 0) FROM bench_hybrid").Scan(&minID, &maxID); err != nil {
+		return Result{}, err
+	}
+	lo := minID + (maxID-minID)/4
+	hi := minID + (maxID-minID)*3/4
+	log.Debug("range scan start")
+	_, endRange := startSpan(ctx, "range")
+	rctx, cancel := queryContext(ctx, cfg)
+	defer cancel()
+	start := time.Now()
+	var c int64
+	if err := db.QueryRowContext(rctx, "SELECT COUNT(*) FROM bench_hybrid WHERE id BETWEEN $1 AND $2", lo, hi).Scan(&c); err != nil {
+		return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
+	}
+	rangeSec := time.Since(start).Seconds()
+	log.Info("range scan done", "sec", rangeSec)
+	endRange(nil)
+	rangeUsedIndex, err := explainRange(ctx, db, cfg, log, "postgres", "SELECT COUNT(*) FROM bench_hybrid WHERE id BETWEEN $1 AND $2", lo, hi)
 	if err != nil {
 		return Result{}, err
 	}
-	for rowsRes.Next() {
-		var b []byte
-		if err := rowsRes.Scan(&b); err != nil {
-			rowsRes.Close()
-			return Result{}, err
+
+	// Insert→Readback 計測: キーは挿入前に手元で決まるため、挿入後すぐそのキーで読み戻せる。
+	readbackSec, err := readbackLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		id := newUUID(cfg, inserted+i)
+		if _, err := insertStmt.ExecContext(ctx, insertArgs(gen, inserted+i, id)...); err != nil {
+			return err
 		}
+		var payload sql.NullString
+		return selectStmt.QueryRowContext(ctx, id).Scan(&payload)
+	})
+	if err != nil {
+		return Result{}, err
 	}
-	rowsRes.Close()
-	rangeSec := time.Since(start).Seconds()
 
 	return Result{
-		DB:               "mysql",
-		Table:            "bench_uuid_bin",
-		InsertRows:       rows,
-		InsertSeconds:    insertSec,
-		PointLookupCount: len(sample),
-		PointSeconds:     pointSec,
-		RangeSeconds:     rangeSec,
+		DB:                    "postgres",
+		Table:                 "bench_hybrid",
+		InsertRows:            inserted,
+		InsertSeconds:         insertSec,
+		PointLookupCount:      len(sample),
+		PointSeconds:          point.Seconds,
+		IndexOnlyPointSeconds: indexOnlySec,
+		PointRounds:           point.Rounds,
+		PointWarmSeconds:      point.Warm,
+		PointSteadySeconds:    point.Steady,
+		RangeSeconds:          rangeSec,
+		RangeUsedIndex:        rangeUsedIndex,
+		InsertReadbackSeconds: readbackSec,
 	}, nil
 }
 
-// benchPGAuto は PostgreSQL の BIGSERIAL 主キーを計測する。
-func benchPGAuto(ctx context.Context, db *sql.DB, rows, lookups int) (Result, error) {
-	insertStmt, err := db.PrepareContext(ctx, "INSERT INTO bench_auto (payload) VALUES ($1)")
+// benchMySQLIntShuffled は MySQL の BIGINT 主キーへ 1..Rows をシャッフル順で挿入して計測する。
+// キー幅は連番と同じまま挿入順だけを乱し、UUID の不利が順序由来か幅由来かを切り分ける。
+func benchMySQLIntShuffled(ctx context.Context, db *sql.DB, cfg Config) (Result, error) {
+	log := slog.With("db", "mysql", "table", "bench_int_shuffled")
+	gen := payloadFor(cfg)
+	insertStmt, err := prepare(ctx, db, cfg, insertSQL("mysql", "bench_int_shuffled", []string{"id", "payload"}, gen.Columns()))
 	if err != nil {
 		return Result{}, err
 	}
 	defer insertStmt.Close()
 
-	// Insert 計測: 指定件数を連続投入する。
-	start := time.Now()
-	for i := 0; i < rows; i++ {
-		if _, err := insertStmt.ExecContext(ctx, fmt.Sprintf("p-%d", i)); err != nil {
-			return Result{}, err
-		}
-	}
-	insertSec := time.Since(start).Seconds()
-
-	// 参照用 ID 一覧を主キー順で収集する。
-	ids := make([]int64, 0, rows)
-	rowsRes, err := db.QueryContext(ctx, "SELECT id FROM bench_auto ORDER BY id")
+	// 乱数シードを固定し、実行間で同じ挿入順になるようにする。
+	ids := ShuffledIDs(cfg.Rows, shuffleSeed)
+	inserted, insertSec, err := insertLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		_, err := insertStmt.ExecContext(ctx, insertArgs(gen, i, ids[i])...)
+		return err
+	})
 	if err != nil {
 		return Result{}, err
 	}
-	for rowsRes.Next() {
-		var id int64
-		if err := rowsRes.Scan(&id); err != nil {
-			rowsRes.Close()
-			return Result{}, err
-		}
-		ids = append(ids, id)
+	if err := analyzeTable(ctx, db, cfg, log, "mysql", "bench_int_shuffled"); err != nil {
+		return Result{}, err
 	}
-	rowsRes.Close()
 
-	// 点検索は先頭から lookups 件をサンプルとして使う。
-	sample := ids
-	if len(sample) > lookups {
-		sample = sample[:lookups]
+	// 点検索サンプルは挿入順の先頭から lookups 件を使う。
+	sample := ids[:inserted]
+	if len(sample) > cfg.Lookups {
+		sample = sample[:cfg.Lookups]
 	}
-	selectStmt, err := db.PrepareContext(ctx, "SELECT payload FROM bench_auto WHERE id = $1")
+	selectStmt, err := prepare(ctx, db, cfg, "SELECT payload FROM bench_int_shuffled WHERE id = ?")
 	if err != nil {
 		return Result{}, err
 	}
 	defer selectStmt.Close()
 
 	// Point Lookup 計測: 主キー完全一致検索の反復時間。
-	start = time.Now()
-	for _, id := range sample {
-		var payload string
-		if err := selectStmt.QueryRowContext(ctx, id).Scan(&payload); err != nil {
-			return Result{}, err
-		}
+	point, err := pointLoop(ctx, cfg, log, len(sample), func(ctx context.Context, i int) error {
+		return scanPayload(cfg, gen, i, sample[i], selectStmt.QueryRowContext(ctx, sample[i]))
+	})
+	if err != nil {
+		return Result{}, err
 	}
-	pointSec := time.Since(start).Seconds()
 
-	// 範囲検索の下限/上限は全 ID の 25%〜75% 点から決める。
-	lo, hi := int64(0), int64(0)
-	if len(ids) > 0 {
-		lo = ids[len(ids)/4]
-		hi = ids[(len(ids)*3)/4]
-	}
-	start = time.Now()
+	// 範囲検索の下限/上限は 1..Rows の 25%〜75% 点から決める。
+	lo := int64(inserted/4 + 1)
+	hi := int64(inserted*3/4 + 1)
+	log.Debug("range scan start")
+	_, endRange := startSpan(ctx, "range")
+	rctx, cancel := queryContext(ctx, cfg)
+	defer cancel()
+	start := time.Now()
 	var c int64
-	// COUNT(*) は結果サイズに依存せず比較しやすい。
-	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM bench_auto WHERE id BETWEEN $1 AND $2", lo, hi).Scan(&c); err != nil {
-		return Result{}, err
+	if err := db.QueryRowContext(rctx, "SELECT COUNT(*) FROM bench_int_shuffled WHERE id BETWEEN ? AND ?", lo, hi).Scan(&c); err != nil {
+		return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
 	}
 	rangeSec := time.Since(start).Seconds()
+	log.Info("range scan done", "sec", rangeSec)
+	endRange(nil)
+	rangeUsedIndex, err := explainRange(ctx, db, cfg, log, "mysql", "SELECT COUNT(*) FROM bench_int_shuffled WHERE id BETWEEN ? AND ?", lo, hi)
+	if err != nil {
+		return Result{}, err
+	}
+
+	// Insert→Readback 計測: キーは挿入前に手元で決まるため、挿入後すぐそのキーで読み戻せる。
+	readbackSec, err := readbackLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		// 1..Rows は使用済みなので、その後ろの連番を使う。
+		id := int64(len(ids) + i + 1)
+		if _, err := insertStmt.ExecContext(ctx, insertArgs(gen, inserted+i, id)...); err != nil {
+			return err
+		}
+		var payload sql.NullString
+		return selectStmt.QueryRowContext(ctx, id).Scan(&payload)
+	})
+	if err != nil {
+		return Result{}, err
+	}
 
 	return Result{
-		DB:               "postgres",
-		Table:            "bench_auto",
-		InsertRows:       rows,
-		InsertSeconds:    insertSec,
-		PointLookupCount: len(sample),
-		PointSeconds:     pointSec,
-		RangeSeconds:     rangeSec,
+		DB:                    "mysql",
+		Table:                 "bench_int_shuffled",
+		InsertRows:            inserted,
+		InsertSeconds:         insertSec,
+		PointLookupCount:      len(sample),
+		PointSeconds:          point.Seconds,
+		PointRounds:           point.Rounds,
+		PointWarmSeconds:      point.Warm,
+		PointSteadySeconds:    point.Steady,
+		RangeSeconds:          rangeSec,
+		RangeUsedIndex:        rangeUsedIndex,
+		InsertReadbackSeconds: readbackSec,
 	}, nil
 }
 
-// benchPGUUID は PostgreSQL の UUID 主キーを計測する。
-func benchPGUUID(ctx context.Context, db *sql.DB, rows, lookups int) (Result, error) {
-	insertStmt, err := db.PrepareContext(ctx, "INSERT INTO bench_uuid (id, payload) VALUES ($1, $2)")
+// benchPGIntShuffled は PostgreSQL の BIGINT 主キーへ 1..Rows をシャッフル順で挿入して計測する。
+// キー幅は連番と同じまま挿入順だけを乱し、UUID の不利が順序由来か幅由来かを切り分ける。
+func benchPGIntShuffled(ctx context.Context, db *sql.DB, cfg Config) (Result, error) {
+	log := slog.With("db", "postgres", "table", "bench_int_shuffled")
+	gen := payloadFor(cfg)
+	insertStmt, err := prepare(ctx, db, cfg, insertSQL("postgres", "bench_int_shuffled", []string{"id", "payload"}, gen.Columns()))
 	if err != nil {
 		return Result{}, err
 	}
 	defer insertStmt.Close()
 
-	// ランダム UUID を生成しながら挿入する。
-	ids := make([]uuid.UUID, rows)
-	start := time.Now()
-	for i := 0; i < rows; i++ {
-		id := uuid.New()
-		ids[i] = id
-		if _, err := insertStmt.ExecContext(ctx, id, fmt.Sprintf("p-%d", i)); err != nil {
-			return Result{}, err
-		}
+	// 乱数シードを固定し、実行間で同じ挿入順になるようにする。
+	ids := ShuffledIDs(cfg.Rows, shuffleSeed)
+	inserted, insertSec, err := insertLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		_, err := insertStmt.ExecContext(ctx, insertArgs(gen, i, ids[i])...)
+		return err
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	if err := analyzeTable(ctx, db, cfg, log, "postgres", "bench_int_shuffled"); err != nil {
+		return Result{}, err
 	}
-	insertSec := time.Since(start).Seconds()
 
-	// 点検索サンプル数は lookups 件までに制限する。
-	sample := ids
-	if len(sample) > lookups {
-		sample = sample[:lookups]
+	// 点検索サンプルは挿入順の先頭から lookups 件を使う。
+	sample := ids[:inserted]
+	if len(sample) > cfg.Lookups {
+		sample = sample[:cfg.Lookups]
 	}
-	selectStmt, err := db.PrepareContext(ctx, "SELECT payload FROM bench_uuid WHERE id = $1")
+	selectStmt, err := prepare(ctx, db, cfg, "SELECT payload FROM bench_int_shuffled WHERE id = $1")
 	if err != nil {
 		return Result{}, err
 	}
 	defer selectStmt.Close()
 
-	// Point Lookup 計測: UUID キーの完全一致検索。
-	start = time.Now()
-	for _, id := range sample {
-		var payload string
-		if err := selectStmt.QueryRowContext(ctx, id).Scan(&payload); err != nil {
-			return Result{}, err
-		}
+	// Point Lookup 計測: 主キー完全一致検索の反復時間。
+	point, err := pointLoop(ctx, cfg, log, len(sample), func(ctx context.Context, i int) error {
+		return scanPayload(cfg, gen, i, sample[i], selectStmt.QueryRowContext(ctx, sample[i]))
+	})
+	if err != nil {
+		return Result{}, err
 	}
-	pointSec := time.Since(start).Seconds()
 
-	// 範囲代替として ORDER BY + LIMIT の読み出し時間を計測する。
-	start = time.Now()
-	rowsRes, err := db.QueryContext(ctx, "SELECT id FROM bench_uuid ORDER BY id LIMIT 10000")
+	// 範囲検索の下限/上限は 1..Rows の 25%〜75% 点から決める。
+	lo := int64(inserted/4 + 1)
+	hi := int64(inserted*3/4 + 1)
+	log.Debug("range scan start")
+	_, endRange := startSpan(ctx, "range")
+	rctx, cancel := queryContext(ctx, cfg)
+	defer cancel()
+	start := time.Now()
+	var c int64
+	if err := db.QueryRowContext(rctx, "SELECT COUNT(*) FROM bench_int_shuffled WHERE id BETWEEN $1 AND $2", lo, hi).Scan(&c); err != nil {
+		return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
+	}
+	rangeSec := time.Since(start).Seconds()
+	log.Info("range scan done", "sec", rangeSec)
+	endRange(nil)
+	rangeUsedIndex, err := explainRange(ctx, db, cfg, log, "postgres", "SELECT COUNT(*) FROM bench_int_shuffled WHERE id BETWEEN $1 AND $2", lo, hi)
 	if err != nil {
 		return Result{}, err
 	}
-	for rowsRes.Next() {
-		var id uuid.UUID
-		if err := rowsRes.Scan(&id); err != nil {
-			rowsRes.Close()
-			return Result{}, err
+
+	// Insert→Readback 計測: キーは挿入前に手元で決まるため、挿入後すぐそのキーで読み戻せる。
+	readbackSec, err := readbackLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		// 1..Rows は使用済みなので、その後ろの連番を使う。
+		id := int64(len(ids) + i + 1)
+		if _, err := insertStmt.ExecContext(ctx, insertArgs(gen, inserted+i, id)...); err != nil {
+			return err
 		}
+		var payload sql.NullString
+		return selectStmt.QueryRowContext(ctx, id).Scan(&payload)
+	})
+	if err != nil {
+		return Result{}, err
 	}
-	rowsRes.Close()
-	rangeSec := time.Since(start).Seconds()
 
 	return Result{
-		DB:               "postgres",
-		Table:            "bench_uuid",
-		InsertRows:       rows,
-		InsertSeconds:    insertSec,
-		PointLookupCount: len(sample),
-		PointSeconds:     pointSec,
-		RangeSeconds:     rangeSec,
+		DB:                    "postgres",
+		Table:                 "bench_int_shuffled",
+		InsertRows:            inserted,
+		InsertSeconds:         insertSec,
+		PointLookupCount:      len(sample),
+		PointSeconds:          point.Seconds,
+		PointRounds:           point.Rounds,
+		PointWarmSeconds:      point.Warm,
+		PointSteadySeconds:    point.Steady,
+		RangeSeconds:          rangeSec,
+		RangeUsedIndex:        rangeUsedIndex,
+		InsertReadbackSeconds: readbackSec,
 	}, nil
 }
@@ -0,0 +1,377 @@
+package bench
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestMySQLDSN(t *testing.T) {
+	t.Run("MySQL接続文字列_multiStatementsを付けない", func(t *testing.T) {
+		dsn := MySQLDSN(DefaultConfig())
+		if want := "bench:bench@tcp(127.0.0.1:3306)/idbench?parseTime=true"; dsn != want {
+			t.Fatalf("MySQLDSN = %q, want %q", dsn, want)
+		}
+		if strings.Contains(dsn, "multiStatements") {
+			t.Fatalf("MySQLDSN = %q, want no multiStatements", dsn)
+		}
+	})
+}
+
+func TestCollationDDL(t *testing.T) {
+	tests := []struct {
+		name      string
+		collation string
+		want      string
+	}{
+		{"未指定はサーバ既定", "", ""},
+		{"ascii_bin", "ascii_bin", " CHARACTER SET ascii COLLATE ascii_bin"},
+		{"utf8mb4", "utf8mb4_0900_ai_ci", " CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_ai_ci"},
+		{"binary", "binary", " CHARACTER SET binary COLLATE binary"},
+	}
+	for _, tt := range tests {
+		t.Run("照合順序DDL_"+tt.name, func(t *testing.T) {
+			if got := collationDDL(tt.collation); got != tt.want {
+				t.Fatalf("collationDDL(%q) = %q, want %q", tt.collation, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateCharCollation(t *testing.T) {
+	t.Run("照合順序検証_DDLを壊す値はエラー", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.CharCollation = "ascii_bin; DROP TABLE x"
+		if err := ValidateConfig(cfg); err == nil {
+			t.Fatal("ValidateConfig error = nil, want error")
+		}
+	})
+}
+
+func TestWithTableOptions(t *testing.T) {
+	stmts := []string{"DROP TABLE IF EXISTS bench_uuid_bin", "CREATE TABLE bench_uuid_bin (\n\tid BINARY(16)\n) ENGINE=InnoDB", "CREATE TABLE bench_auto (id BIGINT)"}
+	t.Run("テーブルオプション_指定したテーブルのCREATEだけに付ける", func(t *testing.T) {
+		got := withTableOptions("mysql", stmts, map[string]string{"mysql.bench_uuid_bin": "ROW_FORMAT=COMPRESSED", "postgres.bench_auto": "WITH (fillfactor=70)"})
+		if got[0] != stmts[0] || got[2] != stmts[2] {
+			t.Fatalf("unrelated statements changed: %q", got)
+		}
+		if !strings.HasSuffix(got[1], ") ENGINE=InnoDB ROW_FORMAT=COMPRESSED") {
+			t.Fatalf("CREATE TABLE bench_uuid_bin = %q, want options appended", got[1])
+		}
+	})
+}
+
+func TestWithUnlogged(t *testing.T) {
+	t.Run("UNLOGGED_CREATE_TABLEだけを置き換える", func(t *testing.T) {
+		stmts := []string{"DROP TABLE IF EXISTS bench_uuid", "CREATE TABLE bench_uuid (\n\tid UUID PRIMARY KEY\n) WITH (fillfactor=70)"}
+		got := withUnlogged(stmts)
+		if got[0] != stmts[0] {
+			t.Fatalf("DROP changed: %q", got[0])
+		}
+		if want := "CREATE UNLOGGED TABLE bench_uuid (\n\tid UUID PRIMARY KEY\n) WITH (fillfactor=70)"; got[1] != want {
+			t.Fatalf("got %q, want %q", got[1], want)
+		}
+		if stmts[1] != "CREATE TABLE bench_uuid (\n\tid UUID PRIMARY KEY\n) WITH (fillfactor=70)" {
+			t.Fatal("input slice was modified")
+		}
+	})
+}
+
+func TestWithAutovacuum(t *testing.T) {
+	t.Run("autovacuum_CREATE_TABLEの直後にALTER_TABLEを加える", func(t *testing.T) {
+		stmts := []string{"DROP TABLE IF EXISTS bench_uuid", "CREATE TABLE bench_uuid (\n\tid UUID PRIMARY KEY\n)", "CREATE UNLOGGED TABLE bench_auto (\n\tid BIGSERIAL PRIMARY KEY\n)"}
+		got := withAutovacuum(stmts, "off")
+		want := []string{
+			stmts[0],
+			stmts[1],
+			"ALTER TABLE bench_uuid SET (autovacuum_enabled = off)",
+			stmts[2],
+			"ALTER TABLE bench_auto SET (autovacuum_enabled = off)",
+		}
+		if !slices.Equal(got, want) {
+			t.Fatalf("withAutovacuum = %q, want %q", got, want)
+		}
+	})
+}
+
+func TestPGIndexOptions(t *testing.T) {
+	t.Run("fillfactor_未指定なら付けない", func(t *testing.T) {
+		if got := pgIndexOptions(DefaultConfig()); got != "" {
+			t.Fatalf("pgIndexOptions = %q, want empty", got)
+		}
+	})
+	t.Run("fillfactor_指定値をWITH句にする", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.PGFillfactor = 70
+		if got := pgIndexOptions(cfg); got != " WITH (fillfactor=70)" {
+			t.Fatalf("pgIndexOptions = %q", got)
+		}
+	})
+}
+
+func TestRunnerEmitter(t *testing.T) {
+	t.Run("逐次通知_コールバックなしならnil", func(t *testing.T) {
+		if (Runner{}).emitter("x") != nil {
+			t.Fatal("emitter should be nil without OnResult")
+		}
+		got := emit(nil, nil, Result{Table: "a"}, Result{Table: "b"})
+		if len(got) != 2 {
+			t.Fatalf("emit len = %d, want 2", len(got))
+		}
+	})
+
+	t.Run("逐次通知_接続先ラベルを付けて1件ずつ渡す", func(t *testing.T) {
+		var seen []Result
+		rn := Runner{OnResult: func(r Result) { seen = append(seen, r) }}
+		results := emit(nil, rn.emitter("mysql-8.4"), Result{Table: "bench_auto"}, Result{Table: "bench_uuid_bin"})
+		if len(seen) != 2 || seen[0].Table != "bench_auto" || seen[1].Server != "mysql-8.4" {
+			t.Fatalf("seen = %+v", seen)
+		}
+		// 戻り値のラベル付けは Run 側の tagServer が行う。
+		if results[0].Server != "" {
+			t.Fatalf("results[0].Server = %q, want empty", results[0].Server)
+		}
+	})
+}
+
+func TestVacuumPGDisabled(t *testing.T) {
+	t.Run("VACUUM計測_無効ならDBに触れず結果を返す", func(t *testing.T) {
+		in := Result{DB: "postgres", Table: "bench_uuid"}
+		got, err := vacuumPG(context.Background(), nil, DefaultConfig(), in)
+		if err != nil || got.VacuumSeconds != 0 || got.DeadTuples != nil {
+			t.Fatalf("vacuumPG = %+v, %v; want unchanged", got, err)
+		}
+	})
+}
+
+func TestBenchTables(t *testing.T) {
+	t.Run("対象テーブル_既定は基本構成のみ", func(t *testing.T) {
+		cfg := DefaultConfig()
+		if got := strings.Join(mysqlTables(cfg), ","); got != "bench_auto,bench_uuid_char,bench_uuid_bin,bench_uuid_tenant,bench_hybrid" {
+			t.Fatalf("mysqlTables = %s", got)
+		}
+		if got := strings.Join(pgTables(cfg), ","); got != "bench_auto,bench_uuid,bench_uuid_tenant,bench_hybrid" {
+			t.Fatalf("pgTables = %s", got)
+		}
+	})
+
+	t.Run("対象テーブル_オプションで追加テーブルを含める", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.RowIDTable = true
+		cfg.ShuffleInsertOrder = true
+		if got := mysqlTables(cfg); got[len(got)-2] != "bench_uuid_rowid" || got[len(got)-1] != "bench_int_shuffled" {
+			t.Fatalf("mysqlTables = %v", got)
+		}
+		if got := pgTables(cfg); got[len(got)-1] != "bench_int_shuffled" {
+			t.Fatalf("pgTables = %v", got)
+		}
+	})
+}
+
+func TestLoopsStopOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cfg := DefaultConfig()
+	cfg.InsertReadback = true
+	calls := 0
+	count := func(context.Context, int) error {
+		calls++
+		return nil
+	}
+
+	t.Run("中断_insertLoopは挿入せずに返る", func(t *testing.T) {
+		calls = 0
+		n, _, err := insertLoop(ctx, cfg, slog.Default(), count)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("err = %v, want context.Canceled", err)
+		}
+		if n != 0 || calls != 0 {
+			t.Fatalf("inserted = %d, calls = %d, want 0", n, calls)
+		}
+	})
+
+	t.Run("中断_pointLoopは検索せずに返る", func(t *testing.T) {
+		calls = 0
+		if _, err := pointLoop(ctx, cfg, slog.Default(), cfg.Lookups, count); !errors.Is(err, context.Canceled) {
+			t.Fatalf("err = %v, want context.Canceled", err)
+		}
+		if calls != 0 {
+			t.Fatalf("calls = %d, want 0", calls)
+		}
+	})
+
+	t.Run("中断_readbackLoopは読み戻しせずに返る", func(t *testing.T) {
+		calls = 0
+		if _, err := readbackLoop(ctx, cfg, slog.Default(), count); !errors.Is(err, context.Canceled) {
+			t.Fatalf("err = %v, want context.Canceled", err)
+		}
+		if calls != 0 {
+			t.Fatalf("calls = %d, want 0", calls)
+		}
+	})
+}
+
+func TestQueryTimeout(t *testing.T) {
+	block := func(ctx context.Context, _ int) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	t.Run("文単位タイムアウト_超過したらフラグ名付きのエラーを返す", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.QueryTimeout = time.Millisecond
+		_, _, err := insertLoop(context.Background(), cfg, slog.Default(), block)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+		}
+		if !strings.Contains(err.Error(), "-query-timeout") {
+			t.Fatalf("err = %v, want mention of -query-timeout", err)
+		}
+	})
+
+	t.Run("文単位タイムアウト_0なら期限を付けない", func(t *testing.T) {
+		cfg := DefaultConfig()
+		err := withQueryTimeout(context.Background(), cfg, 0, func(ctx context.Context, _ int) error {
+			if _, ok := ctx.Deadline(); ok {
+				return errors.New("unexpected deadline")
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("文単位タイムアウト_親の中断はそのまま返す", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.QueryTimeout = time.Hour
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := withQueryTimeout(ctx, cfg, 0, block)
+		if !errors.Is(err, context.Canceled) || strings.Contains(err.Error(), "-query-timeout") {
+			t.Fatalf("err = %v, want plain context.Canceled", err)
+		}
+	})
+}
+
+func TestIsolateFailure(t *testing.T) {
+	boom := errors.New("boom")
+	t.Run("失敗時の扱い_fail-fastなら中断する", func(t *testing.T) {
+		cfg := DefaultConfig()
+		if _, err := isolateFailure(context.Background(), cfg, "mysql", "bench_uuid_char", boom); !errors.Is(err, boom) {
+			t.Fatalf("err = %v, want boom", err)
+		}
+	})
+	t.Run("失敗時の扱い_続行するならErr付きの結果を返す", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.FailFast = false
+		r, err := isolateFailure(context.Background(), cfg, "postgres", "bench_uuid", boom)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.DB != "postgres" || r.Table != "bench_uuid" || r.Err != "boom" {
+			t.Fatalf("result = %+v", r)
+		}
+	})
+	t.Run("失敗時の扱い_中断されたら続行しない", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.FailFast = false
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := isolateFailure(ctx, cfg, "mysql", "bench_auto", boom); err == nil {
+			t.Fatal("err = nil, want error after cancel")
+		}
+	})
+}
+
+func TestPointLoopWarmup(t *testing.T) {
+	slow := func(ctx context.Context, i int) error {
+		if i < 2 {
+			time.Sleep(5 * time.Millisecond)
+		}
+		return nil
+	}
+	t.Run("ウォームアップ_先頭N件と残りに分ける", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.PointWarmup = 2
+		got, err := pointLoop(context.Background(), cfg, slog.Default(), 50, slow)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Warm < 0.01 || got.Steady <= 0 || got.Steady >= got.Warm {
+			t.Fatalf("warm = %v, steady = %v, want warm >= 0.01 and 0 < steady < warm", got.Warm, got.Steady)
+		}
+		if d := got.Warm + got.Steady - got.Seconds; d > 1e-9 || d < -1e-9 {
+			t.Fatalf("warm + steady = %v, want round time %v", got.Warm+got.Steady, got.Seconds)
+		}
+	})
+	t.Run("ウォームアップ_件数以上なら全件をwarmに入れる", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.PointWarmup = 10
+		got, err := pointLoop(context.Background(), cfg, slog.Default(), 3, slow)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Warm <= 0 || got.Steady != 0 {
+			t.Fatalf("warm = %v, steady = %v, want steady 0", got.Warm, got.Steady)
+		}
+	})
+	t.Run("ウォームアップ_無効なら0", func(t *testing.T) {
+		got, err := pointLoop(context.Background(), DefaultConfig(), slog.Default(), 3, slow)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Warm != 0 || got.Steady != 0 {
+			t.Fatalf("warm = %v, steady = %v, want 0", got.Warm, got.Steady)
+		}
+	})
+}
+
+func TestValueBytes(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		v    any
+		want int64
+	}{
+		{"CHAR36", uuid.New().String(), 36},
+		{"BINARY16", UUIDToBytes(uuid.New()), 16},
+		{"UUID型", uuid.New(), 16},
+		{"整数", int64(42), 8},
+		{"未知の型", 1.5, 0},
+	} {
+		t.Run("転送バイト数_"+tc.name, func(t *testing.T) {
+			if got := valueBytes(tc.v); got != tc.want {
+				t.Fatalf("valueBytes(%v) = %d, want %d", tc.v, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestUUIDBytesProbeInsert(t *testing.T) {
+	t.Run("往復検査_追加カラムも埋める", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.ExtraColumns = []ColumnSpec{{Name: "score", Type: "double"}, {Name: "note", Type: "varchar", Size: 12}}
+		query, args := uuidBytesProbeInsert(cfg)
+		if want := "INSERT INTO bench_uuid_bin (id, payload, score, note) VALUES (?, ?, ?, ?)"; query != want {
+			t.Fatalf("query = %q, want %q", query, want)
+		}
+		want := []any{UUIDToBytes(uuidBytesProbe), "uuid-bytes-probe", cfg.ExtraColumns[0].Value(0), cfg.ExtraColumns[1].Value(0)}
+		if len(args) != len(want) {
+			t.Fatalf("args = %v, want %v", args, want)
+		}
+		if id, ok := args[0].([]byte); !ok || !slices.Equal(id, want[0].([]byte)) {
+			t.Fatalf("args[0] = %v, want %x", args[0], want[0])
+		}
+		for i := 1; i < len(want); i++ {
+			if args[i] != want[i] {
+				t.Fatalf("args[%d] = %v, want %v", i, args[i], want[i])
+			}
+		}
+	})
+}
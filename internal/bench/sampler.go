@@ -0,0 +1,198 @@
+package bench
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// mysqlSampledStatus は -metrics-interval で MySQL の SHOW GLOBAL STATUS から読む累計カウンタ。
+// データ・REDO ログの書き込み量、fsync、ダーティページのフラッシュ、ディスク読み込み、行ロック待ちを見る。
+var mysqlSampledStatus = []string{
+	"Innodb_buffer_pool_pages_flushed",
+	"Innodb_buffer_pool_reads",
+	"Innodb_data_fsyncs",
+	"Innodb_data_written",
+	"Innodb_os_log_written",
+	"Innodb_row_lock_waits",
+}
+
+// metricsSampler は -metrics-interval 指定時に、1 方式の計測中に DB 側のカウンタを一定間隔で読み、
+// 前回からの差分を時系列のファイルへ書き出すバックグラウンドのゴルーチン。
+type metricsSampler struct {
+	cancel context.CancelFunc
+	done   chan error
+}
+
+// startMetricsSampler は cfg.MetricsInterval が正なら、kind の table を計測する間のサンプリングを始める。
+// 出力は cfg.MetricsDir の <db>[_<server>]_<table>_metrics.csv。無効なら nil を返す（Stop は nil でも呼べる）。
+func startMetricsSampler(ctx context.Context, db *sql.DB, cfg Config, kind, table string) *metricsSampler {
+	if cfg.MetricsInterval <= 0 {
+		return nil
+	}
+	server, _ := ctx.Value(latencyServerKey{}).(string)
+	path := filepath.Join(cfg.MetricsDir, latencyFileName(kind, server, table, "metrics"))
+	ctx, cancel := context.WithCancel(ctx)
+	s := &metricsSampler{cancel: cancel, done: make(chan error, 1)}
+	go func() {
+		s.done <- sampleMetrics(ctx, db, kind, cfg.MetricsInterval, path)
+	}()
+	return s
+}
+
+// Stop はサンプリングを止めてファイルを閉じ、サンプリング中に起きたエラーを返す。nil でも呼べる。
+func (s *metricsSampler) Stop() error {
+	if s == nil {
+		return nil
+	}
+	s.cancel()
+	if err := <-s.done; err != nil {
+		return fmt.Errorf("metrics sampling: %w", err)
+	}
+	return nil
+}
+
+// sampleMetrics は ctx が終わるまで interval ごとに kind のカウンタを読み、path へ差分を 1 行ずつ書く。
+// 列は最初に読めたカウンタで決め、以後に現れない項目は空欄にする。
+func sampleMetrics(ctx context.Context, db *sql.DB, kind string, interval time.Duration, path string) (err error) {
+	prev, err := readServerMetrics(ctx, db, kind)
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	names := slices.Sorted(maps.Keys(prev))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	defer func() {
+		err = errors.Join(err, w.Flush(), f.Close())
+	}()
+	if _, err := w.WriteString(metricsHeader(names)); err != nil {
+		return err
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		cur, err := readServerMetrics(ctx, db, kind)
+		if err != nil {
+			// 計測の終了で打ち切られた読み取りは失敗扱いにしない。
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		if _, err := w.WriteString(metricsRow(time.Since(start), names, prev, cur)); err != nil {
+			return err
+		}
+		prev = cur
+	}
+}
+
+// metricsHeader は -metrics-interval の出力ファイルの見出し行を返す。
+func metricsHeader(names []string) string {
+	return "elapsed_sec," + strings.Join(names, ",") + "\n"
+}
+
+// metricsRow は経過秒と、names の各カウンタの prev から cur への差分を CSV の 1 行にする。
+// どちらかに無い項目は空欄にする。
+func metricsRow(elapsed time.Duration, names []string, prev, cur map[string]int64) string {
+	fields := make([]string, 0, len(names)+1)
+	fields = append(fields, strconv.FormatFloat(elapsed.Seconds(), 'f', 3, 64))
+	for _, name := range names {
+		fields = append(fields, formatOptionalInt(metricDelta(prev, cur, name)))
+	}
+	return strings.Join(fields, ",") + "\n"
+}
+
+// readServerMetrics は kind のサーバ全体の累計カウンタを読む。
+// MySQL は mysqlSampledStatus、PostgreSQL は pg_stat_bgwriter の整数列（バージョンで列が違うため読めたものすべて）と
+// 現在の WAL 位置（wal_bytes）を返す。
+func readServerMetrics(ctx context.Context, db *sql.DB, kind string) (map[string]int64, error) {
+	if kind == "mysql" {
+		return mysqlStatusCounters(ctx, db)
+	}
+	m, err := pgBgwriterCounters(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	var wal int64
+	if err := db.QueryRowContext(ctx, "SELECT pg_wal_lsn_diff(pg_current_wal_lsn(), '0/0')::bigint").Scan(&wal); err != nil {
+		return nil, fmt.Errorf("postgres wal position query failed: %w", err)
+	}
+	m["wal_bytes"] = wal
+	return m, nil
+}
+
+// mysqlStatusCounters は mysqlSampledStatus の現在値を返す。
+func mysqlStatusCounters(ctx context.Context, db *sql.DB) (map[string]int64, error) {
+	query := "SHOW GLOBAL STATUS WHERE Variable_name IN (?" + strings.Repeat(", ?", len(mysqlSampledStatus)-1) + ")"
+	args := make([]any, len(mysqlSampledStatus))
+	for i, name := range mysqlSampledStatus {
+		args[i] = name
+	}
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("mysql global status query failed: %w", err)
+	}
+	defer rows.Close()
+	m := make(map[string]int64, len(mysqlSampledStatus))
+	for rows.Next() {
+		var name, value string
+		if err := rows.Scan(&name, &value); err != nil {
+			return nil, err
+		}
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			m[name] = n
+		}
+	}
+	return m, rows.Err()
+}
+
+// pgBgwriterCounters は pg_stat_bgwriter の整数列を列名をキーに返す。stats_reset などの整数でない列は除く。
+func pgBgwriterCounters(ctx context.Context, db *sql.DB) (map[string]int64, error) {
+	rows, err := db.QueryContext(ctx, "SELECT * FROM pg_stat_bgwriter")
+	if err != nil {
+		return nil, fmt.Errorf("postgres pg_stat_bgwriter query failed: %w", err)
+	}
+	defer rows.Close()
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]int64, len(cols))
+	for rows.Next() {
+		vals := make([]any, len(cols))
+		ptrs := make([]any, len(cols))
+		for i := range vals {
+			ptrs[i] = &vals[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+		for i, v := range vals {
+			if n, ok := v.(int64); ok {
+				m[cols[i]] = n
+			}
+		}
+	}
+	return m, rows.Err()
+}
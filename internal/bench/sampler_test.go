@@ -0,0 +1,43 @@
+package bench
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMetricsRow(t *testing.T) {
+	t.Run("時系列_見出しは経過秒とカウンタ名", func(t *testing.T) {
+		if got := metricsHeader([]string{"a", "b"}); got != "elapsed_sec,a,b\n" {
+			t.Fatalf("metricsHeader = %q", got)
+		}
+	})
+
+	t.Run("時系列_前回からの差分を書き無い項目は空欄", func(t *testing.T) {
+		prev := map[string]int64{"a": 10, "b": 5}
+		cur := map[string]int64{"a": 25}
+		got := metricsRow(1500*time.Millisecond, []string{"a", "b"}, prev, cur)
+		if want := "1.500,15,\n"; got != want {
+			t.Fatalf("metricsRow = %q, want %q", got, want)
+		}
+	})
+}
+
+func TestMetricsSamplerDisabled(t *testing.T) {
+	t.Run("時系列_無効ならサンプラーを作らずStopはnilで呼べる", func(t *testing.T) {
+		s := startMetricsSampler(t.Context(), nil, DefaultConfig(), "mysql", "bench_auto")
+		if s != nil {
+			t.Fatal("sampler started with metrics-interval 0")
+		}
+		if err := s.Stop(); err != nil {
+			t.Fatalf("Stop = %v", err)
+		}
+	})
+
+	t.Run("時系列_間隔が負ならエラー", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.MetricsInterval = -time.Second
+		if err := ValidateConfig(cfg); err == nil {
+			t.Fatal("ValidateConfig error = nil, want error")
+		}
+	})
+}
@@ -0,0 +1,158 @@
+package bench
+
+import (
+	"fmt"
+	"strings"
+)
+
+// scorecardBaseline は倍率の基準にする連番テーブル。並列挿入などの追加フェーズは同じ接尾辞の連番テーブルを基準にする。
+const scorecardBaseline = "bench_auto"
+
+// scorecardSuffixes は基準テーブルを接尾辞で対応付ける追加フェーズのテーブル名接尾辞。
+var scorecardSuffixes = []string{"_concurrent", "_mixed", "_pgx", "_part"}
+
+// strategyLabels はスコアカードに表示する方式の短い説明。
+var strategyLabels = map[string]string{
+	"bench_uuid_char":        "CHAR(36)",
+	"bench_uuid_char32":      "CHAR(32) hex",
+	"bench_uuid_bin":         "BINARY(16)",
+	"bench_uuid_bin_swapped": "BINARY(16) swapped",
+	"bench_uuid_bin_native":  "BINARY(16) UUID_TO_BIN(UUID())",
+	"bench_uuid_b64":         "VARCHAR(22) Base64",
+	"bench_uuid_comb":        "COMB UUID",
+	"bench_uuid_v1":          "UUIDv1",
+	"bench_uuid_v1_swapped":  "UUIDv1 swapped",
+	"bench_child_uuid":       "UUID FK child",
+	"bench_child_uuid_nofk":  "UUID child, no FK",
+	"bench_uuid":             "UUID",
+	"bench_uuid_bytea":       "BYTEA",
+	"bench_uuid_tenant":      "(tenant_id, UUID)",
+	"bench_uuid_seq":         "UUID + seq",
+	"bench_uuid_rowid":       "UUID secondary, no PK",
+	"bench_hybrid":           "BIGINT + UUID secondary",
+	"bench_int_shuffled":     "BIGINT shuffled",
+	"bench_gapless":          "BIGINT gapless",
+	"bench_natural":          "natural key",
+	"bench_uuid_concurrent":  "UUID concurrent",
+	"bench_uuid_mixed":       "UUID mixed",
+	"bench_uuid_pgx":         "UUID pgx",
+	"bench_uuid_part":        "UUID partitioned",
+}
+
+// dbLabels はスコアカードに表示する DB 名。
+var dbLabels = map[string]string{"mysql": "MySQL", "postgres": "PostgreSQL"}
+
+// ScorecardEntry は 1 方式の指標を、同じ DB・同じ接続先の連番基準に対する倍率で表す。
+// 倍率は 1 より大きいほど基準より遅い（大きい）。算出できない指標は 0。
+type ScorecardEntry struct {
+	DB       string
+	Server   string
+	Table    string
+	Baseline string
+	// Insert は 1 行あたりの挿入時間、Lookup は 1 件あたりの点検索時間の倍率。
+	Insert float64
+	Lookup float64
+	// Size はデータ長 + インデックス長の倍率（-mysql-table-sizes / -pg-vacuum 指定時のみ）。
+	Size float64
+}
+
+// scorecardBaselineFor は table の倍率の基準にするテーブル名を返す。
+// 外部キー計測の子テーブルは、制約の有無が同じ連番参照の子テーブルを基準にする。
+func scorecardBaselineFor(table string) string {
+	if strings.HasPrefix(table, "bench_child_") {
+		if strings.HasSuffix(table, "_nofk") {
+			return "bench_child_auto_nofk"
+		}
+		return "bench_child_auto"
+	}
+	for _, s := range scorecardSuffixes {
+		if strings.HasSuffix(table, s) {
+			return scorecardBaseline + s
+		}
+	}
+	return scorecardBaseline
+}
+
+// Scorecard は results を DB・接続先ごとに、連番の基準に対する倍率へ正規化する。
+// MySQL と PostgreSQL の秒数を直接比べるのではなく、それぞれの DB の中で連番と比べるため公平に並べられる。
+// 基準がない方式と、失敗した結果は含めない。
+func Scorecard(results []Result) []ScorecardEntry {
+	type key struct{ db, server, label, table string }
+	byKey := make(map[key]Result)
+	for _, r := range results {
+		if r.Err == "" {
+			byKey[key{r.DB, r.Server, r.Label, r.Table}] = r
+		}
+	}
+	var entries []ScorecardEntry
+	for _, r := range results {
+		baseTable := scorecardBaselineFor(r.Table)
+		if r.Err != "" || r.Table == baseTable {
+			continue
+		}
+		base, ok := byKey[key{r.DB, r.Server, r.Label, baseTable}]
+		if !ok {
+			continue
+		}
+		entries = append(entries, ScorecardEntry{
+			DB:       r.DB,
+			Server:   r.Server,
+			Table:    r.Table,
+			Baseline: baseTable,
+			Insert:   ratio(perOp(r.InsertSeconds, r.InsertRows), perOp(base.InsertSeconds, base.InsertRows)),
+			Lookup:   ratio(perOp(r.PointSeconds, r.PointLookupCount), perOp(base.PointSeconds, base.PointLookupCount)),
+			Size:     ratio(float64(r.DataBytes+r.IndexBytes), float64(base.DataBytes+base.IndexBytes)),
+		})
+	}
+	return entries
+}
+
+// perOp は n 件ぶんの秒数 sec を 1 件あたりにする。n が 0 なら 0。
+func perOp(sec float64, n int) float64 {
+	if n <= 0 {
+		return 0
+	}
+	return sec / float64(n)
+}
+
+// ratio は v / base を返す。どちらかが 0 以下なら算出できないものとして 0。
+func ratio(v, base float64) float64 {
+	if v <= 0 || base <= 0 {
+		return 0
+	}
+	return v / base
+}
+
+// FormatScorecard はスコアカードを 1 方式 1 行の文で整形する。
+// 例: "MySQL BINARY(16) [bench_uuid_bin]: inserts 1.40x, lookups 1.10x, size 0.70x (vs bench_auto)"
+func FormatScorecard(entries []ScorecardEntry) string {
+	var out strings.Builder
+	out.WriteString("=== Scorecard (per DB, relative to its own auto-increment baseline) ===\n")
+	for _, e := range entries {
+		name := dbLabels[e.DB]
+		if name == "" {
+			name = e.DB
+		}
+		if e.Server != "" {
+			name += " (" + e.Server + ")"
+		}
+		label := strategyLabels[e.Table]
+		if label == "" {
+			label = e.Table
+		}
+		var parts []string
+		for _, m := range []struct {
+			name string
+			v    float64
+		}{{"inserts", e.Insert}, {"lookups", e.Lookup}, {"size", e.Size}} {
+			if m.v > 0 {
+				parts = append(parts, fmt.Sprintf("%s %.2fx", m.name, m.v))
+			}
+		}
+		if len(parts) == 0 {
+			continue
+		}
+		fmt.Fprintf(&out, "%s %s [%s]: %s (vs %s)\n", name, label, e.Table, strings.Join(parts, ", "), e.Baseline)
+	}
+	return out.String()
+}
@@ -0,0 +1,54 @@
+package bench
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestScorecard(t *testing.T) {
+	results := []Result{
+		{DB: "mysql", Table: "bench_auto", InsertRows: 100, InsertSeconds: 1, PointLookupCount: 10, PointSeconds: 0.1, DataBytes: 1000},
+		{DB: "mysql", Table: "bench_uuid_bin", InsertRows: 100, InsertSeconds: 1.4, PointLookupCount: 10, PointSeconds: 0.11, DataBytes: 700},
+		{DB: "mysql", Table: "bench_uuid_char", Err: "boom"},
+		{DB: "postgres", Table: "bench_auto", InsertRows: 100, InsertSeconds: 2, PointLookupCount: 10, PointSeconds: 0.2},
+		// -insert-duration で件数が違っても 1 行あたりで比べる。
+		{DB: "postgres", Table: "bench_uuid", InsertRows: 50, InsertSeconds: 2, PointLookupCount: 10, PointSeconds: 0.2},
+		{DB: "postgres", Table: "bench_uuid_concurrent", InsertRows: 100, InsertSeconds: 3},
+	}
+
+	t.Run("スコアカード_DBごとに連番基準の倍率にする", func(t *testing.T) {
+		got := Scorecard(results)
+		if len(got) != 2 {
+			t.Fatalf("entries = %+v, want 2", got)
+		}
+		near := func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+		if e := got[0]; e.Table != "bench_uuid_bin" || !near(e.Insert, 1.4) || !near(e.Lookup, 1.1) || !near(e.Size, 0.7) {
+			t.Fatalf("mysql entry = %+v", e)
+		}
+		if e := got[1]; e.Table != "bench_uuid" || !near(e.Insert, 2) || !near(e.Lookup, 1) || e.Size != 0 {
+			t.Fatalf("postgres entry = %+v", e)
+		}
+	})
+
+	t.Run("スコアカード_1方式1行の文にする", func(t *testing.T) {
+		out := FormatScorecard(Scorecard(results))
+		for _, want := range []string{
+			"MySQL BINARY(16) [bench_uuid_bin]: inserts 1.40x, lookups 1.10x, size 0.70x (vs bench_auto)\n",
+			"PostgreSQL UUID [bench_uuid]: inserts 2.00x, lookups 1.00x (vs bench_auto)\n",
+		} {
+			if !strings.Contains(out, want) {
+				t.Fatalf("missing %q in:\n%s", want, out)
+			}
+		}
+	})
+
+	t.Run("スコアカード_追加フェーズは同じ接尾辞の連番を基準にする", func(t *testing.T) {
+		if got := scorecardBaselineFor("bench_uuid_concurrent"); got != "bench_auto_concurrent" {
+			t.Fatalf("baseline = %s", got)
+		}
+		if got := scorecardBaselineFor("bench_uuid_bin"); got != "bench_auto" {
+			t.Fatalf("baseline = %s", got)
+		}
+	})
+}
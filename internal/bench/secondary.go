@@ -0,0 +1,115 @@
+package bench
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// secondaryUUIDPKTables は DB ごとの、UUID を主キーにした比較対象のテーブル。
+var secondaryUUIDPKTables = map[string]string{"mysql": "bench_uuid_bin", "postgres": "bench_uuid"}
+
+// indexOnlyLookups は cfg.SecondaryLookups が真なら、sample の各キーを引数に query を点検索として計測した秒数を返す。
+// query は二次インデックスだけで答えられる検索で、通常の点検索との差が主キー（ヒープ）への 2 回目の探索にかかる時間になる。
+// 偽なら何もせず 0 を返す。
+func indexOnlyLookups[K any](ctx context.Context, db *sql.DB, cfg Config, log *slog.Logger, query string, sample []K) (float64, error) {
+	if !cfg.SecondaryLookups {
+		return 0, nil
+	}
+	s, err := prepare(ctx, db, cfg, query)
+	if err != nil {
+		return 0, err
+	}
+	defer s.Close()
+	// -latency-dump には主キーでの点検索だけを書き出す。
+	t, err := pointLoop(withoutLatencyDump(ctx), cfg, log.With("phase", "index_only"), len(sample), func(ctx context.Context, i int) error {
+		var one int
+		return s.QueryRowContext(ctx, sample[i]).Scan(&one)
+	})
+	return t.Seconds, err
+}
+
+// SecondaryLookup は 1 つの DB・接続先について、1 件あたりの点検索時間を主キーの種類ごとに並べたもの。
+// 計測していない値は 0。
+type SecondaryLookup struct {
+	DB     string
+	Server string
+	Label  string
+	// IntPK は bench_auto、UUIDPK は UUID 主キー表（MySQL は bench_uuid_bin、PostgreSQL は bench_uuid）の点検索。
+	IntPK  float64
+	UUIDPK float64
+	// Secondary は bench_hybrid の public_id（二次インデックス）経由の点検索、
+	// IndexOnly は同じキーで二次インデックスだけを読む検索（-secondary-lookups 指定時）。
+	Secondary float64
+	IndexOnly float64
+}
+
+// SecondaryLookups は results から、連番主キー・UUID 主キー・UUID 二次インデックスの点検索時間を
+// DB・接続先ごとに 1 件あたりへ揃えて並べる。bench_hybrid の結果がない DB は含めない。
+func SecondaryLookups(results []Result) []SecondaryLookup {
+	type key struct{ db, server, label string }
+	var order []key
+	byKey := make(map[key]*SecondaryLookup)
+	for _, r := range results {
+		if r.Err != "" {
+			continue
+		}
+		k := key{r.DB, r.Server, r.Label}
+		l, ok := byKey[k]
+		if !ok {
+			l = &SecondaryLookup{DB: r.DB, Server: r.Server, Label: r.Label}
+			byKey[k] = l
+			order = append(order, k)
+		}
+		switch r.Table {
+		case scorecardBaseline:
+			l.IntPK = perOp(r.PointSeconds, r.PointLookupCount)
+		case secondaryUUIDPKTables[r.DB]:
+			l.UUIDPK = perOp(r.PointSeconds, r.PointLookupCount)
+		case "bench_hybrid":
+			l.Secondary = perOp(r.PointSeconds, r.PointLookupCount)
+			l.IndexOnly = perOp(r.IndexOnlyPointSeconds, r.PointLookupCount)
+		}
+	}
+	var out []SecondaryLookup
+	for _, k := range order {
+		if l := byKey[k]; l.Secondary > 0 {
+			out = append(out, *l)
+		}
+	}
+	return out
+}
+
+// FormatSecondaryLookups は SecondaryLookups を 1 DB 1 行で、連番主キーに対する倍率付きで整形する。
+// 例: "MySQL: BIGINT PK 20.0us | UUID PK 25.0us (1.25x) | UUID secondary 31.0us (1.55x) | UUID secondary, index only 18.0us (0.90x)"
+func FormatSecondaryLookups(lookups []SecondaryLookup) string {
+	var out strings.Builder
+	out.WriteString("=== Point lookups per key: BIGINT PK vs UUID PK vs UUID secondary index ===\n")
+	for _, l := range lookups {
+		name := dbLabels[l.DB]
+		if name == "" {
+			name = l.DB
+		}
+		if l.Server != "" {
+			name += " (" + l.Server + ")"
+		}
+		var parts []string
+		for _, m := range []struct {
+			name string
+			v    float64
+		}{{"BIGINT PK", l.IntPK}, {"UUID PK", l.UUIDPK}, {"UUID secondary", l.Secondary}, {"UUID secondary, index only", l.IndexOnly}} {
+			if m.v <= 0 {
+				continue
+			}
+			part := fmt.Sprintf("%s %.1fus", m.name, m.v*1e6)
+			if x := ratio(m.v, l.IntPK); x > 0 && m.name != "BIGINT PK" {
+				part += fmt.Sprintf(" (%.2fx)", x)
+			}
+			parts = append(parts, part)
+		}
+		fmt.Fprintf(&out, "%s: %s\n", name, strings.Join(parts, " | "))
+	}
+	return out.String()
+}
@@ -0,0 +1,37 @@
+package bench
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSecondaryLookups(t *testing.T) {
+	results := []Result{
+		{DB: "mysql", Table: "bench_auto", PointLookupCount: 10, PointSeconds: 0.0002},
+		{DB: "mysql", Table: "bench_uuid_bin", PointLookupCount: 10, PointSeconds: 0.00025},
+		{DB: "mysql", Table: "bench_hybrid", PointLookupCount: 10, PointSeconds: 0.0003, IndexOnlyPointSeconds: 0.00018},
+		// bench_hybrid のない DB は比較できないため含めない。
+		{DB: "postgres", Table: "bench_auto", PointLookupCount: 10, PointSeconds: 0.0002},
+	}
+
+	t.Run("集計_1件あたりに揃える", func(t *testing.T) {
+		got := SecondaryLookups(results)
+		if len(got) != 1 {
+			t.Fatalf("lookups = %+v, want 1", got)
+		}
+		near := func(a, b float64) bool { return math.Abs(a-b) < 1e-12 }
+		l := got[0]
+		if !near(l.IntPK, 20e-6) || !near(l.UUIDPK, 25e-6) || !near(l.Secondary, 30e-6) || !near(l.IndexOnly, 18e-6) {
+			t.Fatalf("lookup = %+v", l)
+		}
+	})
+
+	t.Run("整形_連番主キーに対する倍率", func(t *testing.T) {
+		got := FormatSecondaryLookups(SecondaryLookups(results))
+		want := "=== Point lookups per key: BIGINT PK vs UUID PK vs UUID secondary index ===\n" +
+			"MySQL: BIGINT PK 20.0us | UUID PK 25.0us (1.25x) | UUID secondary 30.0us (1.50x) | UUID secondary, index only 18.0us (0.90x)\n"
+		if got != want {
+			t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+		}
+	})
+}
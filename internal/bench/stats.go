@@ -0,0 +1,221 @@
+package bench
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Mean は xs の算術平均を返す。空なら 0。
+func Mean(xs []float64) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, x := range xs {
+		sum += x
+	}
+	return sum / float64(len(xs))
+}
+
+// StdDev は xs の標本標準偏差 (n-1 で割る) を返す。要素が 2 未満なら 0。
+func StdDev(xs []float64) float64 {
+	if len(xs) < 2 {
+		return 0
+	}
+	m := Mean(xs)
+	ss := 0.0
+	for _, x := range xs {
+		ss += (x - m) * (x - m)
+	}
+	return math.Sqrt(ss / float64(len(xs)-1))
+}
+
+// RelStdDev は変動係数 (標準偏差 / 平均) を返す。平均が 0 なら 0。
+func RelStdDev(xs []float64) float64 {
+	m := Mean(xs)
+	if m == 0 {
+		return 0
+	}
+	return StdDev(xs) / m
+}
+
+// Median は xs の中央値を返す。要素数が偶数なら中央 2 値の平均。空なら 0。
+func Median(xs []float64) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+	sorted := slices.Sorted(slices.Values(xs))
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// Percentile は xs の p パーセンタイル (0 < p <= 100) を最近順位法で返す。空なら 0。
+func Percentile(xs []float64, p float64) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+	sorted := slices.Sorted(slices.Values(xs))
+	k := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[min(max(k, 1), len(sorted))-1]
+}
+
+// TrimmedMean は上下それぞれ trim の割合（切り捨て）の要素を除いた平均を返す。
+// 除いた結果が空になる場合は中央値を返す。
+func TrimmedMean(xs []float64, trim float64) float64 {
+	k := int(trim * float64(len(xs)))
+	if len(xs)-2*k <= 0 {
+		return Median(xs)
+	}
+	sorted := slices.Sorted(slices.Values(xs))
+	return Mean(sorted[k : len(sorted)-k])
+}
+
+// SpearmanRank は並び順（位置）と values の順位のスピアマン順位相関係数を返す。
+// values を主キー順に読んだ挿入順の連番とすれば、1 は挿入順どおり、0 付近は無相関に散らばっていることを表す。
+// 同値は出現順で順位を付ける。要素が 2 未満なら 0。
+func SpearmanRank(values []int64) float64 {
+	n := len(values)
+	if n < 2 {
+		return 0
+	}
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return cmp.Compare(values[a], values[b]) })
+	sumD2 := 0.0
+	for rank, pos := range order {
+		d := float64(rank - pos)
+		sumD2 += d * d
+	}
+	nf := float64(n)
+	return 1 - 6*sumD2/(nf*(nf*nf-1))
+}
+
+// aggregateKinds は -aggregate で選べる集計方法。
+var aggregateKinds = map[string]bool{"mean": true, "median": true, "trimmed": true}
+
+// Aggregate は kind に従って xs の代表値を返す。
+// kind は mean / median / trimmed のいずれかで、trimmed は TrimmedMean(xs, trim)。
+func Aggregate(kind string, xs []float64, trim float64) float64 {
+	switch kind {
+	case "median":
+		return Median(xs)
+	case "trimmed":
+		return TrimmedMean(xs, trim)
+	default:
+		return Mean(xs)
+	}
+}
+
+// AggregateLabel は結果メタデータへ記録する集計方法の表記を返す。
+func AggregateLabel(kind string, trim float64) string {
+	if kind == "trimmed" {
+		return fmt.Sprintf("trimmed(%g)", trim)
+	}
+	return kind
+}
+
+// StudentTQuantile は自由度 df の t 分布の下側確率 p（0 < p < 1）に対応する分位点を返す。
+// 95% 信頼区間の幅には StudentTQuantile(0.975, n-1) を使う。df が 1 未満なら NaN。
+func StudentTQuantile(p float64, df int) float64 {
+	if df < 1 || p <= 0 || p >= 1 {
+		return math.NaN()
+	}
+	if p < 0.5 {
+		return -StudentTQuantile(1-p, df)
+	}
+	// 累積分布は単調増加なので、上端を広げてから二分法で解く。
+	lo, hi := 0.0, 1.0
+	for studentTCDF(hi, df) < p {
+		lo, hi = hi, hi*2
+	}
+	for range 100 {
+		mid := (lo + hi) / 2
+		if studentTCDF(mid, df) < p {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// studentTCDF は自由度 df の t 分布の累積分布関数。
+// P(T > |t|) = I_x(df/2, 1/2) / 2（x = df / (df + t²)）を使う。
+func studentTCDF(t float64, df int) float64 {
+	v := float64(df)
+	tail := regIncBeta(v/2, 0.5, v/(v+t*t)) / 2
+	if t < 0 {
+		return tail
+	}
+	return 1 - tail
+}
+
+// regIncBeta は正則化不完全ベータ関数 I_x(a, b) を連分数展開で求める。
+func regIncBeta(a, b, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	lab, _ := math.Lgamma(a + b)
+	front := math.Exp(lab - la - lb + a*math.Log(x) + b*math.Log(1-x))
+	// 連分数は x < (a+1)/(a+b+2) で速く収束するため、それ以外は対称性 I_x(a,b) = 1 - I_{1-x}(b,a) を使う。
+	if x < (a+1)/(a+b+2) {
+		return front * betaContinuedFraction(a, b, x) / a
+	}
+	return 1 - front*betaContinuedFraction(b, a, 1-x)/b
+}
+
+// betaContinuedFraction は不完全ベータ関数の連分数を修正 Lentz 法で評価する。
+func betaContinuedFraction(a, b, x float64) float64 {
+	const tiny = 1e-300
+	c, d := 1.0, 1-(a+b)*x/(a+1)
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	h := d
+	for m := 1; m <= 300; m++ {
+		mf := float64(m)
+		for _, num := range []float64{
+			mf * (b - mf) * x / ((a + 2*mf - 1) * (a + 2*mf)),
+			-(a + mf) * (a + b + mf) * x / ((a + 2*mf) * (a + 2*mf + 1)),
+		} {
+			d = 1 + num*d
+			if math.Abs(d) < tiny {
+				d = tiny
+			}
+			c = 1 + num/c
+			if math.Abs(c) < tiny {
+				c = tiny
+			}
+			d = 1 / d
+			h *= d * c
+		}
+		if math.Abs(d*c-1) < 1e-15 {
+			break
+		}
+	}
+	return h
+}
+
+// ConfidenceInterval95 は xs を正規母集団からの標本とみなし、平均の 95% 信頼区間を
+// t 分布で求めて下限と上限を返す。要素が 2 未満なら区間は求まらないので平均を両端として返す。
+func ConfidenceInterval95(xs []float64) (low, high float64) {
+	m := Mean(xs)
+	if len(xs) < 2 {
+		return m, m
+	}
+	half := StudentTQuantile(0.975, len(xs)-1) * StdDev(xs) / math.Sqrt(float64(len(xs)))
+	return m - half, m + half
+}
@@ -0,0 +1,109 @@
+package bench
+
+import (
+	"math"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	t.Run("統計_平均と標本標準偏差", func(t *testing.T) {
+		xs := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+		if got := Mean(xs); got != 5 {
+			t.Fatalf("Mean = %v, want 5", got)
+		}
+		// 標本分散は 32/7。
+		if got, want := StdDev(xs), math.Sqrt(32.0/7.0); math.Abs(got-want) > 1e-12 {
+			t.Fatalf("StdDev = %v, want %v", got, want)
+		}
+		if got, want := RelStdDev(xs), math.Sqrt(32.0/7.0)/5; math.Abs(got-want) > 1e-12 {
+			t.Fatalf("RelStdDev = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("統計_パーセンタイルは最近順位法", func(t *testing.T) {
+		xs := []float64{5, 1, 4, 2, 3}
+		for _, c := range []struct{ p, want float64 }{{50, 3}, {95, 5}, {1, 1}, {100, 5}} {
+			if got := Percentile(xs, c.p); got != c.want {
+				t.Fatalf("Percentile(%v) = %v, want %v", c.p, got, c.want)
+			}
+		}
+		if got := Percentile(nil, 99); got != 0 {
+			t.Fatalf("Percentile(nil) = %v, want 0", got)
+		}
+	})
+
+	t.Run("統計_中央値", func(t *testing.T) {
+		if got := Median([]float64{5, 1, 3}); got != 3 {
+			t.Fatalf("Median(odd) = %v, want 3", got)
+		}
+		if got := Median([]float64{4, 1, 3, 2}); got != 2.5 {
+			t.Fatalf("Median(even) = %v, want 2.5", got)
+		}
+	})
+
+	t.Run("統計_トリム平均は外れ値を除く", func(t *testing.T) {
+		xs := []float64{1, 1, 1, 1, 1, 1, 1, 1, 1, 100}
+		if got := TrimmedMean(xs, 0.1); got != 1 {
+			t.Fatalf("TrimmedMean = %v, want 1", got)
+		}
+		if got := Aggregate("mean", xs, 0.1); got != 10.9 {
+			t.Fatalf("Aggregate(mean) = %v, want 10.9", got)
+		}
+		if got := Aggregate("trimmed", []float64{1, 2}, 0.5); got != 1.5 {
+			t.Fatalf("Aggregate(trimmed, all trimmed) = %v, want median 1.5", got)
+		}
+	})
+
+	t.Run("統計_順位相関", func(t *testing.T) {
+		if got := SpearmanRank([]int64{1, 2, 3, 4}); got != 1 {
+			t.Fatalf("SpearmanRank(sorted) = %v, want 1", got)
+		}
+		if got := SpearmanRank([]int64{40, 30, 20, 10}); got != -1 {
+			t.Fatalf("SpearmanRank(reversed) = %v, want -1", got)
+		}
+		// d = (1, -1, 1, -1) なので 1 - 6*4/(4*15) = 0.6。
+		if got := SpearmanRank([]int64{2, 1, 4, 3}); math.Abs(got-0.6) > 1e-12 {
+			t.Fatalf("SpearmanRank = %v, want 0.6", got)
+		}
+	})
+
+	t.Run("統計_要素不足は0", func(t *testing.T) {
+		if Mean(nil) != 0 || StdDev([]float64{1}) != 0 || RelStdDev(nil) != 0 || Median(nil) != 0 || SpearmanRank([]int64{1}) != 0 {
+			t.Fatal("empty/single input should yield 0")
+		}
+	})
+}
+
+func TestStudentTQuantile(t *testing.T) {
+	t.Run("t分布_両側95%の臨界値が表の値と一致する", func(t *testing.T) {
+		// 自由度ごとの t(0.975) の表の値（小数 3 桁）。
+		for df, want := range map[int]float64{1: 12.706, 2: 4.303, 4: 2.776, 9: 2.262, 30: 2.042, 120: 1.980} {
+			if got := StudentTQuantile(0.975, df); math.Abs(got-want) > 5e-4 {
+				t.Fatalf("StudentTQuantile(0.975, %d) = %v, want %v", df, got, want)
+			}
+		}
+	})
+
+	t.Run("t分布_下側は符号を反転し中央は0", func(t *testing.T) {
+		if got := StudentTQuantile(0.025, 4); math.Abs(got+2.776) > 5e-4 {
+			t.Fatalf("StudentTQuantile(0.025, 4) = %v, want -2.776", got)
+		}
+		if got := StudentTQuantile(0.5, 3); math.Abs(got) > 1e-9 {
+			t.Fatalf("StudentTQuantile(0.5, 3) = %v, want 0", got)
+		}
+		if !math.IsNaN(StudentTQuantile(0.975, 0)) {
+			t.Fatal("df 0 should yield NaN")
+		}
+	})
+
+	t.Run("t分布_信頼区間は平均を中心にt×標準誤差の幅", func(t *testing.T) {
+		// 平均 3、標本標準偏差 sqrt(2.5)、n=5 なので半幅は 2.776 * sqrt(2.5/5) ≈ 1.963。
+		low, high := ConfidenceInterval95([]float64{1, 2, 3, 4, 5})
+		if math.Abs(low-1.037) > 1e-3 || math.Abs(high-4.963) > 1e-3 {
+			t.Fatalf("ConfidenceInterval95 = [%v, %v], want [1.037, 4.963]", low, high)
+		}
+		if low, high := ConfidenceInterval95([]float64{2}); low != 2 || high != 2 {
+			t.Fatalf("ConfidenceInterval95(single) = [%v, %v], want [2, 2]", low, high)
+		}
+	})
+}
@@ -0,0 +1,105 @@
+package bench
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+// StrategyInfo は -list-strategies で表示する方式 1 つぶんの説明。
+// Name は結果の table 列・-strategies に指定する名前と同じ。Flag が空なら既定で計測する。
+type StrategyInfo struct {
+	DB          string
+	Name        string
+	KeyType     string
+	Flag        string
+	Description string
+}
+
+// strategyCatalog は計測できる全方式の一覧。DB ごとに計測順で並べる。
+var strategyCatalog = []StrategyInfo{
+	{"mysql", "bench_auto", "BIGINT AUTO_INCREMENT", "", "Server-assigned sequential key (baseline)"},
+	{"mysql", "bench_uuid_char", "CHAR(36)", "", "Random UUIDv4 as its 36-char text form (-char-collation applies)"},
+	{"mysql", "bench_uuid_char32", "CHAR(32)", "-uuid-char32", "Random UUIDv4 as 32-char hex without hyphens (-char-collation applies)"},
+	{"mysql", "bench_uuid_bin", "BINARY(16)", "", "Random UUIDv4 as 16 raw bytes"},
+	{"mysql", "bench_uuid_bin_swapped", "BINARY(16)", "-uuid-bin-swapped", "UUID bytes in UUID_TO_BIN(uuid, 1) order (time fields first)"},
+	{"mysql", "bench_uuid_bin_native", "BINARY(16)", "-compare-with-native-function", "Server-side UUID_TO_BIN(UUID()) in the INSERT (MySQL UUIDv1, time_low first)"},
+	{"mysql", "bench_uuid_comb", "BINARY(16)", "-uuid-comb", "COMB UUID: v4 with a millisecond timestamp in the first 6 bytes"},
+	{"mysql", "bench_uuid_v1", "BINARY(16)", "-uuid-v1", "Time-based UUIDv1 as generated (time_low first); embeds the host MAC address"},
+	{"mysql", "bench_uuid_v1_swapped", "BINARY(16)", "-uuid-v1", "UUIDv1 in UUID_TO_BIN(uuid, 1) order (time-ordered); embeds the host MAC address"},
+	{"mysql", "bench_uuid_tenant", "(BIGINT, BINARY(16))", "", "Composite (tenant_id, uuid) key clustered per tenant"},
+	{"mysql", "bench_hybrid", "BIGINT AUTO_INCREMENT + BINARY(16) UNIQUE", "", "Sequential primary key with a public UUID secondary index"},
+	{"mysql", "bench_uuid_seq", "BINARY(16) + seq BIGINT", "-seq-correlation", "UUID key with an insert-order column to measure key/insert order correlation"},
+	{"mysql", "bench_uuid_rowid", "no PK + BINARY(16) KEY", "-rowid-table", "No primary key: InnoDB clusters by its hidden row id, UUID is a secondary index"},
+	{"mysql", "bench_int_shuffled", "BIGINT", "-shuffle-insert-order", "Client-assigned 1..n inserted in shuffled order"},
+	{"mysql", "bench_gapless", "BIGINT", "-gapless", "Gapless sequence from a counter row locked with SELECT ... FOR UPDATE per insert"},
+	{"mysql", "bench_natural", "(CHAR(2), VARCHAR(100))", "-natural-key", "Natural composite key (country, email)"},
+	{"mysql", "bench_uuid_b64", "VARCHAR(22) ascii_bin", "-uuid-base64", "UUID as 22-char unpadded Base64url text"},
+	{"mysql", "bench_auto_part", "BIGINT AUTO_INCREMENT, RANGE partitioned", "-partitions", "Sequential key RANGE-partitioned by id; the range query is pruned to one partition"},
+	{"mysql", "bench_uuid_part", "BINARY(16), KEY partitioned", "-partitions", "Random UUIDv4 key hash-partitioned across -partitions partitions"},
+	{"mysql", "bench_child_auto", "parent_id BIGINT + FOREIGN KEY", "-foreign-keys", "Child inserts referencing bench_auto with a declared foreign key"},
+	{"mysql", "bench_child_auto_nofk", "parent_id BIGINT", "-foreign-keys", "Child inserts referencing bench_auto with only an index"},
+	{"mysql", "bench_child_uuid", "parent_id BINARY(16) + FOREIGN KEY", "-foreign-keys", "Child inserts referencing bench_uuid_bin with a declared foreign key"},
+	{"mysql", "bench_child_uuid_nofk", "parent_id BINARY(16)", "-foreign-keys", "Child inserts referencing bench_uuid_bin with only an index"},
+	{"mysql", "bench_auto_concurrent", "BIGINT AUTO_INCREMENT", "-concurrent-workers", "Parallel inserts; reports lock waits and deadlocks"},
+	{"mysql", "bench_uuid_concurrent", "BINARY(16)", "-concurrent-workers", "Parallel inserts; reports lock waits and deadlocks"},
+	{"mysql", "bench_gapless_concurrent", "BIGINT", "-concurrent-workers -gapless", "Parallel inserts serialized on the gapless counter row; reports lock waits and deadlocks"},
+	{"mysql", "bench_auto_mixed", "BIGINT AUTO_INCREMENT", "-mixed-duration", "Concurrent point lookups and inserts; reports ops/sec and latency percentiles"},
+	{"mysql", "bench_uuid_mixed", "BINARY(16)", "-mixed-duration", "Concurrent point lookups and inserts; reports ops/sec and latency percentiles"},
+	{"postgres", "bench_auto", "BIGSERIAL", "", "Server-assigned sequential key (baseline)"},
+	{"postgres", "bench_uuid", "UUID", "", "Random UUIDv4 in the native uuid type"},
+	{"postgres", "bench_uuid_comb", "UUID", "-uuid-comb", "COMB UUID: v4 with a millisecond timestamp in the first 6 bytes"},
+	{"postgres", "bench_uuid_bytea", "BYTEA", "-pg-uuid-bytea", "Random UUIDv4 as 16 raw bytes (the BINARY(16) counterpart)"},
+	{"postgres", "bench_uuid_tenant", "(BIGINT, UUID)", "", "Composite (tenant_id, uuid) key"},
+	{"postgres", "bench_hybrid", "BIGSERIAL + UUID UNIQUE", "", "Sequential primary key with a public UUID secondary index"},
+	{"postgres", "bench_uuid_seq", "UUID + seq BIGINT", "-seq-correlation", "UUID key with an insert-order column to measure key/insert order correlation"},
+	{"postgres", "bench_int_shuffled", "BIGINT", "-shuffle-insert-order", "Client-assigned 1..n inserted in shuffled order"},
+	{"postgres", "bench_gapless", "BIGINT", "-gapless", "Gapless sequence from a counter row locked with SELECT ... FOR UPDATE per insert"},
+	{"postgres", "bench_natural", "(CHAR(2), VARCHAR(100))", "-natural-key", "Natural composite key (country, email)"},
+	{"postgres", "bench_uuid_b64", `VARCHAR(22) COLLATE "C"`, "-uuid-base64", "UUID as 22-char unpadded Base64url text"},
+	{"postgres", "bench_auto_part", "BIGSERIAL, RANGE partitioned", "-partitions", "Sequential key RANGE-partitioned by id; the range query is pruned to one partition"},
+	{"postgres", "bench_uuid_part", "UUID, HASH partitioned", "-partitions", "Random UUIDv4 key hash-partitioned across -partitions partitions"},
+	{"postgres", "bench_child_auto", "parent_id BIGINT REFERENCES", "-foreign-keys", "Child inserts referencing bench_auto with a declared foreign key"},
+	{"postgres", "bench_child_auto_nofk", "parent_id BIGINT", "-foreign-keys", "Child inserts referencing bench_auto with only an index"},
+	{"postgres", "bench_child_uuid", "parent_id UUID REFERENCES", "-foreign-keys", "Child inserts referencing bench_uuid with a declared foreign key"},
+	{"postgres", "bench_child_uuid_nofk", "parent_id UUID", "-foreign-keys", "Child inserts referencing bench_uuid with only an index"},
+	{"postgres", "bench_auto_concurrent", "BIGSERIAL", "-concurrent-workers", "Parallel inserts; reports deadlocks"},
+	{"postgres", "bench_uuid_concurrent", "UUID", "-concurrent-workers", "Parallel inserts; reports deadlocks"},
+	{"postgres", "bench_gapless_concurrent", "BIGINT", "-concurrent-workers -gapless", "Parallel inserts serialized on the gapless counter row; reports deadlocks"},
+	{"postgres", "bench_auto_mixed", "BIGSERIAL", "-mixed-duration", "Concurrent point lookups and inserts; reports ops/sec and latency percentiles"},
+	{"postgres", "bench_uuid_mixed", "UUID", "-mixed-duration", "Concurrent point lookups and inserts; reports ops/sec and latency percentiles"},
+	{"postgres", "bench_auto_pgx", "BIGSERIAL", "-pgxpool", "Pipelined batch inserts through pgxpool"},
+	{"postgres", "bench_uuid_pgx", "UUID", "-pgxpool", "Pipelined batch inserts through pgxpool"},
+}
+
+// Strategies は計測できる全方式の説明を返す。RegisterStrategy で追加した方式も末尾に含める。
+// 呼び出し側で変更しても一覧には影響しない。
+func Strategies() []StrategyInfo {
+	infos := append([]StrategyInfo(nil), strategyCatalog...)
+	for _, kind := range []string{"mysql", "postgres"} {
+		for _, s := range strategyRegistry[kind] {
+			name := s.Strategy.Name()
+			if !slices.ContainsFunc(infos, func(i StrategyInfo) bool { return i.DB == kind && i.Name == name }) {
+				infos = append(infos, StrategyInfo{DB: kind, Name: name, Description: "Registered with RegisterStrategy"})
+			}
+		}
+	}
+	return infos
+}
+
+// FormatStrategies は方式の一覧を DB・名前・列の型・有効化フラグ・説明の表に整形する。
+func FormatStrategies(infos []StrategyInfo) string {
+	var out strings.Builder
+	w := tabwriter.NewWriter(&out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DB\tSTRATEGY\tKEY TYPE\tENABLED BY\tDESCRIPTION")
+	for _, s := range infos {
+		flag := s.Flag
+		if flag == "" {
+			flag = "(default)"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", s.DB, s.Name, s.KeyType, flag, s.Description)
+	}
+	w.Flush()
+	return out.String()
+}
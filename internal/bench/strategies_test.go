@@ -0,0 +1,62 @@
+package bench
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStrategies(t *testing.T) {
+	// 全オプションを有効にした設定で作られるテーブルが、すべて一覧に載っていることを確かめる。
+	cfg := DefaultConfig()
+	cfg.SwappedBinary = true
+	cfg.UUIDComb = true
+	cfg.UUIDv1 = true
+	cfg.PGUUIDBytea = true
+	cfg.SeqCorrelation = true
+	cfg.RowIDTable = true
+	cfg.ShuffleInsertOrder = true
+	cfg.Gapless = true
+	cfg.NaturalKey = true
+	cfg.UUIDBase64 = true
+	cfg.UUIDChar32 = true
+	cfg.NativeUUIDFunction = true
+	cfg.ForeignKeys = true
+	cfg.Partitions = 4
+	cfg.ConcurrentWorkers = 2
+	cfg.MixedDuration = time.Second
+
+	listed := func(db string) []string {
+		var names []string
+		for _, s := range Strategies() {
+			if s.DB == db {
+				names = append(names, s.Name)
+			}
+		}
+		return names
+	}
+	t.Run("一覧_MySQLの全テーブルを含む", func(t *testing.T) {
+		got := listed("mysql")
+		for _, table := range mysqlTables(cfg) {
+			if !slices.Contains(got, table) {
+				t.Errorf("%s missing from strategy list", table)
+			}
+		}
+	})
+	t.Run("一覧_PostgreSQLの全テーブルを含む", func(t *testing.T) {
+		got := listed("postgres")
+		for _, table := range pgTables(cfg) {
+			if !slices.Contains(got, table) {
+				t.Errorf("%s missing from strategy list", table)
+			}
+		}
+	})
+	t.Run("一覧_既定で計測する方式は(default)と表示する", func(t *testing.T) {
+		out := FormatStrategies(Strategies()[:1])
+		lines := strings.Split(strings.TrimSpace(out), "\n")
+		if len(lines) != 2 || !strings.HasPrefix(lines[0], "DB") || !strings.Contains(lines[1], "(default)") {
+			t.Fatalf("out = %q", out)
+		}
+	})
+}
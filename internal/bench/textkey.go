@@ -0,0 +1,119 @@
+package bench
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+)
+
+// benchUUIDText は UUID を key で文字列にして保存する主キー table を計測する。
+// 手順は CHAR(36) の bench_uuid_char と同じで、範囲検索の代わりに ORDER BY + LIMIT の読み出し時間を計る。
+func benchUUIDText(ctx context.Context, db *sql.DB, cfg Config, kind, table string, key func(cfg Config, i int) string) (Result, error) {
+	log := slog.With("db", kind, "table", table)
+	gen := payloadFor(cfg)
+	insertStmt, err := prepare(ctx, db, cfg, insertSQL(kind, table, []string{"id", "payload"}, gen.Columns()))
+	if err != nil {
+		return Result{}, err
+	}
+	defer insertStmt.Close()
+
+	ids := make([]string, 0, cfg.Rows)
+	inserted, insertSec, err := insertLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		id := key(cfg, i)
+		ids = append(ids, id)
+		_, err := insertStmt.ExecContext(ctx, insertArgs(gen, i, id)...)
+		return err
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	if err := analyzeTable(ctx, db, cfg, log, kind, table); err != nil {
+		return Result{}, err
+	}
+
+	sample := ids
+	if len(sample) > cfg.Lookups {
+		sample = sample[:cfg.Lookups]
+	}
+	selectStmt, err := prepare(ctx, db, cfg, "SELECT payload FROM "+table+" WHERE id = "+placeholders(kind, 1))
+	if err != nil {
+		return Result{}, err
+	}
+	defer selectStmt.Close()
+
+	// Point Lookup 計測: 文字列キーの完全一致検索。
+	point, err := pointLoop(ctx, cfg, log, len(sample), func(ctx context.Context, i int) error {
+		return scanPayload(cfg, gen, i, sample[i], selectStmt.QueryRowContext(ctx, sample[i]))
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	hotSec, coldSec, err := hotColdLoop(ctx, cfg, log, ids, func(ctx context.Context, id string) error {
+		var payload sql.NullString
+		return selectStmt.QueryRowContext(ctx, id).Scan(&payload)
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	log.Debug("range scan start")
+	_, endRange := startSpan(ctx, "range")
+	var rangeBytes int64
+	rangeSQL := "SELECT id FROM " + table + " ORDER BY id LIMIT 10000"
+	rctx, cancel := queryContext(ctx, cfg)
+	defer cancel()
+	start := time.Now()
+	rowsRes, err := db.QueryContext(rctx, rangeSQL)
+	if err != nil {
+		return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
+	}
+	for rowsRes.Next() {
+		var id string
+		if err := rowsRes.Scan(&id); err != nil {
+			rowsRes.Close()
+			return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
+		}
+		rangeBytes += valueBytes(id)
+	}
+	rowsRes.Close()
+	rangeSec := time.Since(start).Seconds()
+	log.Info("range scan done", "sec", rangeSec)
+	endRange(nil)
+	rangeUsedIndex, err := explainRange(ctx, db, cfg, log, kind, rangeSQL)
+	if err != nil {
+		return Result{}, err
+	}
+
+	// Insert→Readback 計測: キーは挿入前に手元で決まるため、挿入後すぐそのキーで読み戻せる。
+	readbackSec, err := readbackLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		id := key(cfg, inserted+i)
+		if _, err := insertStmt.ExecContext(ctx, insertArgs(gen, inserted+i, id)...); err != nil {
+			return err
+		}
+		var payload sql.NullString
+		return selectStmt.QueryRowContext(ctx, id).Scan(&payload)
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		DB:                    kind,
+		Table:                 table,
+		InsertRows:            inserted,
+		InsertSeconds:         insertSec,
+		PointLookupCount:      len(sample),
+		PointSeconds:          point.Seconds,
+		PointRounds:           point.Rounds,
+		PointWarmSeconds:      point.Warm,
+		PointSteadySeconds:    point.Steady,
+		HotPointSeconds:       hotSec,
+		ColdPointSeconds:      coldSec,
+		RangeSeconds:          rangeSec,
+		RangeBytes:            rangeBytes,
+		RangeUsedIndex:        rangeUsedIndex,
+		InsertReadbackSeconds: readbackSec,
+	}, nil
+}
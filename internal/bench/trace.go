@@ -0,0 +1,206 @@
+package bench
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// otlpTracesPath は OTLP/HTTP でトレースを受け付けるパス。
+const otlpTracesPath = "/v1/traces"
+
+// Tracer は計測フェーズのスパンを溜め、OTLP/HTTP (JSON) のコレクタへまとめて送る。
+// 計測中は送信せず、Flush で一括送信するためフェーズの計測時間にネットワーク往復が混ざらない。
+// OpenTelemetry SDK には依存せず、OTLP の JSON 表現を直接組み立てる。
+type Tracer struct {
+	endpoint string
+	client   *http.Client
+	traceID  string
+
+	mu    sync.Mutex
+	spans []otlpSpan
+}
+
+// NewTracer は endpoint（例 http://localhost:4318）へ送る Tracer を返す。
+// endpoint が /v1/traces で終わっていなければ付け加える。1 回の実行は 1 つのトレースにまとめる。
+func NewTracer(endpoint string) *Tracer {
+	endpoint = strings.TrimSuffix(endpoint, "/")
+	if !strings.HasSuffix(endpoint, otlpTracesPath) {
+		endpoint += otlpTracesPath
+	}
+	return &Tracer{endpoint: endpoint, client: &http.Client{Timeout: 10 * time.Second}, traceID: randomHex(16)}
+}
+
+type tracerKey struct{}
+
+// spanKey は ctx に載せる現在のスパン（子スパンの親）のキー。
+type spanKey struct{}
+
+// activeSpan は子スパンへ引き継ぐ親スパンの ID と属性。
+type activeSpan struct {
+	id    string
+	attrs []string
+}
+
+// WithTracer は t を ctx に載せる。載っていなければ startSpan は何もしない。
+func WithTracer(ctx context.Context, t *Tracer) context.Context {
+	return context.WithValue(ctx, tracerKey{}, t)
+}
+
+// startSpan は ctx の現在スパンの子として name のスパンを開始し、子スパン用の ctx と終了関数を返す。
+// attrs はキーと値を交互に並べた文字列で、親スパンの属性（db / table など）も引き継ぐ。
+// 終了関数へ渡したエラーはスパンのステータスに記録する。Tracer がなければ ctx をそのまま返す。
+// ctx に cpuMeter が載っていれば、スパンの間のクライアント CPU 秒数を name のフェーズに加える。
+func startSpan(ctx context.Context, name string, attrs ...string) (context.Context, func(error)) {
+	stopCPU := measureCPU(ctx, name)
+	t, _ := ctx.Value(tracerKey{}).(*Tracer)
+	if t == nil {
+		return ctx, func(error) { stopCPU() }
+	}
+	parent, _ := ctx.Value(spanKey{}).(activeSpan)
+	span := activeSpan{id: randomHex(8), attrs: append(append([]string(nil), parent.attrs...), attrs...)}
+	start := time.Now()
+	return context.WithValue(ctx, spanKey{}, span), func(err error) {
+		stopCPU()
+		t.record(otlpSpan{
+			TraceID:      t.traceID,
+			SpanID:       span.id,
+			ParentSpanID: parent.id,
+			Name:         name,
+			Kind:         1, // SPAN_KIND_INTERNAL
+			Start:        strconv.FormatInt(start.UnixNano(), 10),
+			End:          strconv.FormatInt(time.Now().UnixNano(), 10),
+			Attributes:   otlpAttributes(span.attrs),
+			Status:       otlpStatusFor(err),
+		})
+	}
+}
+
+// record は終了したスパンを送信待ちに加える。並列ワーカーからも呼べる。
+func (t *Tracer) record(s otlpSpan) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.spans = append(t.spans, s)
+}
+
+// Flush は溜まったスパンをコレクタへ送り、送信待ちを空にする。スパンがなければ何もしない。
+func (t *Tracer) Flush(ctx context.Context) error {
+	t.mu.Lock()
+	spans := t.spans
+	t.spans = nil
+	t.mu.Unlock()
+	if len(spans) == 0 {
+		return nil
+	}
+	body, err := json.Marshal(otlpRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource:   otlpResource{Attributes: otlpAttributes([]string{"service.name", "benchmark_ids"})},
+		ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: "uuid-vs-autoincreament/internal/bench"}, Spans: spans}},
+	}}})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("otlp export to %s: %s", t.endpoint, resp.Status)
+	}
+	return nil
+}
+
+// randomHex は n バイトの乱数を 16 進文字列で返す。トレース ID / スパン ID に使う。
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// 以下は OTLP/HTTP の JSON 表現（ExportTraceServiceRequest）のうち、使う部分だけを写したもの。
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID      string          `json:"traceId"`
+	SpanID       string          `json:"spanId"`
+	ParentSpanID string          `json:"parentSpanId,omitempty"`
+	Name         string          `json:"name"`
+	Kind         int             `json:"kind"`
+	Start        string          `json:"startTimeUnixNano"`
+	End          string          `json:"endTimeUnixNano"`
+	Attributes   []otlpAttribute `json:"attributes,omitempty"`
+	Status       otlpStatus      `json:"status"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// otlpAttributes はキーと値を交互に並べた kv を OTLP の属性にする。後に出たキーが優先される。
+func otlpAttributes(kv []string) []otlpAttribute {
+	var out []otlpAttribute
+	for i := 0; i+1 < len(kv); i += 2 {
+		replaced := false
+		for j := range out {
+			if out[j].Key == kv[i] {
+				out[j].Value.StringValue = kv[i+1]
+				replaced = true
+			}
+		}
+		if !replaced {
+			out = append(out, otlpAttribute{Key: kv[i], Value: otlpValue{StringValue: kv[i+1]}})
+		}
+	}
+	return out
+}
+
+// otlpStatusFor は err があれば STATUS_CODE_ERROR、なければ未設定のステータスを返す。
+func otlpStatusFor(err error) otlpStatus {
+	if err == nil {
+		return otlpStatus{}
+	}
+	return otlpStatus{Code: 2, Message: err.Error()}
+}
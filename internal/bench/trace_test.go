@@ -0,0 +1,72 @@
+package bench
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTracer(t *testing.T) {
+	t.Run("トレーサーなし_何もしない", func(t *testing.T) {
+		ctx := context.Background()
+		got, end := startSpan(ctx, "insert")
+		end(nil)
+		if got != ctx {
+			t.Fatal("startSpan without tracer must return ctx unchanged")
+		}
+	})
+
+	t.Run("スパン_親子関係と属性を引き継いでOTLPで送る", func(t *testing.T) {
+		var req otlpRequest
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != otlpTracesPath || r.Header.Get("Content-Type") != "application/json" {
+				t.Errorf("path = %s, content-type = %s", r.URL.Path, r.Header.Get("Content-Type"))
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Error(err)
+			}
+		}))
+		defer srv.Close()
+
+		tr := NewTracer(srv.URL + "/")
+		ctx, endTable := startSpan(WithTracer(context.Background(), tr), "bench_auto", "db", "mysql", "table", "bench_auto")
+		_, endInsert := startSpan(ctx, "insert")
+		endInsert(errors.New("boom"))
+		endTable(nil)
+		if err := tr.Flush(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+
+		spans := req.ResourceSpans[0].ScopeSpans[0].Spans
+		if len(spans) != 2 {
+			t.Fatalf("spans = %+v", spans)
+		}
+		insert, table := spans[0], spans[1]
+		if insert.Name != "insert" || insert.ParentSpanID != table.SpanID || insert.TraceID != table.TraceID || table.ParentSpanID != "" {
+			t.Fatalf("insert = %+v, table = %+v", insert, table)
+		}
+		if len(insert.Attributes) != 2 || insert.Attributes[1].Key != "table" || insert.Attributes[1].Value.StringValue != "bench_auto" {
+			t.Fatalf("insert attributes = %+v", insert.Attributes)
+		}
+		if insert.Status.Code != 2 || insert.Status.Message != "boom" || table.Status.Code != 0 {
+			t.Fatalf("status insert = %+v, table = %+v", insert.Status, table.Status)
+		}
+	})
+
+	t.Run("送信失敗_ステータスをエラーにする", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer srv.Close()
+
+		tr := NewTracer(srv.URL + otlpTracesPath)
+		_, end := startSpan(WithTracer(context.Background(), tr), "setup")
+		end(nil)
+		if err := tr.Flush(context.Background()); err == nil {
+			t.Fatal("want error for 400 response")
+		}
+	})
+}
@@ -0,0 +1,118 @@
+package bench
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tuiRefresh は進捗だけが変わったときに画面を描き直す最短間隔。
+const tuiRefresh = 100 * time.Millisecond
+
+// tuiBarWidth は進捗バーの幅（文字数）。
+const tuiBarWidth = 30
+
+// TUI は -tui 指定時に端末へ、計測中の方式の進捗バーと終わった方式の結果を描き続ける。
+// 描き直しは前回描いた行までカーソルを戻して消す ANSI エスケープで行うため、w は端末を想定する。
+// Runner の OnProgress / OnResult から呼ぶ。
+type TUI struct {
+	mu      sync.Mutex
+	w       io.Writer
+	now     func() time.Time
+	done    []string
+	current *Progress
+	started time.Time
+	drawn   int
+	last    time.Time
+}
+
+// NewTUI は w へ描画する TUI を作る。
+func NewTUI(w io.Writer) *TUI {
+	return &TUI{w: w, now: time.Now}
+}
+
+// Progress は計測中のフェーズの進捗を受け取り、前回の描画から tuiRefresh 以上経っていれば描き直す。
+// 別の方式・フェーズに移ったときとフェーズの最後は間隔によらず描き直す。
+func (t *TUI) Progress(p Progress) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.now()
+	switched := t.current == nil || t.current.DB != p.DB || t.current.Server != p.Server || t.current.Table != p.Table || t.current.Phase != p.Phase
+	if switched {
+		t.started = now
+	}
+	t.current = &p
+	if !switched && p.Done != p.Total && now.Sub(t.last) < tuiRefresh {
+		return
+	}
+	t.draw(now)
+}
+
+// Result は終わった方式の結果を一覧に加えて描き直す。
+func (t *TUI) Result(r Result) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.done = append(t.done, tuiResultLine(r))
+	t.current = nil
+	t.draw(t.now())
+}
+
+// Close は進捗バーを消し、終わった方式の一覧だけを残す。以降の出力はその下に続く。
+func (t *TUI) Close() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.current = nil
+	t.draw(t.now())
+}
+
+// draw は前回描いた行を消して、終わった方式の一覧と計測中のフェーズを描く。
+func (t *TUI) draw(now time.Time) {
+	var b strings.Builder
+	if t.drawn > 0 {
+		// 前回の先頭行へ戻り、そこから下を消す。
+		fmt.Fprintf(&b, "\x1b[%dF\x1b[J", t.drawn)
+	}
+	lines := t.done
+	if t.current != nil {
+		lines = append(lines[:len(lines):len(lines)], tuiProgressLine(*t.current, now.Sub(t.started)))
+	}
+	for _, l := range lines {
+		b.WriteString(l + "\n")
+	}
+	io.WriteString(t.w, b.String())
+	t.drawn = len(lines)
+	t.last = now
+}
+
+// tuiTarget は DB と接続先ラベル（あれば）を 1 つの表示名にする。
+func tuiTarget(db, server, table string) string {
+	if server != "" {
+		db += "[" + server + "]"
+	}
+	return db + " " + table
+}
+
+// tuiProgressLine は計測中のフェーズを 1 行にする。
+// 例: "mysql bench_uuid_bin insert [###############---------------]  50% 25000/50000 3.2s"。
+// 予定件数がなければ（-insert-duration）バーの代わりに件数だけを出す。
+func tuiProgressLine(p Progress, elapsed time.Duration) string {
+	head := tuiTarget(p.DB, p.Server, p.Table) + " " + p.Phase
+	sec := fmt.Sprintf("%.1fs", elapsed.Seconds())
+	if p.Total <= 0 {
+		return fmt.Sprintf("%s %d %s", head, p.Done, sec)
+	}
+	filled := min(p.Done*tuiBarWidth/p.Total, tuiBarWidth)
+	bar := strings.Repeat("#", filled) + strings.Repeat("-", tuiBarWidth-filled)
+	return fmt.Sprintf("%s [%s] %3d%% %d/%d %s", head, bar, p.Done*100/p.Total, p.Done, p.Total, sec)
+}
+
+// tuiResultLine は終わった方式の結果を 1 行にする。失敗した方式はエラーを出す。
+func tuiResultLine(r Result) string {
+	head := tuiTarget(r.DB, r.Server, r.Table)
+	if r.Err != "" {
+		return "fail " + head + ": " + r.Err
+	}
+	return fmt.Sprintf("ok   %s insert %.3fs, point %.3fs, range %.3fs", head, r.InsertSeconds, r.PointSeconds, r.RangeSeconds)
+}
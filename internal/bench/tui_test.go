@@ -0,0 +1,68 @@
+package bench
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTUIProgressLine(t *testing.T) {
+	t.Run("予定件数があればバーと割合を出す", func(t *testing.T) {
+		got := tuiProgressLine(Progress{DB: "mysql", Table: "bench_uuid_bin", Phase: "insert", Done: 25000, Total: 50000}, 3200*time.Millisecond)
+		want := "mysql bench_uuid_bin insert [###############---------------]  50% 25000/50000 3.2s"
+		if got != want {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("予定件数がなければ件数だけ", func(t *testing.T) {
+		got := tuiProgressLine(Progress{DB: "postgres", Server: "pg16", Table: "bench_uuid", Phase: "insert", Done: 7000}, time.Second)
+		if got != "postgres[pg16] bench_uuid insert 7000 1.0s" {
+			t.Fatalf("got %q", got)
+		}
+	})
+}
+
+func TestTUI(t *testing.T) {
+	t.Run("描き直しは前回の行を消してから描く", func(t *testing.T) {
+		var b strings.Builder
+		now := time.Unix(0, 0)
+		tui := NewTUI(&b)
+		tui.now = func() time.Time { return now }
+		tui.Progress(Progress{DB: "mysql", Table: "bench_auto", Phase: "insert", Done: 1000, Total: 2000})
+		// 間隔内の途中経過は描かない。
+		tui.Progress(Progress{DB: "mysql", Table: "bench_auto", Phase: "insert", Done: 1500, Total: 2000})
+		if strings.Count(b.String(), "\n") != 1 {
+			t.Fatalf("throttled progress was drawn: %q", b.String())
+		}
+		tui.Result(Result{DB: "mysql", Table: "bench_auto", InsertSeconds: 1.5})
+		tui.Close()
+		out := b.String()
+		if !strings.Contains(out, "\x1b[1F\x1b[J") || !strings.HasSuffix(out, "\x1b[1F\x1b[Jok   mysql bench_auto insert 1.500s, point 0.000s, range 0.000s\n") {
+			t.Fatalf("got %q", out)
+		}
+	})
+}
+
+func TestProgressReporter(t *testing.T) {
+	t.Run("通知先がなければ nil", func(t *testing.T) {
+		if progressReporter(context.Background(), "insert", 10) != nil {
+			t.Fatal("expected nil reporter")
+		}
+	})
+
+	t.Run("挿入の進捗を間引いて最後は必ず渡す", func(t *testing.T) {
+		var got []Progress
+		ctx := withProgressTable(withProgress(context.Background(), func(p Progress) { got = append(got, p) }, "mysql", "a"), "bench_auto")
+		cfg := DefaultConfig()
+		cfg.Rows = 2500
+		if _, _, err := insertLoop(ctx, cfg, slog.Default(), func(context.Context, int) error { return nil }); err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 3 || got[2] != (Progress{DB: "mysql", Server: "a", Table: "bench_auto", Phase: "insert", Done: 2500, Total: 2500}) {
+			t.Fatalf("got %+v", got)
+		}
+	})
+}
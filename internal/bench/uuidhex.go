@@ -0,0 +1,38 @@
+package bench
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// uuidHexLen は UUID をハイフンなしの 16 進にした長さ。
+const uuidHexLen = 32
+
+// EncodeUUIDHex は u をハイフンなしの小文字 16 進（32 文字）にする。
+// u.String() から "-" を取り除いたものと同じで、CHAR(36) より 4 文字短い。
+func EncodeUUIDHex(u uuid.UUID) string {
+	return hex.EncodeToString(u[:])
+}
+
+// DecodeUUIDHex は EncodeUUIDHex の出力を UUID へ戻す。大文字の 16 進も受け付ける。
+// ハイフン付きの 36 文字など、長さや文字が不正な入力は明示的にエラーを返す。
+func DecodeUUIDHex(s string) (uuid.UUID, error) {
+	if len(s) != uuidHexLen {
+		return uuid.Nil, fmt.Errorf("hex uuid length must be %d, got %d", uuidHexLen, len(s))
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("hex uuid %q: %w", s, err)
+	}
+	return BytesToUUID(b)
+}
+
+// benchMySQLUUIDChar32 は UUID をハイフンなしの 32 文字で保存する CHAR(32) 主キー (bench_uuid_char32) を計測する。
+// 照合順序は bench_uuid_char と同じ -char-collation に従い、ハイフンの有無だけを比べられるようにする。
+func benchMySQLUUIDChar32(ctx context.Context, db *sql.DB, cfg Config) (Result, error) {
+	return benchUUIDText(ctx, db, cfg, "mysql", "bench_uuid_char32", uuidHexKey)
+}
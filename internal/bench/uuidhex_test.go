@@ -0,0 +1,44 @@
+package bench
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestUUIDHex(t *testing.T) {
+	t.Run("16進_ハイフンを除いた32文字で往復できる", func(t *testing.T) {
+		for _, u := range []uuid.UUID{uuid.Nil, uuidBytesProbe, uuid.MustParse("6af613b6-569c-5c22-9c37-2ed93f31d3af"), uuid.New()} {
+			s := EncodeUUIDHex(u)
+			if want := strings.ReplaceAll(u.String(), "-", ""); s != want {
+				t.Fatalf("EncodeUUIDHex(%s) = %q, want %q", u, s, want)
+			}
+			got, err := DecodeUUIDHex(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != u {
+				t.Fatalf("round trip = %s, want %s", got, u)
+			}
+		}
+	})
+
+	t.Run("16進_大文字も読める", func(t *testing.T) {
+		got, err := DecodeUUIDHex("6AF613B6569C5C229C372ED93F31D3AF")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := uuid.MustParse("6af613b6-569c-5c22-9c37-2ed93f31d3af"); got != want {
+			t.Fatalf("DecodeUUIDHex = %s, want %s", got, want)
+		}
+	})
+
+	t.Run("16進_不正な入力はエラー", func(t *testing.T) {
+		for _, s := range []string{"", "abc", "6af613b6-569c-5c22-9c37-2ed93f31d3af", "6af613b6569c5c229c372ed93f31d3ag"} {
+			if _, err := DecodeUUIDHex(s); err == nil {
+				t.Fatalf("DecodeUUIDHex(%q) error = nil, want error", s)
+			}
+		}
+	})
+}
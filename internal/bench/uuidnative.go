@@ -0,0 +1,142 @@
+package bench
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// nativeUUIDMinMySQLVersion は UUID_TO_BIN が使える MySQL のバージョン。
+const nativeUUIDMinMySQLVersion = "8.0"
+
+// nativeUUIDInsertSQL は主キーをサーバ側の UUID_TO_BIN(UUID()) で作り、payload と cols だけを
+// プレースホルダで渡す table への INSERT 文を返す。
+func nativeUUIDInsertSQL(table string, cols []ColumnSpec) string {
+	names := []string{"id", "payload"}
+	for _, c := range cols {
+		names = append(names, c.Name)
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (UUID_TO_BIN(UUID()), %s)", table, strings.Join(names, ", "), placeholders("mysql", len(names)-1))
+}
+
+// nativeUUIDSampleSeed は bench_uuid_bin_native の点検索サンプルを並べ替えるシード。
+const nativeUUIDSampleSeed = 20261001
+
+// nativeUUIDSample は主キー順に読み出した ids から点検索に使う最大 k 件を選ぶ。
+// 先頭から取ると隣り合う少数のリーフページに収まり、挿入順のキーを引く bench_uuid_bin より有利になるため、
+// 全体から等間隔に選んでシード固定で並べ替える。
+func nativeUUIDSample(ids [][]byte, k int) [][]byte {
+	idx := SpreadSample(len(ids), k, nativeUUIDSampleSeed)
+	sample := make([][]byte, len(idx))
+	for i, j := range idx {
+		sample[i] = ids[j]
+	}
+	return sample
+}
+
+// benchMySQLUUIDNative は UUID の生成と 16 バイトへの変換を INSERT の中の UUID_TO_BIN(UUID()) で
+// サーバに任せ、BINARY(16) 主キー (bench_uuid_bin_native) を計測する。クライアントで uuid.New() と
+// UUIDToBytes を行う bench_uuid_bin と並べ、変換をサーバへ寄せて挿入の速さが変わるかを見る。
+// MySQL の UUID() は UUIDv1 で、入れ替えなしの UUID_TO_BIN は time_low が先頭になるため
+// 挿入位置は bench_uuid_v1 と同じくランダムに近い。キーは手元に残らないため、bench_auto と同じく
+// 挿入後に主キー順で読み出し、nativeUUIDSample で全体から選んだキーで点検索する。Hot/Cold と Insert→Readback は計測しない。
+func benchMySQLUUIDNative(ctx context.Context, db *sql.DB, cfg Config) (Result, error) {
+	const table = "bench_uuid_bin_native"
+	log := slog.With("db", "mysql", "table", table)
+	gen := payloadFor(cfg)
+	insertStmt, err := prepare(ctx, db, cfg, nativeUUIDInsertSQL(table, gen.Columns()))
+	if err != nil {
+		return Result{}, err
+	}
+	defer insertStmt.Close()
+
+	// 主キーはサーバが作るため、payload と追加カラムだけを渡す。
+	inserted, insertSec, err := insertLoop(ctx, cfg, log, func(ctx context.Context, i int) error {
+		_, err := insertStmt.ExecContext(ctx, insertArgs(gen, i)...)
+		return err
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	if err := analyzeTable(ctx, db, cfg, log, "mysql", table); err != nil {
+		return Result{}, err
+	}
+
+	// 参照用のキー一覧を主キー順で収集する。
+	ids := make([][]byte, 0, inserted)
+	rowsRes, err := db.QueryContext(ctx, "SELECT id FROM "+table+" ORDER BY id")
+	if err != nil {
+		return Result{}, err
+	}
+	for rowsRes.Next() {
+		var id []byte
+		if err := rowsRes.Scan(&id); err != nil {
+			rowsRes.Close()
+			return Result{}, err
+		}
+		ids = append(ids, id)
+	}
+	rowsRes.Close()
+
+	sample := nativeUUIDSample(ids, cfg.Lookups)
+	selectStmt, err := prepare(ctx, db, cfg, "SELECT payload FROM "+table+" WHERE id = ?")
+	if err != nil {
+		return Result{}, err
+	}
+	defer selectStmt.Close()
+
+	// Point Lookup 計測: BINARY(16) キーの完全一致検索。
+	point, err := pointLoop(ctx, cfg, log, len(sample), func(ctx context.Context, i int) error {
+		var payload sql.NullString
+		return selectStmt.QueryRowContext(ctx, sample[i]).Scan(&payload)
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	log.Debug("range scan start")
+	_, endRange := startSpan(ctx, "range")
+	var rangeBytes int64
+	rctx, cancel := queryContext(ctx, cfg)
+	defer cancel()
+	// 範囲代替として ORDER BY + LIMIT の読み出し時間を計測する。
+	start := time.Now()
+	rangeRows, err := db.QueryContext(rctx, "SELECT id FROM "+table+" ORDER BY id LIMIT 10000")
+	if err != nil {
+		return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
+	}
+	for rangeRows.Next() {
+		var b []byte
+		if err := rangeRows.Scan(&b); err != nil {
+			rangeRows.Close()
+			return Result{}, queryTimeoutError(ctx, rctx, cfg, err)
+		}
+		rangeBytes += valueBytes(b)
+	}
+	rangeRows.Close()
+	rangeSec := time.Since(start).Seconds()
+	log.Info("range scan done", "sec", rangeSec)
+	endRange(nil)
+	rangeUsedIndex, err := explainRange(ctx, db, cfg, log, "mysql", "SELECT id FROM "+table+" ORDER BY id LIMIT 10000")
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		DB:                 "mysql",
+		Table:              table,
+		InsertRows:         inserted,
+		InsertSeconds:      insertSec,
+		PointLookupCount:   len(sample),
+		PointSeconds:       point.Seconds,
+		PointRounds:        point.Rounds,
+		PointWarmSeconds:   point.Warm,
+		PointSteadySeconds: point.Steady,
+		RangeSeconds:       rangeSec,
+		RangeBytes:         rangeBytes,
+		RangeUsedIndex:     rangeUsedIndex,
+	}, nil
+}
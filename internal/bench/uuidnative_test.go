@@ -0,0 +1,49 @@
+package bench
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNativeUUIDInsertSQL(t *testing.T) {
+	t.Run("SQL_主キーはUUID_TO_BINで作り残りだけを渡す", func(t *testing.T) {
+		got := nativeUUIDInsertSQL("bench_uuid_bin_native", nil)
+		want := "INSERT INTO bench_uuid_bin_native (id, payload) VALUES (UUID_TO_BIN(UUID()), ?)"
+		if got != want {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("SQL_追加カラムのプレースホルダを続ける", func(t *testing.T) {
+		cols := []ColumnSpec{{Name: "c1"}, {Name: "c2"}}
+		got := nativeUUIDInsertSQL("bench_uuid_bin_native", cols)
+		want := "INSERT INTO bench_uuid_bin_native (id, payload, c1, c2) VALUES (UUID_TO_BIN(UUID()), ?, ?, ?)"
+		if got != want {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	})
+}
+
+func TestNativeUUIDSample(t *testing.T) {
+	t.Run("点検索サンプル_主キー順の先頭に偏らない", func(t *testing.T) {
+		ids := make([][]byte, 1000)
+		for i := range ids {
+			ids[i] = []byte{byte(i >> 8), byte(i)}
+		}
+		got := nativeUUIDSample(ids, 10)
+		if len(got) != 10 {
+			t.Fatalf("len = %d, want 10", len(got))
+		}
+		// 先頭 10 件でなく、後半のキーも含む。
+		if !slices.ContainsFunc(got, func(id []byte) bool { return int(id[0])<<8|int(id[1]) >= 500 }) {
+			t.Fatalf("sample = %v, want keys from the whole range", got)
+		}
+	})
+
+	t.Run("点検索サンプル_件数がlookups未満なら全件", func(t *testing.T) {
+		ids := [][]byte{{1}, {2}, {3}}
+		if got := nativeUUIDSample(ids, 10); len(got) != 3 {
+			t.Fatalf("len = %d, want 3", len(got))
+		}
+	})
+}
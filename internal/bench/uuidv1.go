@@ -0,0 +1,41 @@
+package bench
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+// v1Clock は uuidV1Key で作ったキーの時刻（100ns 単位）の逆行を数える。
+// uuid.NewUUID はシステム時刻が戻ると clock sequence を変えて重複を避けるだけで、時刻はそのまま埋め込む。
+var v1Clock keyClock
+
+// uuidV1Key は UUIDv1 方式の i 行目のキーを返す。時刻とノード ID（MAC アドレス、取れなければ乱数）から
+// 作るため -uuid-v5-namespace は効かない。
+// uuid.NewUUID が失敗するのは乱数源が読めないときだけで、その場合は uuid.New と同じく panic する。
+func uuidV1Key(Config, int) uuid.UUID {
+	u := uuid.Must(uuid.NewUUID())
+	v1Clock.observe(int64(u.Time()))
+	return u
+}
+
+// benchMySQLUUIDv1 は UUIDv1 を生成したままのバイト順で BINARY(16) 主キー (bench_uuid_v1) に入れて計測する。
+// v1 の先頭は 100ns 単位の時刻の下位 32 ビット（time_low）なので、約 7 分ごとに一巡して並びが崩れる。
+func benchMySQLUUIDv1(ctx context.Context, db *sql.DB, cfg Config) (Result, error) {
+	return withTimeRegressions(v1Clock.count, func() (Result, error) {
+		return benchMySQLUUIDBinary(ctx, db, cfg, "bench_uuid_v1", func(cfg Config, i int) []byte {
+			return UUIDToBytes(uuidV1Key(cfg, i))
+		})
+	})
+}
+
+// benchMySQLUUIDv1Swapped は UUIDv1 を UUID_TO_BIN(uuid, 1) の並び（time_hi / time_mid を先頭）で
+// BINARY(16) 主キー (bench_uuid_v1_swapped) に入れて計測する。時刻の上位が先頭に来るため挿入順にほぼ並ぶ。
+func benchMySQLUUIDv1Swapped(ctx context.Context, db *sql.DB, cfg Config) (Result, error) {
+	return withTimeRegressions(v1Clock.count, func() (Result, error) {
+		return benchMySQLUUIDBinary(ctx, db, cfg, "bench_uuid_v1_swapped", func(cfg Config, i int) []byte {
+			return UUIDToSwappedBytes(uuidV1Key(cfg, i))
+		})
+	})
+}
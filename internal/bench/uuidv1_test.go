@@ -0,0 +1,27 @@
+package bench
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestUUIDv1Key(t *testing.T) {
+	t.Run("UUIDv1_バージョン1を返す", func(t *testing.T) {
+		if u := uuidV1Key(DefaultConfig(), 0); u.Version() != 1 {
+			t.Fatalf("version = %d", u.Version())
+		}
+	})
+
+	t.Run("UUIDv1_並べ替えたバイト順の時刻部分は生成順に減らない", func(t *testing.T) {
+		// 時刻の上位を先頭へ移すと、同じ時計から連続で作ったキーの先頭 8 バイトは単調非減少になる。
+		// 100ns 以内に続けて作ると時刻は同じまま clock sequence が変わる。
+		prev := UUIDToSwappedBytes(uuidV1Key(DefaultConfig(), 0))
+		for i := 1; i < 1000; i++ {
+			b := UUIDToSwappedBytes(uuidV1Key(DefaultConfig(), i))
+			if bytes.Compare(b[:8], prev[:8]) < 0 {
+				t.Fatalf("key %d went backwards: %x < %x", i, b[:8], prev[:8])
+			}
+			prev = b
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package bench
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// ErrPayloadMismatch は -sample-payload-verification で、点検索が返した payload がそのキーで
+// 挿入した値と違ったことを表す。ドライバやキーの変換の不具合で別の行を読んでいる疑いがある。
+var ErrPayloadMismatch = errors.New("point lookup returned a different payload than was inserted for the key")
+
+// scanPayload は点検索の結果 row から payload を読む。cfg.VerifyPayloadEvery が正で i がその倍数なら、
+// 読んだ値が i 行目に key で gen から挿入した payload と一致するかを確かめ、違えば ErrPayloadMismatch を返す。
+// 計測ループの i 番目の検索が i 行目のキーを引く方式でだけ使う。
+func scanPayload(cfg Config, gen PayloadGenerator, i int, key any, row *sql.Row) error {
+	var payload sql.NullString
+	if err := row.Scan(&payload); err != nil {
+		return err
+	}
+	if cfg.VerifyPayloadEvery <= 0 || i%cfg.VerifyPayloadEvery != 0 {
+		return nil
+	}
+	return checkPayload(i, key, payloadValue(gen, i), payload)
+}
+
+// checkPayload は i 行目の key で読んだ got が、挿入した want と一致するかを返す。
+// want が nil なら NULL を、それ以外は文字列としての一致を求める。
+func checkPayload(i int, key, want any, got sql.NullString) error {
+	if want == nil {
+		if !got.Valid {
+			return nil
+		}
+	} else if got.Valid && got.String == payloadText(want) {
+		return nil
+	}
+	gotText := "NULL"
+	if got.Valid {
+		gotText = fmt.Sprintf("%q", got.String)
+	}
+	wantText := "NULL"
+	if want != nil {
+		wantText = fmt.Sprintf("%q", payloadText(want))
+	}
+	return fmt.Errorf("%w: row %d key %s: got %s, want %s", ErrPayloadMismatch, i, keyText(key), gotText, wantText)
+}
+
+// payloadText は PayloadGenerator が返した payload の値を、読み出したときの文字列にする。
+func payloadText(v any) string {
+	if b, ok := v.([]byte); ok {
+		return string(b)
+	}
+	return fmt.Sprint(v)
+}
+
+// keyText はエラーに出すキーの表記。BINARY(16) などのバイト列は 16 進にする。
+func keyText(key any) string {
+	if b, ok := key.([]byte); ok {
+		return fmt.Sprintf("%x", b)
+	}
+	return fmt.Sprint(key)
+}
@@ -0,0 +1,71 @@
+package bench
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheckPayload(t *testing.T) {
+	t.Run("照合_一致すればnil", func(t *testing.T) {
+		if err := checkPayload(3, int64(4), "p-3", sql.NullString{String: "p-3", Valid: true}); err != nil {
+			t.Fatalf("checkPayload = %v, want nil", err)
+		}
+	})
+
+	t.Run("照合_別の行ならErrPayloadMismatch", func(t *testing.T) {
+		err := checkPayload(3, []byte{0xab, 0xcd}, "p-3", sql.NullString{String: "p-7", Valid: true})
+		if !errors.Is(err, ErrPayloadMismatch) {
+			t.Fatalf("checkPayload = %v, want ErrPayloadMismatch", err)
+		}
+		if !strings.Contains(err.Error(), "key abcd") {
+			t.Fatalf("error = %q, want hex key", err)
+		}
+	})
+
+	t.Run("照合_NULLの行はNULLだけを受け付ける", func(t *testing.T) {
+		if err := checkPayload(0, "k", nil, sql.NullString{}); err != nil {
+			t.Fatalf("checkPayload(NULL) = %v, want nil", err)
+		}
+		if err := checkPayload(0, "k", nil, sql.NullString{String: "p-0", Valid: true}); !errors.Is(err, ErrPayloadMismatch) {
+			t.Fatalf("checkPayload(value for NULL) = %v, want ErrPayloadMismatch", err)
+		}
+		if err := checkPayload(0, "k", "p-0", sql.NullString{}); !errors.Is(err, ErrPayloadMismatch) {
+			t.Fatalf("checkPayload(NULL for value) = %v, want ErrPayloadMismatch", err)
+		}
+	})
+
+	t.Run("照合_バイト列のpayloadは文字列として比べる", func(t *testing.T) {
+		if err := checkPayload(1, "k", []byte("p-1"), sql.NullString{String: "p-1", Valid: true}); err != nil {
+			t.Fatalf("checkPayload = %v, want nil", err)
+		}
+	})
+}
+
+func TestValidateConfigPayloadVerification(t *testing.T) {
+	t.Run("照合_正の間隔なら有効", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.VerifyPayloadEvery = 10
+		if err := ValidateConfig(cfg); err != nil {
+			t.Fatalf("ValidateConfig = %v, want nil", err)
+		}
+	})
+
+	t.Run("照合_負の間隔はエラー", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.VerifyPayloadEvery = -1
+		if err := ValidateConfig(cfg); err == nil {
+			t.Fatal("ValidateConfig error = nil, want error")
+		}
+	})
+
+	t.Run("照合_no-setupとは併用できない", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.VerifyPayloadEvery = 10
+		cfg.NoSetup = true
+		if err := ValidateConfig(cfg); err == nil {
+			t.Fatal("ValidateConfig error = nil, want error")
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package bench
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// poolWorkers は同時に接続を使うゴルーチンの最大数。並列挿入・混合負荷を有効にしたときのワーカー数で、
+// どちらも無効なら 1。-metrics-interval のサンプラーも計測ループと同じ接続プールを使うため 1 本足す。
+func poolWorkers(cfg Config) int {
+	n := max(1, cfg.ConcurrentWorkers)
+	if cfg.MixedDuration > 0 {
+		n = max(n, cfg.MixedWorkers)
+	}
+	if cfg.MetricsInterval > 0 {
+		n++
+	}
+	return n
+}
+
+// warmupConns は -connection-warmup で開いておく接続数。-max-open-conns 指定時はその数、
+// 未指定なら poolWorkers。
+func warmupConns(cfg Config) int {
+	if cfg.MaxOpenConns > 0 {
+		return cfg.MaxOpenConns
+	}
+	return poolWorkers(cfg)
+}
+
+// ConfigurePool は cfg.MaxOpenConns が正なら db の同時接続数の上限をその値にする。
+// -connection-warmup 指定時は、開いた接続が返却時に閉じられないようアイドル接続の上限も
+// 開いておく接続数まで上げる（database/sql の既定は 2）。
+func ConfigurePool(db *sql.DB, cfg Config) {
+	if cfg.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(cfg.MaxOpenConns)
+	}
+	if cfg.ConnectionWarmup {
+		db.SetMaxIdleConns(warmupConns(cfg))
+	}
+}
+
+// WarmConnections は cfg.ConnectionWarmup が真なら、db のプールに warmupConns(cfg) 本の接続を同時に開かせ、
+// それぞれで SELECT 1 を実行してから返却する。接続の確立（TCP / TLS のハンドシェイクや認証）にかかる時間が
+// 最初に計測するフェーズへ混ざらないようにする。偽なら何もしない。
+func WarmConnections(ctx context.Context, db *sql.DB, cfg Config) error {
+	if !cfg.ConnectionWarmup {
+		return nil
+	}
+	n := warmupConns(cfg)
+	start := time.Now()
+	// 同時に借りたままにしないと、プールは同じ接続を使い回して n 本開かない。
+	conns := make([]*sql.Conn, 0, n)
+	defer func() {
+		for _, c := range conns {
+			c.Close()
+		}
+	}()
+	for range n {
+		c, err := db.Conn(ctx)
+		if err != nil {
+			return fmt.Errorf("connection warmup: %w", err)
+		}
+		conns = append(conns, c)
+		var one int
+		if err := c.QueryRowContext(ctx, "SELECT 1").Scan(&one); err != nil {
+			return fmt.Errorf("connection warmup: %w", err)
+		}
+	}
+	slog.Debug("connection warmup done", "conns", n, "sec", time.Since(start).Seconds())
+	return nil
+}
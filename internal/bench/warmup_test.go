@@ -0,0 +1,61 @@
+package bench
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWarmupConns(t *testing.T) {
+	t.Run("接続の準備_既定は1本", func(t *testing.T) {
+		if got := warmupConns(DefaultConfig()); got != 1 {
+			t.Fatalf("warmupConns = %d, want 1", got)
+		}
+	})
+
+	t.Run("接続の準備_有効なワーカー数だけ開く", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.ConcurrentWorkers = 3
+		if got := warmupConns(cfg); got != 3 {
+			t.Fatalf("warmupConns = %d, want 3", got)
+		}
+		cfg.MixedDuration = time.Second
+		cfg.MixedWorkers = 6
+		if got := warmupConns(cfg); got != 6 {
+			t.Fatalf("warmupConns = %d, want 6", got)
+		}
+	})
+
+	t.Run("接続の準備_max-open-connsを優先する", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.MaxOpenConns = 8
+		if got := warmupConns(cfg); got != 8 {
+			t.Fatalf("warmupConns = %d, want 8", got)
+		}
+	})
+
+	t.Run("接続の準備_max-open-connsがワーカー数より少なければエラー", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.MaxOpenConns = 2
+		cfg.ConcurrentWorkers = 4
+		if err := ValidateConfig(cfg); err == nil {
+			t.Fatal("ValidateConfig error = nil, want error")
+		}
+	})
+
+	t.Run("接続の準備_metrics-intervalのサンプラーにも1本使う", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.MetricsInterval = time.Second
+		cfg.MetricsDir = t.TempDir()
+		if got := warmupConns(cfg); got != 2 {
+			t.Fatalf("warmupConns = %d, want 2", got)
+		}
+		cfg.MaxOpenConns = 1
+		if err := ValidateConfig(cfg); err == nil {
+			t.Fatal("ValidateConfig error = nil, want error")
+		}
+		cfg.MaxOpenConns = 2
+		if err := ValidateConfig(cfg); err != nil {
+			t.Fatalf("ValidateConfig error = %v, want nil", err)
+		}
+	})
+}